@@ -0,0 +1,74 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Command sonatypeiq-export generates Terraform configuration and import blocks for the
+// Organizations, Applications, Role Memberships and Source Control configurations of an existing
+// Sonatype IQ Server.
+//
+// Usage:
+//
+//	sonatypeiq-export -url https://iq.example.com -username admin -out iq.tf
+//
+// The URL, username and password default to the SONATYPEIQ_URL, SONATYPEIQ_USERNAME and
+// SONATYPEIQ_PASSWORD environment variables, like for the provider.
+package main
+
+import (
+	"context"
+	"flag"
+	"io"
+	"log"
+	"os"
+	"terraform-provider-sonatypeiq/internal/provider"
+)
+
+var (
+	// these will be set by the goreleaser configuration
+	// to appropriate values for the compiled binary.
+	version string = "dev"
+)
+
+func main() {
+	var options provider.ExportOptions
+	var out string
+
+	flag.StringVar(&options.Url, "url", os.Getenv("SONATYPEIQ_URL"), "URL of Sonatype IQ Server")
+	flag.StringVar(&options.Username, "username", os.Getenv("SONATYPEIQ_USERNAME"), "username or User Code to authenticate with")
+	flag.StringVar(&options.Password, "password", os.Getenv("SONATYPEIQ_PASSWORD"), "password or Pass Code to authenticate with")
+	flag.BoolVar(&options.InsecureSkipVerify, "insecure-skip-verify", false, "skip verifying the TLS certificate of Sonatype IQ Server")
+	flag.StringVar(&out, "out", "", "file to write the configuration to - written to stdout when not set")
+	flag.Parse()
+
+	if len(options.Url) == 0 || len(options.Username) == 0 || len(options.Password) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	options.UserAgent = "sonatypeiq-export/" + version
+
+	var w io.Writer = os.Stdout
+	if len(out) > 0 {
+		file, err := os.Create(out)
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+		defer file.Close()
+		w = file
+	}
+
+	if err := provider.Export(context.Background(), options, w); err != nil {
+		log.Fatal(err.Error())
+	}
+}
@@ -0,0 +1,8 @@
+# Check whether a Policy Violation is already waived
+data "sonatypeiq_applicable_waivers" "violation" {
+  policy_violation_id = "5a2b2e24f0574fb2bd0d5f8ac2e0b3c5"
+}
+
+output "violation_is_waived" {
+  value = data.sonatypeiq_applicable_waivers.violation.is_waived
+}
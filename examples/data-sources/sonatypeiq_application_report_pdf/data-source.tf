@@ -0,0 +1,10 @@
+# Download the PDF report of the latest release evaluation to attach it to the release record
+data "sonatypeiq_application_report_pdf" "release" {
+  application_id = "sandbox-application"
+  stage_id       = "release"
+  output_path    = "${path.root}/release/sonatype-iq-report.pdf"
+}
+
+output "release_report_sha256" {
+  value = data.sonatypeiq_application_report_pdf.release.content_sha256
+}
@@ -1,2 +1,12 @@
 # Get all Applications
-data "sonatypeiq_applications" "apps" {}
\ No newline at end of file
+data "sonatypeiq_applications" "apps" {}
+
+# Get the Applications of an Organization
+data "sonatypeiq_applications" "sandbox" {
+  organization_id = "ROOT_ORGANIZATION_ID"
+}
+
+# Get Applications by Public ID
+data "sonatypeiq_applications" "by_public_id" {
+  public_ids = ["sandbox-application", "my-application"]
+}
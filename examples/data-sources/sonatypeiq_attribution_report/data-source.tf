@@ -0,0 +1,11 @@
+# Generate the attribution report of the latest release evaluation of an Application
+data "sonatypeiq_attribution_report" "sandbox" {
+  application_id = "sandbox-application"
+  stage_id       = "release"
+}
+
+# Publish the attribution report alongside the release
+resource "local_file" "attribution" {
+  filename = "${path.module}/dist/attribution.html"
+  content  = data.sonatypeiq_attribution_report.sandbox.content
+}
@@ -0,0 +1,15 @@
+# Export yesterday's policy changes from the audit log
+locals {
+  yesterday = formatdate("YYYY-MM-DD", timeadd(plantimestamp(), "-24h"))
+}
+
+data "sonatypeiq_audit_log" "policy_changes" {
+  start_date = local.yesterday
+  end_date   = local.yesterday
+  domain     = "governance.policy"
+}
+
+resource "local_file" "policy_changes" {
+  filename = "audit-${local.yesterday}.jsonl"
+  content  = join("\n", [for entry in data.sonatypeiq_audit_log.policy_changes.entries : entry.json])
+}
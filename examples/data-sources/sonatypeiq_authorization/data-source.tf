@@ -0,0 +1,22 @@
+data "sonatypeiq_organization" "sandbox" {
+  name = "Sandbox Organization"
+}
+
+data "sonatypeiq_authorization" "sandbox_write" {
+  organization_id = data.sonatypeiq_organization.sandbox.id
+  permission      = "write"
+  probe           = true
+}
+
+resource "sonatypeiq_application" "example" {
+  name            = "Example Application"
+  public_id       = "example_application"
+  organization_id = data.sonatypeiq_organization.sandbox.id
+
+  lifecycle {
+    precondition {
+      condition     = data.sonatypeiq_authorization.sandbox_write.allowed
+      error_message = "The provider credentials cannot edit the Sandbox Organization: ${coalesce(data.sonatypeiq_authorization.sandbox_write.detail, "")}"
+    }
+  }
+}
@@ -0,0 +1,10 @@
+# Get Component Labels available to the Root Organization
+data "sonatypeiq_component_labels" "root" {
+  organization_id = "ROOT_ORGANIZATION_ID"
+}
+
+# Get Component Labels available to an Application, excluding inherited Labels
+data "sonatypeiq_component_labels" "app" {
+  application_id = "4bb67dcfc86344e3a483832f8c496419"
+  inherit        = false
+}
@@ -0,0 +1,18 @@
+data "sonatypeiq_current_user" "me" {}
+
+# Fail early when the provider is not allowed to manage policies
+check "policy_administrator" {
+  assert {
+    condition     = contains(data.sonatypeiq_current_user.me.roles, "Policy Administrator")
+    error_message = "${coalesce(data.sonatypeiq_current_user.me.username, "The provider user")} is not a Policy Administrator"
+  }
+}
+
+# Roles on an Application, including those inherited from its Organizations
+data "sonatypeiq_current_user" "app" {
+  application_id = "4537e6fe68c24dd5ac83efd97d4fc2f4"
+}
+
+output "application_roles" {
+  value = data.sonatypeiq_current_user.app.roles
+}
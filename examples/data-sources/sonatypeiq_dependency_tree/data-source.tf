@@ -0,0 +1,15 @@
+# Read the dependency tree of the latest build evaluation of the Sandbox Application
+data "sonatypeiq_dependency_tree" "sandbox" {
+  application_id = "sandbox-application"
+  stage_id       = "build"
+}
+
+# Only generate waivers for violations of transitive dependencies, which cannot be upgraded directly
+output "transitive_dependencies" {
+  value = data.sonatypeiq_dependency_tree.sandbox.transitive_package_urls
+}
+
+# Which components depend on each transitive dependency
+output "depended_on_by" {
+  value = { for dependency in data.sonatypeiq_dependency_tree.sandbox.dependencies : dependency.package_url => dependency.parent_package_url... if !dependency.direct }
+}
@@ -0,0 +1,16 @@
+data "sonatypeiq_application" "sandbox" {
+  public_id = "sandbox-application"
+}
+
+# Verify which settings actually apply to an Application
+data "sonatypeiq_effective_configuration" "sandbox" {
+  application_id = data.sonatypeiq_application.sandbox.id
+}
+
+output "sandbox_scm_provider" {
+  value = data.sonatypeiq_effective_configuration.sandbox.source_control.scm_provider
+}
+
+output "sandbox_build_report_retention" {
+  value = data.sonatypeiq_effective_configuration.sandbox.data_retention.application_reports["build"].max_age
+}
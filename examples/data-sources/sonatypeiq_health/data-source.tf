@@ -0,0 +1,37 @@
+# Fail loudly when Sonatype IQ Server itself is unhealthy
+check "iq_server_health" {
+  data "sonatypeiq_health" "iq" {}
+
+  assert {
+    condition     = data.sonatypeiq_health.iq.reachable
+    error_message = "Sonatype IQ Server is not reachable"
+  }
+
+  assert {
+    condition     = coalesce(data.sonatypeiq_health.iq.license_days_remaining, 0) > 30
+    error_message = "The Sonatype IQ Server license expires within 30 days"
+  }
+
+  assert {
+    condition     = coalesce(data.sonatypeiq_health.iq.hds_reachable, false)
+    error_message = "Sonatype IQ Server cannot reach the Sonatype Data Services"
+  }
+}
+
+# Only manage resources that require a recent Sonatype IQ Server when it is recent enough
+data "sonatypeiq_health" "version" {
+  minimum_version = "1.170.0"
+}
+
+resource "sonatypeiq_application" "app" {
+  name            = "My Application"
+  public_id       = "my-application"
+  organization_id = "ROOT_ORGANIZATION_ID"
+
+  lifecycle {
+    precondition {
+      condition     = data.sonatypeiq_health.version.meets_minimum_version
+      error_message = "Sonatype IQ Server ${coalesce(data.sonatypeiq_health.version.version, "(unreachable)")} is older than 1.170.0"
+    }
+  }
+}
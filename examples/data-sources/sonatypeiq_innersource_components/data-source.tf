@@ -0,0 +1,9 @@
+# List the InnerSource components consumed by the Sandbox Application in its latest build evaluation
+data "sonatypeiq_innersource_components" "sandbox" {
+  application_id = "sandbox-application"
+  stage_id       = "build"
+}
+
+output "innersource_producers" {
+  value = distinct([for component in data.sonatypeiq_innersource_components.sandbox.components : component.owner_application_name])
+}
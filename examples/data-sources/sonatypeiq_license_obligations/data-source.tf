@@ -0,0 +1,9 @@
+# List the license obligations of the Sandbox Application that were flagged during legal review
+data "sonatypeiq_license_obligations" "flagged" {
+  application_id = "sandbox-application"
+  status         = "FLAGGED"
+}
+
+output "flagged_obligations" {
+  value = [for obligation in data.sonatypeiq_license_obligations.flagged.obligations : "${obligation.package_url}: ${obligation.name}"]
+}
@@ -0,0 +1,9 @@
+# Get License Threat Groups for the Root Organization
+data "sonatypeiq_license_threat_groups" "root" {
+  organization_id = "ROOT_ORGANIZATION_ID"
+}
+
+# Reference a License Threat Group by name
+output "banned_licenses" {
+  value = one([for g in data.sonatypeiq_license_threat_groups.root.license_threat_groups : g.licenses if g.name == "Banned"])
+}
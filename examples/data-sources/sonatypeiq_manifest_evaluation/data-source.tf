@@ -0,0 +1,14 @@
+# Evaluate the dependencies of a service against the policies of its Application
+data "sonatypeiq_manifest_evaluation" "service" {
+  application_id  = "sandbox-application"
+  manifest_format = "package-lock.json"
+  manifest        = file("${path.module}/service/package-lock.json")
+}
+
+# Stop the golden path when a dependency violates a policy with a high threat level
+check "service_dependencies" {
+  assert {
+    condition     = data.sonatypeiq_manifest_evaluation.service.max_threat_level < 8
+    error_message = "Dependencies violate policies: ${join(", ", [for component in data.sonatypeiq_manifest_evaluation.service.components : component.package_url if component.max_threat_level >= 8])}"
+  }
+}
@@ -0,0 +1,16 @@
+# Configure Source Control for every Application in the "Sandbox Organization" and its child Organizations
+data "sonatypeiq_organization" "sandbox" {
+  name = "Sandbox Organization"
+}
+
+data "sonatypeiq_organization_applications" "sandbox" {
+  organization_id = data.sonatypeiq_organization.sandbox.id
+  recursive       = true
+}
+
+resource "sonatypeiq_source_control" "sandbox" {
+  for_each = { for application in data.sonatypeiq_organization_applications.sandbox.applications : application.public_id => application }
+
+  application_id = each.value.id
+  repository_url = "https://github.com/example/${each.key}"
+}
@@ -0,0 +1,14 @@
+data "sonatypeiq_product_license" "license" {}
+
+# Warn ahead of the license expiring
+check "iq_server_license" {
+  assert {
+    condition     = coalesce(data.sonatypeiq_product_license.license.days_remaining, 365) > 30
+    error_message = "The Sonatype IQ Server license expires within 30 days"
+  }
+}
+
+# Only manage configuration for Sonatype Repository Firewall when it is licensed
+output "firewall_enabled" {
+  value = data.sonatypeiq_product_license.license.firewall_enabled
+}
@@ -0,0 +1,19 @@
+# Get the latest build evaluations of the Sandbox Application
+data "sonatypeiq_report_history" "sandbox" {
+  application_id = "sandbox-application"
+  stage_id       = "build"
+  max_results    = 5
+}
+
+# Warn when the Sandbox Application has not been evaluated for a week
+check "sandbox_evaluated" {
+  assert {
+    condition     = data.sonatypeiq_report_history.sandbox.latest_evaluation_date != null && timecmp(timeadd(data.sonatypeiq_report_history.sandbox.latest_evaluation_date, "168h"), plantimestamp()) > 0
+    error_message = "The Sandbox Application has not been evaluated in the last week"
+  }
+}
+
+# Scan of the evaluation before the latest one
+output "previous_scan_id" {
+  value = try(data.sonatypeiq_report_history.sandbox.reports[1].scan_id, null)
+}
@@ -0,0 +1,7 @@
+# Refer to the Root Organization rather than hardcoding its ID
+data "sonatypeiq_root_organization" "root" {}
+
+resource "sonatypeiq_organization" "platform" {
+  name                   = "Platform"
+  parent_organization_id = data.sonatypeiq_root_organization.root.id
+}
@@ -0,0 +1,14 @@
+# Get the SCM providers supported by Sonatype IQ Server
+data "sonatypeiq_scm_providers" "supported" {}
+
+variable "scm_provider" {
+  type    = string
+  default = "github"
+}
+
+check "scm_provider_supported" {
+  assert {
+    condition     = contains(data.sonatypeiq_scm_providers.supported.scm_providers, var.scm_provider)
+    error_message = "SCM provider ${var.scm_provider} is not supported by Sonatype IQ Server."
+  }
+}
@@ -0,0 +1,9 @@
+# Get the Source Control configuration for the Root Organization
+data "sonatypeiq_source_control" "root" {
+  organization_id = "ROOT_ORGANIZATION_ID"
+}
+
+# Get the Source Control configuration for an Application
+data "sonatypeiq_source_control" "app" {
+  application_id = "4bb67dcfc86344e3a483832f8c496419"
+}
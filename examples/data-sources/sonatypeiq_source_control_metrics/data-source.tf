@@ -0,0 +1,12 @@
+# Report on the automated Pull Requests that IQ Server opened for an Application
+data "sonatypeiq_application" "example" {
+  public_id = "example_application"
+}
+
+data "sonatypeiq_source_control_metrics" "example" {
+  application_id = data.sonatypeiq_application.example.id
+}
+
+output "pull_requests_opened" {
+  value = data.sonatypeiq_source_control_metrics.example.successful_count
+}
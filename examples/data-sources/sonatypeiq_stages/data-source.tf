@@ -0,0 +1,6 @@
+# Get all Stages supported by Sonatype IQ Server
+data "sonatypeiq_stages" "all" {}
+
+locals {
+  stage_ids = [for s in data.sonatypeiq_stages.all.stages : s.id]
+}
@@ -0,0 +1,18 @@
+# Success Metrics of all Organizations for the first half of 2024
+data "sonatypeiq_success_metrics" "h1_2024" {
+  time_period       = "MONTH"
+  first_time_period = "2024-01"
+  last_time_period  = "2024-06"
+}
+
+output "violations_resolved" {
+  value = { for organization in data.sonatypeiq_success_metrics.h1_2024.organizations : organization.organization_name => organization.fixed + organization.waived }
+}
+
+output "critical_mttr_days" {
+  value = {
+    for organization in data.sonatypeiq_success_metrics.h1_2024.organizations :
+    organization.organization_name => floor(organization.mttr_critical_threat / 86400000)
+    if organization.mttr_critical_threat != null
+  }
+}
@@ -0,0 +1,13 @@
+# Monthly security violation trends of all Organizations since the start of 2024
+data "sonatypeiq_violation_trends" "monthly" {
+  time_period       = "MONTH"
+  first_time_period = "2024-01"
+}
+
+output "critical_security_violations_discovered" {
+  value = {
+    for trend in data.sonatypeiq_violation_trends.monthly.trends :
+    "${trend.organization_name} ${trend.time_period_start}" => trend.discovered
+    if trend.policy_type == "SECURITY" && trend.threat_level == "CRITICAL"
+  }
+}
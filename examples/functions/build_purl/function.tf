@@ -0,0 +1,4 @@
+output "commons_lang3_purl" {
+  # pkg:maven/org.apache.commons/commons-lang3@3.12.0?type=jar
+  value = provider::sonatypeiq::build_purl("maven", "org.apache.commons", "commons-lang3", "3.12.0", { type = "jar" })
+}
@@ -0,0 +1,4 @@
+output "threat_level" {
+  # 9
+  value = provider::sonatypeiq::cvss_to_threat_level("CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H")
+}
@@ -18,4 +18,175 @@ provider "sonatypeiq" {
   host     = "my-sonatype-iq-server.tld:port"
   username = "username"
   password = "password"
-}
\ No newline at end of file
+}
+
+# Alternatively, authenticate using a (revocable) User Token
+provider "sonatypeiq" {
+  alias     = "token"
+  url       = "https://my-sonatype-iq-server.tld"
+  user_code = "user-code"
+  pass_code = "pass-code"
+}
+
+# Authenticate with a bearer token at a proxy in front of Sonatype IQ Server
+provider "sonatypeiq" {
+  alias     = "bearer"
+  url       = "https://my-sonatype-iq-server.tld"
+  auth_mode = "bearer"
+  token     = "token"
+}
+
+# Or send the token in a custom header expected by an SSO proxy
+provider "sonatypeiq" {
+  alias       = "sso"
+  url         = "https://my-sonatype-iq-server.tld"
+  auth_mode   = "header"
+  auth_header = "X-Auth-Token"
+  token       = "token"
+}
+
+# Or configure the provider entirely through SONATYPEIQ_URL, SONATYPEIQ_USERNAME and
+# SONATYPEIQ_PASSWORD (or SONATYPEIQ_USER_CODE and SONATYPEIQ_PASS_CODE)
+provider "sonatypeiq" {
+  alias = "environment"
+}
+
+# Keep credentials out of plan files by passing them in ephemeral variables, e.g. set from a
+# secret manager or an ephemeral resource (requires Terraform 1.10 or later)
+variable "iq_password" {
+  type      = string
+  sensitive = true
+  ephemeral = true
+}
+
+provider "sonatypeiq" {
+  alias    = "ephemeral"
+  url      = "https://my-sonatype-iq-server.tld"
+  username = "username"
+  password = var.iq_password
+}
+
+# Read the URL and credentials from a profile of a credentials file, e.g.
+# {"production": {"url": "https://my-sonatype-iq-server.tld", "user_code": "...", "pass_code": "..."}}
+provider "sonatypeiq" {
+  alias            = "profile"
+  credentials_file = "~/.sonatypeiq/credentials.json"
+  profile          = "production"
+}
+
+# Trust a private CA when Sonatype IQ Server uses a certificate issued by private PKI
+provider "sonatypeiq" {
+  alias        = "private_pki"
+  url          = "https://my-sonatype-iq-server.tld"
+  username     = "username"
+  password     = "password"
+  ca_cert_file = "/etc/pki/my-private-ca.pem"
+}
+
+# Present a Client Certificate when Sonatype IQ Server sits behind a mutual TLS gateway
+provider "sonatypeiq" {
+  alias       = "mtls"
+  url         = "https://my-sonatype-iq-server.tld"
+  username    = "username"
+  password    = "password"
+  client_cert = file("client.pem")
+  client_key  = file("client-key.pem")
+}
+
+# Reach Sonatype IQ Server through an egress proxy
+provider "sonatypeiq" {
+  alias     = "proxied"
+  url       = "https://my-sonatype-iq-server.tld"
+  username  = "username"
+  password  = "password"
+  proxy_url = "http://proxy.my-company.tld:3128"
+}
+
+# Retry more patiently against a busy Sonatype IQ Server
+provider "sonatypeiq" {
+  alias          = "busy"
+  url            = "https://my-sonatype-iq-server.tld"
+  username       = "username"
+  password       = "password"
+  max_retries    = 5
+  retry_wait_min = 2
+  retry_wait_max = 60
+
+  # Allow slow report and evaluation endpoints up to 10 minutes
+  request_timeout = 600
+}
+
+# Protect a small Sonatype IQ Server instance from Terraform's parallelism
+provider "sonatypeiq" {
+  alias                   = "small"
+  url                     = "https://my-sonatype-iq-server.tld"
+  username                = "username"
+  password                = "password"
+  max_concurrent_requests = 4
+  requests_per_second     = 10
+}
+
+# Reach Sonatype IQ Server through an API gateway
+provider "sonatypeiq" {
+  alias    = "gateway"
+  url      = "https://api-gateway.my-company.tld/sonatype-iq"
+  username = "username"
+  password = "password"
+  additional_headers = {
+    "X-Api-Key" = "my-gateway-api-key"
+  }
+}
+
+# Fail fast when Sonatype IQ Server is too old for this configuration
+provider "sonatypeiq" {
+  alias                  = "recent"
+  url                    = "https://my-sonatype-iq-server.tld"
+  username               = "username"
+  password               = "password"
+  minimum_server_version = "1.170.0"
+}
+
+# Create Applications in a default Organization unless they configure an organization_id
+provider "sonatypeiq" {
+  alias                   = "team"
+  url                     = "https://my-sonatype-iq-server.tld"
+  username                = "username"
+  password                = "password"
+  default_organization_id = "2f2f5a0ff0c64c0c8c8b9d3f1d0d7f0a"
+}
+
+# Fail over to a standby Sonatype IQ Server behind another load balancer
+provider "sonatypeiq" {
+  alias         = "ha"
+  url           = "https://iq-active.my-company.tld"
+  failover_urls = ["https://iq-standby.my-company.tld"]
+  username      = "username"
+  password      = "password"
+}
+
+# Detect drift against a production Sonatype IQ Server without any risk of changing it
+provider "sonatypeiq" {
+  alias     = "drift"
+  url       = "https://my-sonatype-iq-server.tld"
+  username  = "username"
+  password  = "password"
+  read_only = true
+}
+
+# Keep an independent record of every change the provider makes to Sonatype IQ Server
+provider "sonatypeiq" {
+  alias          = "audited"
+  url            = "https://my-sonatype-iq-server.tld"
+  username       = "username"
+  password       = "password"
+  audit_log_file = "/var/log/terraform/sonatypeiq-audit.jsonl"
+}
+
+# Write metrics of the requests sent to Sonatype IQ Server for the node exporter, e.g. to tune parallelism
+provider "sonatypeiq" {
+  alias        = "measured"
+  url          = "https://my-sonatype-iq-server.tld"
+  username     = "username"
+  password     = "password"
+  metrics_file = "/var/lib/node_exporter/textfile_collector/sonatypeiq.prom"
+}
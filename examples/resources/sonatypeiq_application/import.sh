@@ -0,0 +1,2 @@
+# Applications can be imported using their internal ID
+terraform import sonatypeiq_application.example 4bb67dcfc86344e3a483832f8c496419
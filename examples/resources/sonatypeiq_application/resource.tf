@@ -9,6 +9,43 @@ resource "sonatypeiq_application" "example" {
   organization_id = data.sonatypeiq_organization.sandbox.id
 }
 
+# Create an Application with Application Categories applied to it
+data "sonatypeiq_application_categories" "sandbox" {
+  organization_id = data.sonatypeiq_organization.sandbox.id
+}
+
+resource "sonatypeiq_application" "example_with_categories" {
+  name                     = "Example Categorized Application"
+  public_id                = "example_categorized_application"
+  organization_id          = data.sonatypeiq_organization.sandbox.id
+  application_category_ids = [for category in data.sonatypeiq_application_categories.sandbox.categories : category.id if category.name == "Distributed"]
+}
+
+# Create an Application together with its Source Control configuration
+resource "sonatypeiq_application" "example_with_repository" {
+  name            = "Example Repository Application"
+  public_id       = "example_repository_application"
+  organization_id = data.sonatypeiq_organization.sandbox.id
+
+  source_control = {
+    repository_url = "https://github.com/example/example-repository"
+    base_branch    = "main"
+  }
+}
+
+# Take over an Application that was managed as sonatypeiq_application.legacy with the Sonatype IQ
+# community provider, without destroying and recreating it
+resource "sonatypeiq_application" "migrated" {
+  name            = "Migrated Application"
+  public_id       = "migrated_application"
+  organization_id = data.sonatypeiq_organization.sandbox.id
+}
+
+moved {
+  from = sonatypeiq_application.legacy
+  to   = sonatypeiq_application.migrated
+}
+
 output "example_app" {
   value = sonatypeiq_application.example
 }
\ No newline at end of file
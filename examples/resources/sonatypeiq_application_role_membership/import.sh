@@ -0,0 +1,3 @@
+# Application role memberships can be imported using an ID of the form
+# <application_id>/<role_id>/<user|group>/<user_name|group_name>, with each part URL-escaped
+terraform import sonatypeiq_application_role_membership.example 4bb67dcfc86344e3a483832f8c496419/1cddabf7fdaa47d6833454af10e0a3ef/user/example
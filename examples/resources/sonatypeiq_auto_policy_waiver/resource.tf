@@ -0,0 +1,8 @@
+# Automatically waive security violations up to threat level 3 for all Applications,
+# as long as the vulnerable code is not reachable
+resource "sonatypeiq_auto_policy_waiver" "low_risk" {
+  organization_id = "ROOT_ORGANIZATION_ID"
+  threat_level    = 3
+  reachable       = false
+  path_forward    = true
+}
@@ -0,0 +1,2 @@
+# There is only one Mail Configuration - any ID can be used to import it
+terraform import sonatypeiq_config_mail.example config_mail
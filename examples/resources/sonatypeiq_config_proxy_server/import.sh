@@ -0,0 +1,2 @@
+# There is only one Proxy Server Configuration - any ID can be used to import it
+terraform import sonatypeiq_config_proxy_server.example config_proxy_server
@@ -0,0 +1,19 @@
+data "sonatypeiq_application" "sandbox" {
+  public_id = "sandbox-application"
+}
+
+# Record that the Sandbox Application uses commons-collections under the Apache 2.0 license
+resource "sonatypeiq_license_override" "commons_collections" {
+  application_id   = data.sonatypeiq_application.sandbox.id
+  component_format = "maven"
+  component_coordinates = {
+    groupId    = "commons-collections"
+    artifactId = "commons-collections"
+    version    = "3.2.1"
+    extension  = "jar"
+    classifier = ""
+  }
+  status      = "SELECTED"
+  license_ids = ["Apache-2.0"]
+  comment     = "Confirmed by legal, see LEGAL-123"
+}
@@ -0,0 +1,2 @@
+# Organizations can be imported using their internal ID
+terraform import sonatypeiq_organization.example 2f2f5a0ff0c64c0c8c8b9d3f1d0d7f0a
@@ -0,0 +1,3 @@
+# Organization role memberships can be imported using an ID of the form
+# <organization_id>/<role_id>/<user|group>/<user_name|group_name>, with each part URL-escaped
+terraform import sonatypeiq_organization_role_membership.example ROOT_ORGANIZATION_ID/1cddabf7fdaa47d6833454af10e0a3ef/group/developers
@@ -0,0 +1,12 @@
+data "sonatypeiq_application" "sandbox" {
+  public_id = "sandbox-application"
+}
+
+# Waive a Policy Violation for every version of the violating component
+resource "sonatypeiq_policy_waiver" "struts" {
+  application_id      = data.sonatypeiq_application.sandbox.id
+  policy_violation_id = "5a2b2e24f0574fb2bd0d5f8ac2e0b3c5"
+  matcher_strategy    = "ALL_VERSIONS"
+  comment             = "Not exploitable, the affected endpoint is disabled - see SEC-42"
+  expiry_time         = "2027-01-01T00:00:00Z"
+}
@@ -0,0 +1,6 @@
+# Ask the approvers to waive a Policy Violation of the Sandbox Application
+resource "sonatypeiq_policy_waiver_request" "struts" {
+  policy_violation_id   = "5a2b2e24f0574fb2bd0d5f8ac2e0b3c5"
+  comment               = "Not exploitable, the affected endpoint is disabled - see SEC-42"
+  policy_violation_link = "https://iq.example.com/ui/links/application/sandbox-application/report/1234"
+}
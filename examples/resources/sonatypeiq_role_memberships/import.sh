@@ -0,0 +1,3 @@
+# Role memberships can be imported using an ID of the form <organization|application>/<id>,
+# which manages all Roles that have members on the Organization or Application itself
+terraform import sonatypeiq_role_memberships.sandbox application/4bb67dcfc86344e3a483832f8c496419
@@ -0,0 +1,18 @@
+data "sonatypeiq_application" "sandbox" {
+  public_id = "sandbox-application"
+}
+
+# Manage all Developers and Owners of the Sandbox Application in a single resource
+resource "sonatypeiq_role_memberships" "sandbox" {
+  application_id = data.sonatypeiq_application.sandbox.id
+
+  roles = {
+    "Developer" = {
+      users  = ["alice", "bob"]
+      groups = ["developers"]
+    }
+    "Owner" = {
+      users = ["carol"]
+    }
+  }
+}
@@ -0,0 +1,2 @@
+# The configuration of the Root Organization can be imported using its ID
+terraform import sonatypeiq_root_organization.this ROOT_ORGANIZATION_ID
@@ -0,0 +1,30 @@
+# Configure the Root Organization, which all Organizations and Applications inherit from,
+# without referring to it by ID
+variable "scm_token" {
+  type      = string
+  sensitive = true
+}
+
+resource "sonatypeiq_root_organization" "this" {
+  source_control = {
+    scm_provider                      = "github"
+    base_branch                       = "main"
+    remediation_pull_requests_enabled = true
+    pull_request_commenting_enabled   = true
+  }
+  source_control_token         = var.scm_token
+  source_control_token_version = 1
+
+  application_categories = [
+    {
+      name        = "Internal"
+      description = "Applications that are only used internally"
+      color       = "light-blue"
+    },
+    {
+      name        = "Distributed"
+      description = "Applications that are distributed to customers"
+      color       = "dark-red"
+    },
+  ]
+}
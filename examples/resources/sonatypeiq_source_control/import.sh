@@ -0,0 +1,3 @@
+# Source Control configurations can be imported using an ID of the form
+# <organization|application>/<organization_id|application_id>
+terraform import sonatypeiq_source_control.example application/4bb67dcfc86344e3a483832f8c496419
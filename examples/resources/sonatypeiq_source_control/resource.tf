@@ -0,0 +1,18 @@
+# Configure Source Control for an existing Application. The token is write-only and never
+# stored in state - it is sent again when it changes, or when token_version is bumped.
+data "sonatypeiq_application" "example" {
+  public_id = "example_application"
+}
+
+variable "scm_token" {
+  type      = string
+  sensitive = true
+}
+
+resource "sonatypeiq_source_control" "example" {
+  application_id = data.sonatypeiq_application.example.id
+  repository_url = "https://github.com/example/example-application"
+  base_branch    = "main"
+  token          = var.scm_token
+  token_version  = 1
+}
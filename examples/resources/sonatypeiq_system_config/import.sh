@@ -0,0 +1,2 @@
+# There is only one System Configuration - any ID can be used to import it
+terraform import sonatypeiq_system_config.example system_config
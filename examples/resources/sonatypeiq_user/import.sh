@@ -0,0 +1,2 @@
+# Users can be imported using their username
+terraform import sonatypeiq_user.example example
@@ -0,0 +1,113 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	sonatypeiq "github.com/sonatype-nexus-community/nexus-iq-api-client-go"
+)
+
+// callIqApi performs a request against a Sonatype IQ Server endpoint that is not exposed by the
+// generated API client. The server URL, HTTP client, User-Agent and default headers of the
+// supplied client are re-used, and Basic Authentication is taken from the context in the same
+// way the generated client does.
+//
+// When the server does not respond with a 2xx status an error is returned together with the
+// response, whose body can still be read by the caller.
+func callIqApi(ctx context.Context, client *sonatypeiq.APIClient, method string, path string, body interface{}, result interface{}) (*http.Response, error) {
+	var requestBody io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		requestBody = bytes.NewReader(payload)
+	}
+
+	response, responseBody, err := sendIqRequest(ctx, client, method, path, "application/json", requestBody)
+	if err != nil {
+		return response, err
+	}
+
+	if result != nil && len(responseBody) > 0 {
+		if err := json.Unmarshal(responseBody, result); err != nil {
+			return response, err
+		}
+		response.Body = io.NopCloser(bytes.NewReader(responseBody))
+	}
+
+	return response, nil
+}
+
+// downloadIqFile downloads a file, such as a PDF report, from a Sonatype IQ Server endpoint in the
+// same way as callIqApi, returning its contents.
+func downloadIqFile(ctx context.Context, client *sonatypeiq.APIClient, path string) ([]byte, *http.Response, error) {
+	response, content, err := sendIqRequest(ctx, client, http.MethodGet, path, "*/*", nil)
+	return content, response, err
+}
+
+// sendIqRequest sends a request for callIqApi and downloadIqFile and reads the response body,
+// which is left readable on the response as well.
+func sendIqRequest(ctx context.Context, client *sonatypeiq.APIClient, method string, path string, accept string, requestBody io.Reader) (*http.Response, []byte, error) {
+	cfg := client.GetConfig()
+
+	request, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(cfg.Servers[0].URL, "/")+path, requestBody)
+	if err != nil {
+		return nil, nil, err
+	}
+	request.Header.Set("Accept", accept)
+	if requestBody != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	request.Header.Set("User-Agent", cfg.UserAgent)
+	if auth, ok := ctx.Value(sonatypeiq.ContextBasicAuth).(sonatypeiq.BasicAuth); ok {
+		request.SetBasicAuth(auth.UserName, auth.Password)
+	}
+	for header, value := range cfg.DefaultHeader {
+		request.Header.Add(header, value)
+	}
+
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return response, nil, err
+	}
+
+	responseBody, err := io.ReadAll(response.Body)
+	response.Body.Close()
+	if err != nil {
+		return response, nil, err
+	}
+	response.Body = io.NopCloser(bytes.NewReader(responseBody))
+
+	if response.StatusCode >= 300 {
+		return response, responseBody, fmt.Errorf("%s %s returned %s", method, path, response.Status)
+	}
+
+	return response, responseBody, nil
+}
@@ -0,0 +1,231 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	sonatypeiq "github.com/sonatype-nexus-community/nexus-iq-api-client-go"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &applicableWaiversDataSource{}
+	_ datasource.DataSourceWithConfigure = &applicableWaiversDataSource{}
+)
+
+// ApplicableWaiversDataSource is a helper function to simplify the provider implementation.
+func ApplicableWaiversDataSource() datasource.DataSource {
+	return &applicableWaiversDataSource{}
+}
+
+// applicableWaiversDataSource is the data source implementation.
+type applicableWaiversDataSource struct {
+	baseDataSource
+}
+
+type applicableWaiversDataSourceModel struct {
+	ID                types.String        `tfsdk:"id"`
+	PolicyViolationId types.String        `tfsdk:"policy_violation_id"`
+	IsWaived          types.Bool          `tfsdk:"is_waived"`
+	ActiveWaivers     []policyWaiverModel `tfsdk:"active_waivers"`
+	ExpiredWaivers    []policyWaiverModel `tfsdk:"expired_waivers"`
+}
+
+type policyWaiverModel struct {
+	ID                   types.String `tfsdk:"id"`
+	PolicyId             types.String `tfsdk:"policy_id"`
+	PolicyName           types.String `tfsdk:"policy_name"`
+	ScopeOwnerId         types.String `tfsdk:"scope_owner_id"`
+	ScopeOwnerName       types.String `tfsdk:"scope_owner_name"`
+	ScopeOwnerType       types.String `tfsdk:"scope_owner_type"`
+	MatcherStrategy      types.String `tfsdk:"matcher_strategy"`
+	AssociatedPackageUrl types.String `tfsdk:"associated_package_url"`
+	Comment              types.String `tfsdk:"comment"`
+	CreatorName          types.String `tfsdk:"creator_name"`
+	CreateTime           types.String `tfsdk:"create_time"`
+	ExpiryTime           types.String `tfsdk:"expiry_time"`
+}
+
+// newPolicyWaiverModel maps a Policy Waiver returned by IQ Server to its Terraform model.
+func newPolicyWaiverModel(waiver sonatypeiq.ApiPolicyWaiverDTO) policyWaiverModel {
+	model := policyWaiverModel{
+		ID:                   types.StringPointerValue(waiver.PolicyWaiverId),
+		PolicyId:             types.StringPointerValue(waiver.PolicyId),
+		PolicyName:           types.StringPointerValue(waiver.PolicyName),
+		ScopeOwnerId:         types.StringPointerValue(waiver.ScopeOwnerId),
+		ScopeOwnerName:       types.StringPointerValue(waiver.ScopeOwnerName),
+		ScopeOwnerType:       types.StringPointerValue(waiver.ScopeOwnerType),
+		MatcherStrategy:      types.StringPointerValue(waiver.MatcherStrategy),
+		AssociatedPackageUrl: types.StringPointerValue(waiver.AssociatedPackageUrl),
+		Comment:              types.StringPointerValue(waiver.Comment),
+		CreatorName:          types.StringPointerValue(waiver.CreatorName),
+		CreateTime:           types.StringNull(),
+		ExpiryTime:           types.StringNull(),
+	}
+	if waiver.CreateTime != nil {
+		model.CreateTime = types.StringValue(waiver.CreateTime.Format(time.RFC3339))
+	}
+	if waiver.ExpiryTime != nil {
+		model.ExpiryTime = types.StringValue(waiver.ExpiryTime.Format(time.RFC3339))
+	}
+	return model
+}
+
+// policyWaiverSchemaAttributes are the (read-only) attributes of a Policy Waiver.
+var policyWaiverSchemaAttributes = map[string]schema.Attribute{
+	"id": schema.StringAttribute{
+		Description: "Internal ID of the Policy Waiver",
+		Computed:    true,
+	},
+	"policy_id": schema.StringAttribute{
+		Description: "Internal ID of the waived Policy",
+		Computed:    true,
+	},
+	"policy_name": schema.StringAttribute{
+		Description: "Name of the waived Policy",
+		Computed:    true,
+	},
+	"scope_owner_id": schema.StringAttribute{
+		Description: "Internal ID of the owner the Policy Waiver applies to",
+		Computed:    true,
+	},
+	"scope_owner_name": schema.StringAttribute{
+		Description: "Name of the owner the Policy Waiver applies to",
+		Computed:    true,
+	},
+	"scope_owner_type": schema.StringAttribute{
+		Description: "Type of the owner the Policy Waiver applies to",
+		Computed:    true,
+	},
+	"matcher_strategy": schema.StringAttribute{
+		Description: "Strategy used to match components against the Policy Waiver",
+		Computed:    true,
+	},
+	"associated_package_url": schema.StringAttribute{
+		Description: "Package URL of the component the Policy Waiver was created for",
+		Computed:    true,
+	},
+	"comment": schema.StringAttribute{
+		Description: "Comment provided when the Policy Waiver was created",
+		Computed:    true,
+	},
+	"creator_name": schema.StringAttribute{
+		Description: "Name of the user that created the Policy Waiver",
+		Computed:    true,
+	},
+	"create_time": schema.StringAttribute{
+		Description: "Time the Policy Waiver was created (RFC3339)",
+		Computed:    true,
+	},
+	"expiry_time": schema.StringAttribute{
+		Description: "Time the Policy Waiver expires (RFC3339) - null when the Policy Waiver does not expire",
+		Computed:    true,
+	},
+}
+
+// Metadata returns the data source type name.
+func (d *applicableWaiversDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_applicable_waivers"
+}
+
+// Schema defines the schema for the data source.
+func (d *applicableWaiversDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Use this data source to get the Policy Waivers applicable to a Policy Violation",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"policy_violation_id": schema.StringAttribute{
+				Description: "Internal ID of the Policy Violation",
+				Required:    true,
+			},
+			"is_waived": schema.BoolAttribute{
+				Description: "Whether at least one active Policy Waiver applies to the Policy Violation",
+				Computed:    true,
+			},
+			"active_waivers": schema.ListNestedAttribute{
+				Description: "List of active Policy Waivers applicable to the Policy Violation",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: policyWaiverSchemaAttributes,
+				},
+			},
+			"expired_waivers": schema.ListNestedAttribute{
+				Description: "List of expired Policy Waivers that applied to the Policy Violation",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: policyWaiverSchemaAttributes,
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *applicableWaiversDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data applicableWaiversDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = context.WithValue(
+		ctx,
+		sonatypeiq.ContextBasicAuth,
+		d.auth,
+	)
+
+	waivers, api_response, err := d.client.PolicyViolationsAPI.GetApplicableWaivers(ctx, data.PolicyViolationId.ValueString()).Execute()
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read IQ Applicable Waivers for Policy Violation",
+			describeApiError(api_response, err),
+		)
+		return
+	}
+	if api_response.StatusCode != 200 {
+		resp.Diagnostics.AddError("Unexpected API Response", api_response.Status)
+		return
+	}
+
+	for _, waiver := range waivers.ActiveWaivers {
+		data.ActiveWaivers = append(data.ActiveWaivers, newPolicyWaiverModel(waiver))
+	}
+	for _, waiver := range waivers.ExpiredWaivers {
+		data.ExpiredWaivers = append(data.ExpiredWaivers, newPolicyWaiverModel(waiver))
+	}
+
+	data.ID = data.PolicyViolationId
+	data.IsWaived = types.BoolValue(len(data.ActiveWaivers) > 0)
+
+	// Set state
+	diags := resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
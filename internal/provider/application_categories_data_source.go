@@ -94,6 +94,10 @@ func (d *applicationCategoriesDataSource) Schema(_ context.Context, req datasour
 
 // Read refreshes the Terraform state with the latest data.
 func (d *applicationCategoriesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if deferUnknownOwnerRead(req, resp) {
+		return
+	}
+
 	var data applicationCategoriesModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
@@ -113,7 +117,7 @@ func (d *applicationCategoriesDataSource) Read(ctx context.Context, req datasour
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read IQ Application Categories for Organization",
-			err.Error(),
+			describeApiError(api_response, err),
 		)
 		return
 	}
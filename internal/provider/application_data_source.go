@@ -102,6 +102,10 @@ func (d *applicationDataSource) Schema(_ context.Context, req datasource.SchemaR
 
 // Read refreshes the Terraform state with the latest data.
 func (d *applicationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if deferUnknownOwnerRead(req, resp) {
+		return
+	}
+
 	var data applicationModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
@@ -127,7 +131,7 @@ func (d *applicationDataSource) Read(ctx context.Context, req datasource.ReadReq
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Unable to Read IQ Application by ID",
-				err.Error(),
+				describeApiError(r, err),
 			)
 			return
 		}
@@ -145,7 +149,7 @@ func (d *applicationDataSource) Read(ctx context.Context, req datasource.ReadReq
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Unable to Read IQ Application by Public ID",
-				err.Error(),
+				describeApiError(r, err),
 			)
 			return
 		}
@@ -0,0 +1,219 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	sonatypeiq "github.com/sonatype-nexus-community/nexus-iq-api-client-go"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                     = &applicationReportPdfDataSource{}
+	_ datasource.DataSourceWithConfigure        = &applicationReportPdfDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &applicationReportPdfDataSource{}
+)
+
+// ApplicationReportPdfDataSource is a helper function to simplify the provider implementation.
+func ApplicationReportPdfDataSource() datasource.DataSource {
+	return &applicationReportPdfDataSource{}
+}
+
+// applicationReportPdfDataSource is the data source implementation.
+type applicationReportPdfDataSource struct {
+	baseDataSource
+}
+
+type applicationReportPdfDataSourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	ApplicationId types.String `tfsdk:"application_id"`
+	StageId       types.String `tfsdk:"stage_id"`
+	ScanId        types.String `tfsdk:"scan_id"`
+	OutputPath    types.String `tfsdk:"output_path"`
+	Url           types.String `tfsdk:"url"`
+	ContentBase64 types.String `tfsdk:"content_base64"`
+	ContentSha256 types.String `tfsdk:"content_sha256"`
+	Size          types.Int64  `tfsdk:"size"`
+}
+
+// Metadata returns the data source type name.
+func (d *applicationReportPdfDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_application_report_pdf"
+}
+
+// Schema defines the schema for the data source.
+func (d *applicationReportPdfDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Use this data source to download the PDF report of an evaluation of an Application, e.g. to attach it to a release record. The PDF is written to output_path when set, and returned base64 encoded otherwise",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"application_id": schema.StringAttribute{
+				Description: "Internal ID or public ID of the Application",
+				Required:    true,
+			},
+			"stage_id": schema.StringAttribute{
+				Description: "Use the latest evaluation in this Stage (e.g. build, release) - the most recent evaluation of any Stage is used when neither stage_id nor scan_id is set",
+				Optional:    true,
+				Computed:    true,
+			},
+			"scan_id": schema.StringAttribute{
+				Description: "ID of the evaluation report to download - the latest report is used when not set",
+				Optional:    true,
+				Computed:    true,
+			},
+			"output_path": schema.StringAttribute{
+				Description: "Local path to write the PDF to, creating missing directories - keeps the PDF out of the Terraform state",
+				Optional:    true,
+			},
+			"url": schema.StringAttribute{
+				Description: "URL the PDF report can be downloaded from by an authenticated user",
+				Computed:    true,
+			},
+			"content_base64": schema.StringAttribute{
+				Description: "Base64 encoded PDF report - null when output_path is set",
+				Computed:    true,
+			},
+			"content_sha256": schema.StringAttribute{
+				Description: "SHA-256 checksum of the PDF report, e.g. to only publish the report when it changed",
+				Computed:    true,
+			},
+			"size": schema.Int64Attribute{
+				Description: "Size of the PDF report in bytes",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *applicationReportPdfDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.Conflicting(
+			path.MatchRoot("stage_id"),
+			path.MatchRoot("scan_id"),
+		),
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *applicationReportPdfDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if deferUnknownOwnerRead(req, resp) {
+		return
+	}
+
+	var data applicationReportPdfDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = context.WithValue(
+		ctx,
+		sonatypeiq.ContextBasicAuth,
+		d.auth,
+	)
+
+	applicationId, diags := resolveApplicationId(ctx, d.client, data.ApplicationId.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	reports, api_response, err := d.client.ReportsAPI.GetByApplicationId(ctx, applicationId).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read IQ application reports",
+			describeApiError(api_response, err),
+		)
+		return
+	}
+
+	report := findReport(reports, data.StageId.ValueString(), data.ScanId.ValueString())
+	if report == nil {
+		resp.Diagnostics.AddError(
+			"No IQ evaluation report found",
+			fmt.Sprintf("Application %s has no evaluation report for the requested Stage or scan", data.ApplicationId.ValueString()),
+		)
+		return
+	}
+	_, scanId, _ := parseReportDataUrl(report.GetReportDataUrl())
+	if len(scanId) == 0 || len(report.GetReportPdfUrl()) == 0 {
+		resp.Diagnostics.AddError(
+			"Unable to Read IQ evaluation report",
+			fmt.Sprintf("Unexpected report URLs: %s %s", report.GetReportDataUrl(), report.GetReportPdfUrl()),
+		)
+		return
+	}
+
+	pdfPath := "/" + strings.TrimPrefix(report.GetReportPdfUrl(), "/")
+	content, api_response, err := downloadIqFile(ctx, d.client, pdfPath)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to download IQ PDF report",
+			describeApiError(api_response, err),
+		)
+		return
+	}
+
+	checksum := sha256.Sum256(content)
+
+	data.ID = types.StringValue(applicationId + "/" + scanId)
+	data.StageId = types.StringPointerValue(report.Stage)
+	data.ScanId = types.StringValue(scanId)
+	data.Url = types.StringValue(strings.TrimSuffix(d.client.GetConfig().Servers[0].URL, "/") + pdfPath)
+	data.ContentSha256 = types.StringValue(hex.EncodeToString(checksum[:]))
+	data.Size = types.Int64Value(int64(len(content)))
+	data.ContentBase64 = types.StringNull()
+
+	if data.OutputPath.IsNull() {
+		data.ContentBase64 = types.StringValue(base64.StdEncoding.EncodeToString(content))
+	} else {
+		outputPath := data.OutputPath.ValueString()
+		err := os.MkdirAll(filepath.Dir(outputPath), 0o755)
+		if err == nil {
+			err = os.WriteFile(outputPath, content, 0o644)
+		}
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("output_path"),
+				"Unable to write IQ PDF report",
+				err.Error(),
+			)
+			return
+		}
+	}
+
+	// Set state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,66 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccApplicationReportPdfDataSource(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "reports", "sandbox.pdf")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccSandbox(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `data "sonatypeiq_application_report_pdf" "sandbox" {
+					application_id = "sandbox-application"
+					stage_id       = "build"
+				}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.sonatypeiq_application_report_pdf.sandbox", "scan_id"),
+					resource.TestCheckResourceAttr("data.sonatypeiq_application_report_pdf.sandbox", "stage_id", "build"),
+					resource.TestMatchResourceAttr("data.sonatypeiq_application_report_pdf.sandbox", "content_base64", regexp.MustCompile("^JVBERi")),
+					resource.TestCheckResourceAttrSet("data.sonatypeiq_application_report_pdf.sandbox", "content_sha256"),
+				),
+			},
+			// Writing the PDF to a file
+			{
+				Config: providerConfig + `data "sonatypeiq_application_report_pdf" "sandbox" {
+					application_id = "sandbox-application"
+					stage_id       = "build"
+					output_path    = "` + filepath.ToSlash(outputPath) + `"
+				}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("data.sonatypeiq_application_report_pdf.sandbox", "content_base64"),
+					func(*terraform.State) error {
+						_, err := os.Stat(outputPath)
+						return err
+					},
+				),
+			},
+		},
+	})
+}
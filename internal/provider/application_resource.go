@@ -18,11 +18,24 @@ package provider
 
 import (
 	"context"
-	"io"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	sonatypeiq "github.com/sonatype-nexus-community/nexus-iq-api-client-go"
@@ -34,12 +47,35 @@ type applicationResource struct {
 }
 
 type applicationModelResource struct {
-	ID              types.String `tfsdk:"id"`
-	PublicId        types.String `tfsdk:"public_id"`
-	Name            types.String `tfsdk:"name"`
-	OrganizationId  types.String `tfsdk:"organization_id"`
-	ContactUserName types.String `tfsdk:"contact_user_name"`
-	LastUpdated     types.String `tfsdk:"last_updated"`
+	ID                 types.String                   `tfsdk:"id"`
+	PublicId           types.String                   `tfsdk:"public_id"`
+	Name               types.String                   `tfsdk:"name"`
+	OrganizationId     types.String                   `tfsdk:"organization_id"`
+	ContactUserName    types.String                   `tfsdk:"contact_user_name"`
+	StrictContact      types.Bool                     `tfsdk:"strict_contact_validation"`
+	DeletionProtection types.Bool                     `tfsdk:"deletion_protection"`
+	RetainOnDestroy    types.Bool                     `tfsdk:"retain_on_destroy"`
+	LastUpdated        types.String                   `tfsdk:"last_updated"`
+	CategoryIds        types.Set                      `tfsdk:"application_category_ids"`
+	ApplicationTags    types.List                     `tfsdk:"application_tags"`
+	SourceControl      *applicationSourceControlModel `tfsdk:"source_control"`
+	Timeouts           *timeoutsModel                 `tfsdk:"timeouts"`
+}
+
+// applicationTagLinkObjectType is the type of the elements of the application_tags attribute.
+var applicationTagLinkObjectType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"id":             types.StringType,
+		"tag_id":         types.StringType,
+		"application_id": types.StringType,
+	},
+}
+
+type applicationSourceControlModel struct {
+	RepositoryUrl          types.String `tfsdk:"repository_url"`
+	RepositoryUrlGitSuffix types.String `tfsdk:"repository_url_git_suffix"`
+	BaseBranch             types.String `tfsdk:"base_branch"`
+	Provider               types.String `tfsdk:"scm_provider"`
 }
 
 // NewApplicationResource is a helper function to simplify the provider implementation.
@@ -58,23 +94,237 @@ func (r *applicationResource) Schema(_ context.Context, _ resource.SchemaRequest
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"name": schema.StringAttribute{
 				Required: true,
 			},
 			"public_id": schema.StringAttribute{
-				Required: true,
+				Description: "Public ID of the Application - changing it updates the Application in place",
+				Required:    true,
 			},
 			"organization_id": schema.StringAttribute{
-				Required: true,
+				Description: "Internal ID of the Organization the Application belongs to - defaults to the default_organization_id of the provider",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.String{
+					organizationIdValidator,
+				},
 			},
 			"contact_user_name": schema.StringAttribute{
-				Optional: true,
+				Description: "Username of the contact of the Application - removing it clears the contact",
+				Optional:    true,
+			},
+			"strict_contact_validation": schema.BoolAttribute{
+				Description: "Whether an unknown contact_user_name fails the plan rather than resulting in a warning - defaults to false",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"deletion_protection": schema.BoolAttribute{
+				Description: "Whether destroying the Application fails - deleting an Application also deletes all of its reports and cannot be undone. Defaults to false",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"retain_on_destroy": schema.BoolAttribute{
+				Description: "Whether destroying the Application only removes it from the Terraform state, keeping the Application and its reports in IQ Server. Defaults to false",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"application_category_ids": schema.SetAttribute{
+				Description: "Internal IDs of the Application Categories applied to the Application - left untouched when not set",
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"application_tags": schema.ListNestedAttribute{
+				Description: "Application Categories applied to the Application",
+				Computed:    true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Internal ID of the Application-Tag link",
+							Computed:    true,
+						},
+						"tag_id": schema.StringAttribute{
+							Description: "Internal ID of the Tag",
+							Computed:    true,
+						},
+						"application_id": schema.StringAttribute{
+							Description: "Internal ID of the Application",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"source_control": schema.SingleNestedAttribute{
+				Description: "Source Control configuration of the Application - do not combine with a sonatypeiq_source_control resource for the same Application",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"repository_url": schema.StringAttribute{
+						Description: "Repository URL, either an https:// or SSH git URL",
+						Required:    true,
+						Validators: []validator.String{
+							repositoryUrlValidator{},
+						},
+					},
+					"repository_url_git_suffix": schema.StringAttribute{
+						Description: "Whether the repository_url must end in .git (required), must not end in .git (forbidden) or may do either (optional, the default). Only used to validate the repository_url",
+						Optional:    true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(gitSuffixes...),
+						},
+					},
+					"base_branch": schema.StringAttribute{
+						Description: "Default branch - inherited from the Organization when not set",
+						Optional:    true,
+					},
+					"scm_provider": schema.StringAttribute{
+						Description: "SCM provider, one of " + strings.Join(scmProviders, ", ") + " - inherited from the Organization when not set",
+						Optional:    true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(scmProviders...),
+						},
+					},
+				},
 			},
 			"last_updated": schema.StringAttribute{
 				Computed: true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(),
+		},
+	}
+}
+
+// ModifyPlan defaults the Organization to the default_organization_id of the provider and
+// validates the contact of the Application.
+func (r *applicationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do when destroying or when the provider has not been configured (yet)
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+	if deferUnknownOwner(req, resp) {
+		return
+	}
+
+	r.planOrganization(ctx, req, resp)
+	r.planApplicationTags(ctx, req, resp)
+	r.validateContact(ctx, req, resp)
+	r.warnPublicIdChange(ctx, req, resp)
+}
+
+// warnPublicIdChange warns that changing the Public ID of an Application, which is updated in place,
+// affects everything that refers to the Application by its Public ID.
+func (r *applicationResource) warnPublicIdChange(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var publicId, priorPublicId types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("public_id"), &publicId)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("public_id"), &priorPublicId)...)
+	if resp.Diagnostics.HasError() || publicId.IsUnknown() || publicId.Equal(priorPublicId) {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeWarning(
+		path.Root("public_id"),
+		"Application Public ID changes",
+		fmt.Sprintf("The Public ID of the Application changes from %s to %s. The Application and its reports are kept, "+
+			"but scanners such as the IQ CLI and CI plugins that evaluate against %s must be updated to use %s.",
+			priorPublicId.ValueString(), publicId.ValueString(), priorPublicId.ValueString(), publicId.ValueString()),
+	)
+}
+
+// planOrganization defaults the Organization to the default_organization_id of the provider.
+func (r *applicationResource) planOrganization(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var organizationId types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("organization_id"), &organizationId)...)
+	if resp.Diagnostics.HasError() || !organizationId.IsNull() {
+		return
+	}
+
+	if len(r.defaultOrganizationId) == 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("organization_id"),
+			"Missing Organization",
+			"The organization_id must be configured when the provider does not configure a default_organization_id",
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("organization_id"), r.defaultOrganizationId)...)
+}
+
+// planApplicationTags plans new links to the Application Categories when the Categories change.
+// Otherwise the links are kept, as planned from the state.
+func (r *applicationResource) planApplicationTags(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var categoryIds, priorCategoryIds types.Set
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("application_category_ids"), &categoryIds)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("application_category_ids"), &priorCategoryIds)...)
+	if resp.Diagnostics.HasError() || categoryIds.Equal(priorCategoryIds) {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("application_tags"), types.ListUnknown(applicationTagLinkObjectType))...)
+}
+
+// validateContact checks that a new contact of the Application is a known User. Users from
+// external realms such as LDAP may not be known to IQ Server until they log in, so an unknown
+// contact only results in a warning unless strict_contact_validation is enabled.
+func (r *applicationResource) validateContact(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var contactUserName, priorContactUserName types.String
+	var strict types.Bool
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("contact_user_name"), &contactUserName)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("strict_contact_validation"), &strict)...)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("contact_user_name"), &priorContactUserName)...)
+	}
+	if resp.Diagnostics.HasError() || contactUserName.IsNull() || contactUserName.IsUnknown() || contactUserName.Equal(priorContactUserName) {
+		return
+	}
+
+	ctx = context.WithValue(
+		ctx,
+		sonatypeiq.ContextBasicAuth,
+		r.auth,
+	)
+
+	_, api_response, err := r.client.UsersAPI.Get1(ctx, contactUserName.ValueString()).Execute()
+	if err == nil {
+		return
+	}
+	if !isNotFound(api_response) {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("contact_user_name"),
+			"Unable to validate Application contact",
+			apiErrorDetail("Could not look up User "+contactUserName.ValueString(), api_response, err),
+		)
+		return
+	}
+
+	summary := "Unknown Application contact"
+	detail := fmt.Sprintf("No User with username %s is known to IQ Server. Users from external realms such as LDAP may only be known once they have logged in.", contactUserName.ValueString())
+	if strict.ValueBool() {
+		resp.Diagnostics.AddAttributeError(path.Root("contact_user_name"), summary, detail)
+	} else {
+		resp.Diagnostics.AddAttributeWarning(path.Root("contact_user_name"), summary, detail+" Enable strict_contact_validation to treat this as an error.")
 	}
 }
 
@@ -95,29 +345,48 @@ func (r *applicationResource) Create(ctx context.Context, req resource.CreateReq
 		r.auth,
 	)
 
+	ctx, cancel := withTimeout(ctx, plan.Timeouts.create())
+	defer cancel()
+
 	application_request := r.client.ApplicationsAPI.AddApplication(ctx)
 	application_request = application_request.ApiApplicationDTO(sonatypeiq.ApiApplicationDTO{
 		Name:            plan.Name.ValueStringPointer(),
 		PublicId:        plan.PublicId.ValueStringPointer(),
 		OrganizationId:  plan.OrganizationId.ValueStringPointer(),
 		ContactUserName: plan.ContactUserName.ValueStringPointer(),
+		ApplicationTags: plan.applicationTags(),
 	})
 	application, api_response, err := application_request.Execute()
 
 	// Call API
 	if err != nil {
-		error_body, _ := io.ReadAll(api_response.Body)
 		resp.Diagnostics.AddError(
 			"Error creating Application",
-			"Could not create Application, unexpected error: "+api_response.Status+": "+string(error_body),
+			apiErrorDetail("Could not create Application", api_response, err),
 		)
 		return
 	}
 
 	// Map response body to schema and populate Computed attribute values
 	plan.ID = types.StringValue(*application.Id)
+	resp.Diagnostics.Append(plan.setApplicationTags(application)...)
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
+	// IQ Server may not return the new entity straight away
+	resp.Diagnostics.Append(markCreated(ctx, resp.Private)...)
+
+	if plan.SourceControl != nil {
+		_, api_response, err := r.client.SourceControlAPI.AddSourceControl(ctx, "application", plan.ID.ValueString()).ApiSourceControlDTO(plan.SourceControl.toDTO()).Execute()
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error creating Application Source Control configuration",
+				apiErrorDetail("Could not create Source Control configuration for Application "+plan.ID.ValueString(), api_response, err),
+			)
+			// Keep track of the Application that was created
+			plan.SourceControl = nil
+		}
+	}
+
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -142,16 +411,25 @@ func (r *applicationResource) Read(ctx context.Context, req resource.ReadRequest
 		r.auth,
 	)
 
+	ctx, cancel := withTimeout(ctx, state.Timeouts.read())
+	defer cancel()
+
 	// Get refreshed Application from IQ
-	application, api_response, err := r.client.ApplicationsAPI.GetApplication(ctx, state.ID.ValueString()).Execute()
+	var application *sonatypeiq.ApiApplicationDTO
+	var api_response *http.Response
+	var err error
+	resp.Diagnostics.Append(readConsistently(ctx, req.Private, resp.Private, func(ctx context.Context) bool {
+		application, api_response, err = r.client.ApplicationsAPI.GetApplication(ctx, state.ID.ValueString()).Execute()
+		return isNotFound(api_response)
+	})...)
 
 	if err != nil {
-		if api_response.StatusCode == 404 {
+		if isNotFound(api_response) {
 			resp.State.RemoveResource(ctx)
 		} else {
 			resp.Diagnostics.AddError(
 				"Error Reading IQ Application",
-				"Could not read Application with ID "+state.ID.ValueString()+": "+err.Error(),
+				apiErrorDetail("Could not read Application with ID "+state.ID.ValueString(), api_response, err),
 			)
 		}
 		return
@@ -161,11 +439,29 @@ func (r *applicationResource) Read(ctx context.Context, req resource.ReadRequest
 		state.Name = types.StringValue(*application.Name)
 		state.PublicId = types.StringValue(*application.PublicId)
 		state.OrganizationId = types.StringValue(*application.OrganizationId)
-		if application.ContactUserName != nil {
+		if application.ContactUserName != nil && *application.ContactUserName != "" {
 			state.ContactUserName = types.StringValue(*application.ContactUserName)
 		} else {
 			state.ContactUserName = types.StringNull()
 		}
+		resp.Diagnostics.Append(state.setApplicationTags(application)...)
+	}
+
+	// Only refresh the Source Control configuration when it is managed by this resource
+	if state.SourceControl != nil {
+		sourceControl, api_response, err := r.client.SourceControlAPI.GetSourceControl1(ctx, "application", state.ID.ValueString()).Execute()
+		if err != nil && !isNotFound(api_response) {
+			resp.Diagnostics.AddError(
+				"Error Reading IQ Application Source Control configuration",
+				apiErrorDetail("Could not read Source Control configuration for Application "+state.ID.ValueString(), api_response, err),
+			)
+			return
+		}
+		if err != nil {
+			state.SourceControl = nil
+		} else {
+			state.SourceControl.fromDTO(sourceControl)
+		}
 	}
 
 	// Set refreshed state
@@ -191,30 +487,52 @@ func (r *applicationResource) Update(ctx context.Context, req resource.UpdateReq
 		sonatypeiq.ContextBasicAuth,
 		r.auth,
 	)
+
+	ctx, cancel := withTimeout(ctx, plan.Timeouts.update())
+	defer cancel()
+
+	// IQ Server only clears the contact when it is explicitly set to an empty value
+	contactUserName := plan.ContactUserName.ValueStringPointer()
+	if contactUserName == nil && !state.ContactUserName.IsNull() {
+		noContact := ""
+		contactUserName = &noContact
+	}
+
 	app_update_request := r.client.ApplicationsAPI.UpdateApplication(ctx, state.ID.ValueString())
 	app_update_request = app_update_request.ApiApplicationDTO(sonatypeiq.ApiApplicationDTO{
 		Name:            plan.Name.ValueStringPointer(),
 		PublicId:        plan.PublicId.ValueStringPointer(),
 		OrganizationId:  plan.OrganizationId.ValueStringPointer(),
-		ContactUserName: plan.ContactUserName.ValueStringPointer(),
+		ContactUserName: contactUserName,
+		ApplicationTags: plan.applicationTags(),
 	})
 
 	application, api_response, err := app_update_request.Execute()
 
 	// Call API
 	if err != nil {
-		error_body, _ := io.ReadAll(api_response.Body)
 		resp.Diagnostics.AddError(
 			"Error updating Application",
-			"Could not update Application, unexpected error: "+api_response.Status+": "+string(error_body),
+			apiErrorDetail("Could not update Application", api_response, err),
 		)
 		return
 	}
 
-	// Map response body to schema and populate Computed attribute values
+	// Map response body to schema and populate Computed attribute values. Links to Categories that
+	// did not change were planned from the state and must be kept as planned.
 	plan.ID = types.StringValue(*application.Id)
+	plannedTags := plan.ApplicationTags
+	resp.Diagnostics.Append(plan.setApplicationTags(application)...)
+	if !plannedTags.IsUnknown() {
+		plan.ApplicationTags = plannedTags
+	}
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
+	resp.Diagnostics.Append(r.updateSourceControl(ctx, plan.ID.ValueString(), state.SourceControl, plan.SourceControl)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Set state to fully populated data
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 	if resp.Diagnostics.HasError() {
@@ -231,6 +549,24 @@ func (r *applicationResource) Delete(ctx context.Context, req resource.DeleteReq
 		return
 	}
 
+	if state.DeletionProtection.ValueBool() {
+		resp.Diagnostics.AddError(
+			"Application is protected from deletion",
+			fmt.Sprintf("Application %s has deletion_protection enabled. Deleting an Application also deletes all of its reports and cannot be undone. "+
+				"Set deletion_protection to false and apply before destroying it.", state.PublicId.ValueString()),
+		)
+		return
+	}
+
+	if state.RetainOnDestroy.ValueBool() {
+		r.retained.add(state.ID.ValueString())
+		resp.Diagnostics.AddWarning(
+			"Application retained",
+			fmt.Sprintf("Application %s has retain_on_destroy enabled, so it was only removed from the Terraform state and still exists in IQ Server together with its reports.", state.PublicId.ValueString()),
+		)
+		return
+	}
+
 	// Make Delete API Call
 	ctx = context.WithValue(
 		ctx,
@@ -238,13 +574,171 @@ func (r *applicationResource) Delete(ctx context.Context, req resource.DeleteReq
 		r.auth,
 	)
 
+	ctx, cancel := withTimeout(ctx, state.Timeouts.delete())
+	defer cancel()
+
 	api_response, err := r.client.ApplicationsAPI.DeleteApplication(ctx, state.ID.ValueString()).Execute()
 	if err != nil {
-		error_body, _ := io.ReadAll(api_response.Body)
 		resp.Diagnostics.AddError(
 			"Error deleting Application",
-			"Could not delete Application, unexpected error: "+api_response.Status+": "+string(error_body),
+			apiErrorDetail("Could not delete Application", api_response, err),
+		)
+		return
+	}
+}
+
+// MoveState moves the resource from the Sonatype IQ community provider.
+func (r *applicationResource) MoveState(_ context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		moveResourceState("sonatypeiq_application", func(state map[string]interface{}) {
+			setDefault(state, "strict_contact_validation", false)
+			setDefault(state, "deletion_protection", false)
+			setDefault(state, "retain_on_destroy", false)
+		}),
+	}
+}
+
+// ImportState imports the resource by its internal ID.
+func (r *applicationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("strict_contact_validation"), false)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("deletion_protection"), false)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("retain_on_destroy"), false)...)
+	resp.Diagnostics.Append(markCreated(ctx, resp.Private)...)
+}
+
+// updateSourceControl adds, updates or deletes the Source Control configuration of the Application
+// depending on whether it was and is configured.
+func (r *applicationResource) updateSourceControl(ctx context.Context, applicationId string, state *applicationSourceControlModel, plan *applicationSourceControlModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var api_response *http.Response
+	var err error
+
+	switch {
+	case plan == nil && state == nil:
+		return diags
+	case plan == nil:
+		api_response, err = r.client.SourceControlAPI.DeleteSourceControl(ctx, "application", applicationId).Execute()
+		if isNotFound(api_response) {
+			err = nil
+		}
+	case state == nil:
+		_, api_response, err = r.client.SourceControlAPI.AddSourceControl(ctx, "application", applicationId).ApiSourceControlDTO(plan.toDTO()).Execute()
+	default:
+		_, api_response, err = r.client.SourceControlAPI.UpdateSourceControl(ctx, "application", applicationId).ApiSourceControlDTO(plan.toDTO()).Execute()
+	}
+
+	if err != nil {
+		diags.AddError(
+			"Error updating Application Source Control configuration",
+			apiErrorDetail("Could not update Source Control configuration for Application "+applicationId, api_response, err),
 		)
+	}
+	return diags
+}
+
+func (m *applicationSourceControlModel) toDTO() sonatypeiq.ApiSourceControlDTO {
+	return sonatypeiq.ApiSourceControlDTO{
+		RepositoryUrl: m.RepositoryUrl.ValueStringPointer(),
+		BaseBranch:    m.BaseBranch.ValueStringPointer(),
+		Provider:      m.Provider.ValueStringPointer(),
+	}
+}
+
+func (m *applicationSourceControlModel) fromDTO(sourceControl *sonatypeiq.ApiSourceControlDTO) {
+	m.RepositoryUrl = types.StringPointerValue(sourceControl.RepositoryUrl)
+	m.BaseBranch = types.StringPointerValue(sourceControl.BaseBranch)
+	m.Provider = types.StringPointerValue(sourceControl.Provider)
+}
+
+// applicationTags returns the Application Categories to apply to the Application. IQ Server
+// replaces the applied Categories with these, so additions and removals are reconciled at once.
+// Categories that remain applied keep their existing link.
+func (m *applicationModelResource) applicationTags() []sonatypeiq.ApiApplicationTagDTO {
+	if m.CategoryIds.IsNull() || m.CategoryIds.IsUnknown() {
+		return nil
+	}
+
+	links := map[string]string{}
+	if !m.ApplicationTags.IsNull() && !m.ApplicationTags.IsUnknown() {
+		for _, element := range m.ApplicationTags.Elements() {
+			if link, ok := element.(types.Object); ok {
+				tagId, _ := link.Attributes()["tag_id"].(types.String)
+				id, _ := link.Attributes()["id"].(types.String)
+				links[tagId.ValueString()] = id.ValueString()
+			}
+		}
+	}
+
+	tags := []sonatypeiq.ApiApplicationTagDTO{}
+	for _, element := range m.CategoryIds.Elements() {
+		if categoryId, ok := element.(types.String); ok {
+			tag := sonatypeiq.ApiApplicationTagDTO{
+				TagId: categoryId.ValueStringPointer(),
+			}
+			// The Application has no ID yet while it is being created
+			if !m.ID.IsUnknown() {
+				tag.ApplicationId = m.ID.ValueStringPointer()
+			}
+			if id, ok := links[categoryId.ValueString()]; ok && len(id) > 0 {
+				tag.Id = sonatypeiq.PtrString(id)
+			}
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// setApplicationTags maps the Application Categories applied to the Application to the model.
+func (m *applicationModelResource) setApplicationTags(application *sonatypeiq.ApiApplicationDTO) diag.Diagnostics {
+	var diags diag.Diagnostics
+	categoryIds := []attr.Value{}
+	links := []attr.Value{}
+	for _, tag := range application.ApplicationTags {
+		categoryIds = append(categoryIds, types.StringPointerValue(tag.TagId))
+		link, d := types.ObjectValue(applicationTagLinkObjectType.AttrTypes, map[string]attr.Value{
+			"id":             types.StringPointerValue(tag.Id),
+			"tag_id":         types.StringPointerValue(tag.TagId),
+			"application_id": types.StringPointerValue(tag.ApplicationId),
+		})
+		diags.Append(d...)
+		links = append(links, link)
+	}
+
+	var d diag.Diagnostics
+	m.CategoryIds, d = types.SetValue(types.StringType, categoryIds)
+	diags.Append(d...)
+	m.ApplicationTags, d = types.ListValue(applicationTagLinkObjectType, links)
+	diags.Append(d...)
+	return diags
+}
+
+// retainedApplications records the Applications that retain_on_destroy removed from the state but
+// kept in IQ Server, so that destroying their Organization with force_destroy later in the same
+// run does not delete them after all.
+type retainedApplications struct {
+	mu  sync.Mutex
+	ids map[string]bool
+}
+
+func newRetainedApplications() *retainedApplications {
+	return &retainedApplications{ids: map[string]bool{}}
+}
+
+func (r *retainedApplications) add(applicationId string) {
+	if r == nil {
 		return
 	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ids[applicationId] = true
+}
+
+func (r *retainedApplications) contains(applicationId string) bool {
+	if r == nil {
+		return false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.ids[applicationId]
 }
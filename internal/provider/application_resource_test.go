@@ -17,18 +17,23 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"regexp"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 )
 
 func TestAccApplicationResource(t *testing.T) {
 
-	appName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	appName := testAccRandomName()
 
 	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccSandbox(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			// Create and Read testing
@@ -39,11 +44,18 @@ func TestAccApplicationResource(t *testing.T) {
 					resource.TestCheckResourceAttrSet("sonatypeiq_application.test", "id"),
 					resource.TestCheckResourceAttr("sonatypeiq_application.test", "name", appName),
 					resource.TestCheckResourceAttr("sonatypeiq_application.test", "public_id", appName),
+					resource.TestCheckResourceAttr("sonatypeiq_application.test", "application_category_ids.#", "0"),
 					resource.TestCheckResourceAttrSet("sonatypeiq_application.test", "last_updated"),
 				),
 			},
+			// Public ID changes are applied in place
 			{
 				Config: testAccApplicationResource(appName, "2"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("sonatypeiq_application.test", plancheck.ResourceActionUpdate),
+					},
+				},
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttrSet("sonatypeiq_application.test", "id"),
 					resource.TestCheckResourceAttr("sonatypeiq_application.test", "name", appName+"2"),
@@ -51,6 +63,47 @@ func TestAccApplicationResource(t *testing.T) {
 					resource.TestCheckResourceAttrSet("sonatypeiq_application.test", "last_updated"),
 				),
 			},
+			// ImportState testing
+			{
+				ResourceName:            "sonatypeiq_application.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			// Unknown contacts are rejected in strict mode
+			{
+				Config:      testAccApplicationResourceWithContact(appName+"2", "does-not-exist-"+appName),
+				ExpectError: regexp.MustCompile("Unknown Application contact"),
+			},
+			// Source Control configuration
+			{
+				Config: testAccApplicationResourceWithSourceControl(appName+"2", appName, "main"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("sonatypeiq_application.test", "source_control.repository_url", "https://github.com/sonatype/"+appName),
+					resource.TestCheckResourceAttr("sonatypeiq_application.test", "source_control.base_branch", "main"),
+				),
+			},
+			{
+				Config: testAccApplicationResourceWithSourceControl(appName+"2", appName, "develop"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("sonatypeiq_application.test", "source_control.base_branch", "develop"),
+				),
+			},
+			// Deletion protection
+			{
+				Config: testAccApplicationResourceWithDeletionProtection(appName+"2", true),
+			},
+			{
+				Config:      testAccApplicationResourceWithDeletionProtection(appName+"2", true),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile("Application is protected from deletion"),
+			},
+			{
+				Config: testAccApplicationResource(appName, "2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("sonatypeiq_application.test", "source_control"),
+				),
+			},
 			// Delete testing automatically occurs in TestCase
 		},
 	})
@@ -68,3 +121,150 @@ resource "sonatypeiq_application" "test" {
   organization_id = data.sonatypeiq_organization.sandbox.id
 }`, name, update, name, update)
 }
+
+func testAccApplicationResourceWithSourceControl(name string, repository string, branch string) string {
+	return fmt.Sprintf(providerConfig+`
+data "sonatypeiq_organization" "sandbox" {
+  name = "Sandbox Organization"
+}
+
+resource "sonatypeiq_application" "test" {
+  name = "%s"
+  public_id = "%s"
+  organization_id = data.sonatypeiq_organization.sandbox.id
+  source_control = {
+    repository_url = "https://github.com/sonatype/%s"
+    base_branch = "%s"
+  }
+}`, name, name, repository, branch)
+}
+
+func testAccApplicationResourceWithContact(name string, contact string) string {
+	return fmt.Sprintf(providerConfig+`
+data "sonatypeiq_organization" "sandbox" {
+  name = "Sandbox Organization"
+}
+
+resource "sonatypeiq_application" "test" {
+  name = "%s"
+  public_id = "%s"
+  organization_id = data.sonatypeiq_organization.sandbox.id
+  contact_user_name = "%s"
+  strict_contact_validation = true
+}`, name, name, contact)
+}
+
+func testAccApplicationResourceWithDeletionProtection(name string, protected bool) string {
+	return fmt.Sprintf(providerConfig+`
+data "sonatypeiq_organization" "sandbox" {
+  name = "Sandbox Organization"
+}
+
+resource "sonatypeiq_application" "test" {
+  name = "%s"
+  public_id = "%s"
+  organization_id = data.sonatypeiq_organization.sandbox.id
+  deletion_protection = %t
+}`, name, name, protected)
+}
+
+func TestApplicationResourcePlanApplicationTags(t *testing.T) {
+	const (
+		applicationId = "c5b6a7f8e9d0c1b2a3f4e5d6c7b8a9f0"
+		categoryId    = "d6c7b8a9f0e1d2c3b4a5f6e7d8c9b0a1"
+		otherId       = "e7d8c9b0a1f2e3d4c5b6a7f8e9d0c1b2"
+	)
+	server, schemas := testProviderServer(t, newMockIqServer(t))
+	s := schemas["sonatypeiq_application"]
+	attributeTypes := s.ValueType().(tftypes.Object).AttributeTypes
+	categoryIds := func(ids ...string) tftypes.Value {
+		var elements []tftypes.Value
+		for _, id := range ids {
+			elements = append(elements, tftypes.NewValue(tftypes.String, id))
+		}
+		return tftypes.NewValue(attributeTypes["application_category_ids"], elements)
+	}
+
+	linkType := attributeTypes["application_tags"].(tftypes.List).ElementType
+	tags := tftypes.NewValue(attributeTypes["application_tags"], []tftypes.Value{
+		tftypes.NewValue(linkType, map[string]tftypes.Value{
+			"id":             tftypes.NewValue(tftypes.String, "f8e9d0c1b2a3f4e5d6c7b8a9f0e1d2c3"),
+			"tag_id":         tftypes.NewValue(tftypes.String, categoryId),
+			"application_id": tftypes.NewValue(tftypes.String, applicationId),
+		}),
+	})
+	config := map[string]tftypes.Value{
+		"name":                      tftypes.NewValue(tftypes.String, "Sandbox Application"),
+		"public_id":                 tftypes.NewValue(tftypes.String, "sandbox-application"),
+		"organization_id":           tftypes.NewValue(tftypes.String, "ROOT_ORGANIZATION_ID"),
+		"strict_contact_validation": tftypes.NewValue(tftypes.Bool, false),
+		"deletion_protection":       tftypes.NewValue(tftypes.Bool, false),
+		"retain_on_destroy":         tftypes.NewValue(tftypes.Bool, false),
+	}
+	prior := map[string]tftypes.Value{
+		"id":                       tftypes.NewValue(tftypes.String, applicationId),
+		"application_category_ids": categoryIds(categoryId),
+		"application_tags":         tags,
+		"last_updated":             tftypes.NewValue(tftypes.String, "Thursday, 15-Oct-26 17:00:00 UTC"),
+	}
+	for name, value := range config {
+		prior[name] = value
+	}
+
+	for _, test := range []struct {
+		name        string
+		categoryIds tftypes.Value
+		rename      bool
+		changed     bool
+	}{
+		{name: "unchanged Categories", categoryIds: categoryIds(categoryId), rename: true},
+		{name: "Categories not configured", rename: true},
+		{name: "changed Categories", categoryIds: categoryIds(categoryId, otherId), changed: true},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			configured := map[string]tftypes.Value{}
+			for name, value := range config {
+				configured[name] = value
+			}
+			if test.rename {
+				configured["name"] = tftypes.NewValue(tftypes.String, "Renamed Application")
+			}
+			if test.categoryIds.Type() != nil {
+				configured["application_category_ids"] = test.categoryIds
+			}
+
+			// Terraform proposes the prior values of computed attributes that are not configured
+			proposed := map[string]tftypes.Value{}
+			for name, value := range prior {
+				proposed[name] = value
+			}
+			for name, value := range configured {
+				proposed[name] = value
+			}
+
+			planned, err := server.PlanResourceChange(context.Background(), &tfprotov6.PlanResourceChangeRequest{
+				TypeName:         "sonatypeiq_application",
+				PriorState:       testDynamicValue(t, s, prior, false),
+				ProposedNewState: testDynamicValue(t, s, proposed, false),
+				Config:           testDynamicValue(t, s, configured, false),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !checkDiagnostics(t, "plan", planned.Diagnostics, "") {
+				return
+			}
+
+			var attributes map[string]tftypes.Value
+			if err := testStateValue(t, s, planned.PlannedState).As(&attributes); err != nil {
+				t.Fatal(err)
+			}
+			if test.changed && attributes["application_tags"].IsKnown() {
+				t.Errorf("Expected new links to the Categories to be planned, got %s", attributes["application_tags"])
+			}
+			if !test.changed && !attributes["application_tags"].Equal(tags) {
+				t.Errorf("Expected the links to the Categories to be kept, got %s", attributes["application_tags"])
+			}
+		})
+	}
+}
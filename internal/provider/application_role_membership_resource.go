@@ -19,13 +19,18 @@ package provider
 import (
 	"context"
 	"fmt"
-	"io"
 	"net/http"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	sonatypeiq "github.com/sonatype-nexus-community/nexus-iq-api-client-go"
@@ -37,11 +42,12 @@ type applicationRoleMembershipResource struct {
 }
 
 type applicationRoleMembershipModelResource struct {
-	ID            types.String `tfsdk:"id"`
-	RoleId        types.String `tfsdk:"role_id"`
-	ApplicationId types.String `tfsdk:"application_id"`
-	UserName      types.String `tfsdk:"user_name"`
-	GroupName     types.String `tfsdk:"group_name"`
+	ID                   types.String `tfsdk:"id"`
+	RoleId               types.String `tfsdk:"role_id"`
+	ApplicationId        types.String `tfsdk:"application_id"`
+	UserName             types.String `tfsdk:"user_name"`
+	GroupName            types.String `tfsdk:"group_name"`
+	IgnoreMemberNameCase types.Bool   `tfsdk:"ignore_member_name_case"`
 }
 
 // NewApplicationRoleMembershipResource is a helper function to simplify the provider implementation.
@@ -57,26 +63,60 @@ func (r *applicationRoleMembershipResource) Metadata(_ context.Context, req reso
 // Schema defines the schema for the resource.
 func (r *applicationRoleMembershipResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
 			},
 			"role_id": schema.StringAttribute{
 				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					roleIdValidator,
+				},
 			},
 			"application_id": schema.StringAttribute{
-				Required: true,
+				Description: "Internal ID or public ID of the Application",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"user_name": schema.StringAttribute{
 				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"group_name": schema.StringAttribute{
 				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"ignore_member_name_case": schema.BoolAttribute{
+				Description: "Whether user_name and group_name are matched case-insensitively, for realms such as LDAP and SAML that may return names with a different casing. Defaults to false",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
 			},
 		},
 	}
 }
 
+// ValidateConfig checks that the Role exists when validate_roles is enabled on the provider.
+func (r *applicationRoleMembershipResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var roleId types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("role_id"), &roleId)...)
+	if resp.Diagnostics.HasError() || roleId.IsNull() || roleId.IsUnknown() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.checkRoles(ctx, map[string]path.Path{roleId.ValueString(): path.Root("role_id")})...)
+}
+
 func (r *applicationRoleMembershipResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
 	return []resource.ConfigValidator{
 		resourcevalidator.ExactlyOneOf(
@@ -115,22 +155,30 @@ func (r *applicationRoleMembershipResource) Create(ctx context.Context, req reso
 		memberName = data.UserName.ValueString()
 	}
 
-	apiRequest := r.client.RoleMembershipsAPI.GrantRoleMembershipApplicationOrOrganization(ctx, "application", data.ApplicationId.ValueString(), data.RoleId.ValueString(), memberType, memberName)
+	applicationId, diags := resolveApplicationId(ctx, r.client, data.ApplicationId.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiRequest := r.client.RoleMembershipsAPI.GrantRoleMembershipApplicationOrOrganization(ctx, "application", applicationId, data.RoleId.ValueString(), memberType, memberName)
 	apiResponse, err := r.client.RoleMembershipsAPI.GrantRoleMembershipApplicationOrOrganizationExecute(apiRequest)
 
 	// Call API
 	if err != nil {
-		error_body, _ := io.ReadAll(apiResponse.Body)
 		resp.Diagnostics.AddError(
 			"Error creating application role membership",
-			"Could not create application role membership, unexpected error: "+apiResponse.Status+": "+string(error_body),
+			apiErrorDetail("Could not create application role membership", apiResponse, err),
 		)
 		return
 	}
 
 	// Map response body to schema and populate Computed attribute values.
 	// Because the application role membership does not have an ID of its own, we create a synthetic one based on the provided attributes.
-	data.ID = types.StringValue(fmt.Sprintf("%s_%s_%s_%s", data.ApplicationId.ValueString(), data.RoleId.ValueString(), memberType, memberName))
+	data.ID = types.StringValue(formatEscapedId(data.ApplicationId.ValueString(), data.RoleId.ValueString(), memberType, memberName))
+
+	// IQ Server may not return the new entity straight away
+	resp.Diagnostics.Append(markCreated(ctx, resp.Private)...)
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, data)
@@ -157,23 +205,6 @@ func (r *applicationRoleMembershipResource) Read(ctx context.Context, req resour
 		r.auth,
 	)
 
-	// Get refreshed application role membership from IQ
-	apiRequest := r.client.RoleMembershipsAPI.GetRoleMembershipsApplicationOrOrganization(ctx, "application", data.ApplicationId.ValueString())
-	roleMemberships, apiResponse, err := r.client.RoleMembershipsAPI.GetRoleMembershipsApplicationOrOrganizationExecute(apiRequest)
-
-	// Check if we received a list of role mappings.
-	if err != nil {
-		if apiResponse.StatusCode == http.StatusNotFound {
-			resp.State.RemoveResource(ctx)
-		} else {
-			resp.Diagnostics.AddError(
-				"Error Reading IQ application role membership",
-				"Could not read applicaton role membership with ID "+data.ID.ValueString()+": "+err.Error(),
-			)
-		}
-		return
-	}
-
 	// Determine the member type, which can be any of group or user.
 	// The resource validator makes sure that exactly one of these is configured.
 	var memberType, memberName string
@@ -185,16 +216,46 @@ func (r *applicationRoleMembershipResource) Read(ctx context.Context, req resour
 		memberName = data.UserName.ValueString()
 	}
 
-	// Find our application role membership mapping
+	applicationId, diags := resolveApplicationId(ctx, r.client, data.ApplicationId.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Get refreshed application role membership from IQ and find our application role membership mapping
 	var applicationRoleMembership *sonatypeiq.ApiMemberDTO
-	for _, roleMembership := range roleMemberships.MemberMappings {
-		if *roleMembership.RoleId == data.RoleId.ValueString() {
-			for _, member := range roleMembership.Members {
-				if *member.Type == memberType && *member.UserOrGroupName == memberName && *member.OwnerType == "APPLICATION" && *member.OwnerId == data.ApplicationId.ValueString() {
-					applicationRoleMembership = &member
+	var apiResponse *http.Response
+	var err error
+	resp.Diagnostics.Append(readConsistently(ctx, req.Private, resp.Private, func(ctx context.Context) bool {
+		var roleMemberships *sonatypeiq.ApiRoleMemberMappingListDTO
+		roleMemberships, apiResponse, err = r.client.RoleMembershipsAPI.GetRoleMembershipsApplicationOrOrganization(ctx, "application", applicationId).Execute()
+		if err != nil {
+			return isNotFound(apiResponse)
+		}
+
+		for _, roleMembership := range roleMemberships.MemberMappings {
+			if *roleMembership.RoleId == data.RoleId.ValueString() {
+				for _, member := range roleMembership.Members {
+					if *member.Type == memberType && memberNameMatches(member.GetUserOrGroupName(), memberName, data.IgnoreMemberNameCase.ValueBool()) && *member.OwnerType == "APPLICATION" && *member.OwnerId == applicationId {
+						applicationRoleMembership = &member
+					}
 				}
 			}
 		}
+		return applicationRoleMembership == nil
+	})...)
+
+	// Check if we received a list of role mappings.
+	if err != nil {
+		if isNotFound(apiResponse) {
+			resp.State.RemoveResource(ctx)
+		} else {
+			resp.Diagnostics.AddError(
+				"Error Reading IQ application role membership",
+				apiErrorDetail("Could not read application role membership with ID "+data.ID.ValueString(), apiResponse, err),
+			)
+		}
+		return
 	}
 
 	if applicationRoleMembership == nil {
@@ -209,55 +270,18 @@ func (r *applicationRoleMembershipResource) Read(ctx context.Context, req resour
 	}
 }
 
-// // Update updates the resource and sets the updated Terraform state on success.
-//
-//	func (r *applicationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-//		var plan applicationModelResource
-//		var state applicationModelResource
-//		resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
-//		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
-//		if resp.Diagnostics.HasError() {
-//			return
-//		}
-//
-//		// Make Update API Call
-//		ctx = context.WithValue(
-//			ctx,
-//			sonatypeiq.ContextBasicAuth,
-//			r.auth,
-//		)
-//		app_update_request := r.client.ApplicationsAPI.UpdateApplication(ctx, state.ID.ValueString())
-//		app_update_request = app_update_request.ApiApplicationDTO(sonatypeiq.ApiApplicationDTO{
-//			Name:            plan.Name.ValueStringPointer(),
-//			PublicId:        plan.PublicId.ValueStringPointer(),
-//			OrganizationId:  plan.OrganizationId.ValueStringPointer(),
-//			ContactUserName: plan.ContactUserName.ValueStringPointer(),
-//		})
-//
-//		application, api_response, err := app_update_request.Execute()
-//
-//		// Call API
-//		if err != nil {
-//			error_body, _ := io.ReadAll(api_response.Body)
-//			resp.Diagnostics.AddError(
-//				"Error updating Application",
-//				"Could not update Application, unexpected error: "+api_response.Status+": "+string(error_body),
-//			)
-//			return
-//		}
-//
-//		// Map response body to schema and populate Computed attribute values
-//		plan.ID = types.StringValue(*application.Id)
-//		plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
-//
-//		// Set state to fully populated data
-//		resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
-//		if resp.Diagnostics.HasError() {
-//			return
-//		}
-//
-// }
-//
+// Update updates the resource and sets the updated Terraform state on success. All attributes
+// that identify the application role membership require replacement, so only options are updated.
+func (r *applicationRoleMembershipResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan applicationRoleMembershipModelResource
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *applicationRoleMembershipResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var data applicationRoleMembershipModelResource
@@ -284,14 +308,96 @@ func (r *applicationRoleMembershipResource) Delete(ctx context.Context, req reso
 		memberName = data.UserName.ValueString()
 	}
 
-	apiRequest := r.client.RoleMembershipsAPI.RevokeRoleMembershipApplicationOrOrganization(ctx, "application", data.ApplicationId.ValueString(), data.RoleId.ValueString(), memberType, memberName)
+	applicationId, diags := resolveApplicationId(ctx, r.client, data.ApplicationId.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiRequest := r.client.RoleMembershipsAPI.RevokeRoleMembershipApplicationOrOrganization(ctx, "application", applicationId, data.RoleId.ValueString(), memberType, memberName)
 	apiResponse, err := r.client.RoleMembershipsAPI.RevokeRoleMembershipApplicationOrOrganizationExecute(apiRequest)
 	if err != nil {
-		error_body, _ := io.ReadAll(apiResponse.Body)
 		resp.Diagnostics.AddError(
 			"Error deleting application role membership",
-			"Could not delete application role membership, unexpected error: "+apiResponse.Status+": "+string(error_body),
+			apiErrorDetail("Could not delete application role membership", apiResponse, err),
+		)
+		return
+	}
+}
+
+// UpgradeState upgrades IDs created by earlier versions of the provider.
+func (r *applicationRoleMembershipResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: upgradeRoleMembershipIdV0("application_id"),
+	}
+}
+
+// MoveState moves the resource from the Sonatype IQ community provider.
+func (r *applicationRoleMembershipResource) MoveState(_ context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		moveResourceState("sonatypeiq_application_role_membership", func(state map[string]interface{}) {
+			upgradeRoleMembershipState(state, "application_id")
+		}),
+	}
+}
+
+// ImportState imports the application role membership by its ID of the form
+// <application_id>/<role_id>/<user|group>/<user_name|group_name>, with each part URL-escaped. IDs of
+// the form <application_id>_<role_id>_<user|group>_<user_name|group_name> are still accepted.
+func (r *applicationRoleMembershipResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts, err := parseEscapedId(req.ID, "application_id", "role_id", "member_type", "member_name")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid application role membership ID", err.Error())
+		return
+	}
+
+	var memberAttribute string
+	switch parts[2] {
+	case "user":
+		memberAttribute = "user_name"
+	case "group":
+		memberAttribute = "group_name"
+	default:
+		resp.Diagnostics.AddError(
+			"Invalid application role membership ID",
+			fmt.Sprintf("The member type of ID %s must be either user or group, got: %s", req.ID, parts[2]),
 		)
 		return
 	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), formatEscapedId(parts...))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("application_id"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("role_id"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(memberAttribute), parts[3])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("ignore_member_name_case"), false)...)
+	resp.Diagnostics.Append(markCreated(ctx, resp.Private)...)
+}
+
+// resolveApplicationId returns the internal ID of the Application with the given public ID. Values
+// that are not the public ID of any Application are assumed to be an internal ID already.
+func resolveApplicationId(ctx context.Context, client *sonatypeiq.APIClient, applicationId string) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	applications, apiResponse, err := client.ApplicationsAPI.GetApplications(ctx).PublicId([]string{applicationId}).Execute()
+	if err != nil {
+		diags.AddError(
+			"Error resolving Application",
+			apiErrorDetail("Could not look up Application with public ID "+applicationId, apiResponse, err),
+		)
+		return "", diags
+	}
+	for _, application := range applications.Applications {
+		if application.GetPublicId() == applicationId {
+			return application.GetId(), diags
+		}
+	}
+	return applicationId, diags
+}
+
+// memberNameMatches reports whether the name of a member returned by IQ Server matches the
+// configured name. Realms such as LDAP and SAML may return names with a different casing.
+func memberNameMatches(actual string, configured string, ignoreCase bool) bool {
+	if ignoreCase {
+		return strings.EqualFold(actual, configured)
+	}
+	return actual == configured
 }
@@ -26,6 +26,7 @@ import (
 func TestAccApplicationRoleMembershipResource(t *testing.T) {
 
 	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccSandbox(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			// Create and Read testing
@@ -53,13 +54,36 @@ func TestAccApplicationRoleMembershipResource(t *testing.T) {
           user_name      = sonatypeiq_user.user.username
         }
 
+        data "sonatypeiq_role" "owner" {
+          name = "Owner"
+        }
+
+        resource "sonatypeiq_application_role_membership" "public_id" {
+          role_id        = data.sonatypeiq_role.owner.id
+          application_id = data.sonatypeiq_application.sandbox.public_id
+          user_name      = sonatypeiq_user.user.username
+        }
+
         `),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					// Verify application role membership
 					resource.TestCheckResourceAttrSet("sonatypeiq_application_role_membership.test", "id"),
 					resource.TestCheckResourceAttr("sonatypeiq_application_role_membership.test", "user_name", "example2"),
+					// Verify application role membership by public ID
+					resource.TestCheckResourceAttr("sonatypeiq_application_role_membership.public_id", "application_id", "sandbox-application"),
 				),
 			},
+			// ImportState testing
+			{
+				ResourceName:      "sonatypeiq_application_role_membership.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "sonatypeiq_application_role_membership.public_id",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
@@ -19,6 +19,7 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net/http"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
@@ -45,8 +46,12 @@ type applicationsDataSource struct {
 }
 
 type applicationsDataSourceModel struct {
-	ID           types.String       `tfsdk:"id"`
-	Applications []applicationModel `tfsdk:"applications"`
+	ID             types.String       `tfsdk:"id"`
+	OrganizationId types.String       `tfsdk:"organization_id"`
+	PublicIds      []types.String     `tfsdk:"public_ids"`
+	Applications   []applicationModel `tfsdk:"applications"`
+	MaxResults     types.Int64        `tfsdk:"max_results"`
+	TotalCount     types.Int64        `tfsdk:"total_count"`
 }
 
 type applicationModel struct {
@@ -72,61 +77,26 @@ func (d *applicationsDataSource) Metadata(_ context.Context, req datasource.Meta
 // Schema defines the schema for the data source.
 func (d *applicationsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		Description: "Use this data source to get all Applications",
+		Description: "Use this data source to get all Applications, optionally only those of an Organization or with given Public IDs. " +
+			"The filters are applied by IQ Server, so that only the matching Applications are transferred",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
 			},
+			"organization_id": schema.StringAttribute{
+				Description: "Only return the Applications that belong directly to this Organization - use 'ROOT_ORGANIZATION_ID' for the Root Organization",
+				Optional:    true,
+			},
+			"public_ids": schema.SetAttribute{
+				Description: "Only return the Applications with these Public IDs",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"max_results": maxResultsAttribute("Applications"),
+			"total_count": totalCountAttribute("matching Applications"),
 			"applications": schema.ListNestedAttribute{
-				Computed: true,
-				NestedObject: schema.NestedAttributeObject{
-					Attributes: map[string]schema.Attribute{
-						"id": schema.StringAttribute{
-							Description: "Internal ID of the Application",
-							Computed:    true,
-							Optional:    true,
-						},
-						"public_id": schema.StringAttribute{
-							Description: "Public ID of the Application",
-							Computed:    true,
-							Optional:    true,
-						},
-						"name": schema.StringAttribute{
-							Description: "Name of the Application",
-							Computed:    true,
-							Optional:    true,
-						},
-						"organization_id": schema.StringAttribute{
-							Description: "Internal ID of the Organization to which this Application belongs",
-							Computed:    true,
-						},
-						"contact_user_name": schema.StringAttribute{
-							Description: "User Name of the Contact for the Application",
-							Computed:    true,
-							Optional:    true,
-						},
-						"application_tags": schema.ListNestedAttribute{
-							Description: "List of Tags applied to this Application",
-							Computed:    true,
-							NestedObject: schema.NestedAttributeObject{
-								Attributes: map[string]schema.Attribute{
-									"id": schema.StringAttribute{
-										Description: "Internal ID of the Application-Tag link",
-										Computed:    true,
-									},
-									"tag_id": schema.StringAttribute{
-										Description: "Internal ID of the Tag",
-										Computed:    true,
-									},
-									"application_id": schema.StringAttribute{
-										Description: "Internal ID of the Application",
-										Computed:    true,
-									},
-								},
-							},
-						},
-					},
-				},
+				Computed:     true,
+				NestedObject: applicationNestedObject(),
 			},
 		},
 	}
@@ -134,7 +104,15 @@ func (d *applicationsDataSource) Schema(_ context.Context, _ datasource.SchemaRe
 
 // Read refreshes the Terraform state with the latest data.
 func (d *applicationsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if deferUnknownOwnerRead(req, resp) {
+		return
+	}
+
 	var state applicationsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	ctx = context.WithValue(
 		ctx,
@@ -142,36 +120,46 @@ func (d *applicationsDataSource) Read(ctx context.Context, req datasource.ReadRe
 		d.auth,
 	)
 
-	applicationList, _, err := d.client.ApplicationsAPI.GetApplications(ctx).Execute()
+	var publicIds []string
+	for _, publicId := range state.PublicIds {
+		publicIds = append(publicIds, publicId.ValueString())
+	}
+
+	// The Public IDs are the most selective filter, so the Organization is only filtered on by
+	// IQ Server when no Public IDs are given
+	var applicationList *sonatypeiq.ApiApplicationListDTO
+	var api_response *http.Response
+	var err error
+	if len(publicIds) > 0 {
+		applicationList, api_response, err = d.client.ApplicationsAPI.GetApplications(ctx).PublicId(publicIds).Execute()
+	} else if !state.OrganizationId.IsNull() {
+		applicationList, api_response, err = d.client.ApplicationsAPI.GetApplicationsByOrganizationId(ctx, state.OrganizationId.ValueString()).Execute()
+	} else {
+		applicationList, api_response, err = d.client.ApplicationsAPI.GetApplications(ctx).Execute()
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read IQ Applications",
-			err.Error(),
+			describeApiError(api_response, err),
 		)
 		return
 	}
 
+	if len(publicIds) > 0 && !state.OrganizationId.IsNull() {
+		var applications []sonatypeiq.ApiApplicationDTO
+		for _, application := range applicationList.Applications {
+			if application.GetOrganizationId() == state.OrganizationId.ValueString() {
+				applications = append(applications, application)
+			}
+		}
+		applicationList.Applications = applications
+	}
+
 	tflog.Debug(ctx, fmt.Sprintf("Iterating %d Applications", len(applicationList.Applications)))
 
-	for _, application := range applicationList.Applications {
-		var contactUserName = types.StringNull()
-		if application.ContactUserName != nil {
-			contactUserName = types.StringValue(*application.ContactUserName)
-		}
-		applicationState := applicationModel{
-			ID:              types.StringValue(*application.Id),
-			PublicId:        types.StringValue(*application.PublicId),
-			Name:            types.StringValue(*application.Name),
-			OrganizationId:  types.StringValue(*application.OrganizationId),
-			ContactUserName: contactUserName,
-		}
-		for _, tag := range application.ApplicationTags {
-			applicationState.ApplicationTags = append(applicationState.ApplicationTags, applicationTagLinkModel{
-				ID:            types.StringValue(*tag.Id),
-				TagId:         types.StringValue(*tag.TagId),
-				ApplicationId: types.StringValue(*tag.ApplicationId),
-			})
-		}
+	state.TotalCount = types.Int64Value(int64(len(applicationList.Applications)))
+	for _, application := range limitResults(applicationList.Applications, state.MaxResults, "Applications", &resp.Diagnostics) {
+		applicationState := applicationModelFromDTO(application)
 
 		state.Applications = append(state.Applications, applicationState)
 
@@ -188,3 +176,78 @@ func (d *applicationsDataSource) Read(ctx context.Context, req datasource.ReadRe
 		return
 	}
 }
+
+// applicationNestedObject returns the schema of an Application in a list of Applications.
+func applicationNestedObject() schema.NestedAttributeObject {
+	return schema.NestedAttributeObject{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Internal ID of the Application",
+				Computed:    true,
+				Optional:    true,
+			},
+			"public_id": schema.StringAttribute{
+				Description: "Public ID of the Application",
+				Computed:    true,
+				Optional:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of the Application",
+				Computed:    true,
+				Optional:    true,
+			},
+			"organization_id": schema.StringAttribute{
+				Description: "Internal ID of the Organization to which this Application belongs",
+				Computed:    true,
+			},
+			"contact_user_name": schema.StringAttribute{
+				Description: "User Name of the Contact for the Application",
+				Computed:    true,
+				Optional:    true,
+			},
+			"application_tags": schema.ListNestedAttribute{
+				Description: "List of Tags applied to this Application",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Internal ID of the Application-Tag link",
+							Computed:    true,
+						},
+						"tag_id": schema.StringAttribute{
+							Description: "Internal ID of the Tag",
+							Computed:    true,
+						},
+						"application_id": schema.StringAttribute{
+							Description: "Internal ID of the Application",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// applicationModelFromDTO maps an Application returned by IQ Server to the data source model.
+func applicationModelFromDTO(application sonatypeiq.ApiApplicationDTO) applicationModel {
+	var contactUserName = types.StringNull()
+	if application.ContactUserName != nil {
+		contactUserName = types.StringValue(*application.ContactUserName)
+	}
+	applicationState := applicationModel{
+		ID:              types.StringValue(*application.Id),
+		PublicId:        types.StringValue(*application.PublicId),
+		Name:            types.StringValue(*application.Name),
+		OrganizationId:  types.StringValue(*application.OrganizationId),
+		ContactUserName: contactUserName,
+	}
+	for _, tag := range application.ApplicationTags {
+		applicationState.ApplicationTags = append(applicationState.ApplicationTags, applicationTagLinkModel{
+			ID:            types.StringValue(*tag.Id),
+			TagId:         types.StringValue(*tag.TagId),
+			ApplicationId: types.StringValue(*tag.ApplicationId),
+		})
+	}
+	return applicationState
+}
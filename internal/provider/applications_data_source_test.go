@@ -24,6 +24,7 @@ import (
 
 func TestAccApplicationsDataSource(t *testing.T) {
 	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccSandbox(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			// Read testing
@@ -33,6 +34,41 @@ func TestAccApplicationsDataSource(t *testing.T) {
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("data.sonatypeiq_applications.apps", "id", "placeholder"),
 					resource.TestCheckResourceAttrSet("data.sonatypeiq_applications.apps", "applications.#"),
+					resource.TestCheckResourceAttrSet("data.sonatypeiq_applications.apps", "total_count"),
+				),
+			},
+			// Read testing with a cap on the number of results
+			{
+				Config: providerConfig + `data "sonatypeiq_applications" "apps" {
+					max_results = 1
+				}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.sonatypeiq_applications.apps", "applications.#", "1"),
+					resource.TestCheckResourceAttrSet("data.sonatypeiq_applications.apps", "total_count"),
+				),
+			},
+			// Read testing filtered by Public ID
+			{
+				Config: providerConfig + `data "sonatypeiq_applications" "apps" {
+					public_ids = ["sandbox-application"]
+				}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.sonatypeiq_applications.apps", "applications.#", "1"),
+					resource.TestCheckResourceAttr("data.sonatypeiq_applications.apps", "applications.0.public_id", "sandbox-application"),
+					resource.TestCheckResourceAttr("data.sonatypeiq_applications.apps", "total_count", "1"),
+				),
+			},
+			// Read testing filtered by Organization
+			{
+				Config: providerConfig + `data "sonatypeiq_organization" "sandbox" {
+					name = "Sandbox Organization"
+				}
+
+				data "sonatypeiq_applications" "apps" {
+					organization_id = data.sonatypeiq_organization.sandbox.id
+				}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.sonatypeiq_applications.apps", "applications.0.organization_id", "data.sonatypeiq_organization.sandbox", "id"),
 				),
 			},
 		},
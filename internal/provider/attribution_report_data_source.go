@@ -0,0 +1,161 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	sonatypeiq "github.com/sonatype-nexus-community/nexus-iq-api-client-go"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &attributionReportDataSource{}
+	_ datasource.DataSourceWithConfigure = &attributionReportDataSource{}
+)
+
+// AttributionReportDataSource is a helper function to simplify the provider implementation.
+func AttributionReportDataSource() datasource.DataSource {
+	return &attributionReportDataSource{}
+}
+
+// attributionReportDataSource is the data source implementation.
+type attributionReportDataSource struct {
+	baseDataSource
+}
+
+type attributionReportDataSourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	ApplicationId types.String `tfsdk:"application_id"`
+	StageId       types.String `tfsdk:"stage_id"`
+	TemplateId    types.String `tfsdk:"template_id"`
+	Url           types.String `tfsdk:"url"`
+	Content       types.String `tfsdk:"content"`
+	ContentSha256 types.String `tfsdk:"content_sha256"`
+}
+
+// Metadata returns the data source type name.
+func (d *attributionReportDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_attribution_report"
+}
+
+// Schema defines the schema for the data source.
+func (d *attributionReportDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Use this data source to generate the Advanced Legal Pack attribution report (HTML) for the latest evaluation of an Application in a Stage",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"application_id": schema.StringAttribute{
+				Description: "Internal ID or public ID of the Application",
+				Required:    true,
+			},
+			"stage_id": schema.StringAttribute{
+				Description: "Stage of the evaluation to report on (e.g. build, stage-release, release, operate)",
+				Required:    true,
+			},
+			"template_id": schema.StringAttribute{
+				Description: "Internal ID of the attribution report template to use - the default template is used when not set",
+				Optional:    true,
+			},
+			"url": schema.StringAttribute{
+				Description: "URL the attribution report can be downloaded from by an authenticated user",
+				Computed:    true,
+			},
+			"content": schema.StringAttribute{
+				Description: "HTML document of the attribution report",
+				Computed:    true,
+			},
+			"content_sha256": schema.StringAttribute{
+				Description: "SHA-256 checksum of content, e.g. to only publish the report when it changed",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *attributionReportDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if deferUnknownOwnerRead(req, resp) {
+		return
+	}
+
+	var data attributionReportDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = context.WithValue(
+		ctx,
+		sonatypeiq.ContextBasicAuth,
+		d.auth,
+	)
+
+	applicationId, diags := resolveApplicationId(ctx, d.client, data.ApplicationId.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stageId := data.StageId.ValueString()
+	reportPath := "/api/v2/licenseLegalMetadata/application/" + url.PathEscape(applicationId) + "/stage/" + url.PathEscape(stageId) + "/report"
+
+	var content string
+	var api_response *http.Response
+	var err error
+	if data.TemplateId.IsNull() {
+		content, api_response, err = d.client.LicenseLegalMetadataAPI.GetLicenseLegalApplicationHTMLReport(ctx, applicationId, stageId).Execute()
+	} else {
+		reportPath += "/templateId/" + url.PathEscape(data.TemplateId.ValueString())
+		// Reports from a template are requested with a POST, which only reads
+		content, api_response, err = d.client.LicenseLegalMetadataAPI.GetLicenseLegalCustomApplicationHTMLReport1(onlyReads(ctx), applicationId, stageId, data.TemplateId.ValueString()).Execute()
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to generate IQ attribution report",
+			describeApiError(api_response, err),
+		)
+		return
+	}
+
+	checksum := sha256.Sum256([]byte(content))
+
+	data.ID = types.StringValue(formatEscapedId(applicationId, stageId))
+	data.Url = types.StringValue(strings.TrimSuffix(d.client.GetConfig().Servers[0].URL, "/") + reportPath)
+	data.Content = types.StringValue(content)
+	data.ContentSha256 = types.StringValue(hex.EncodeToString(checksum[:]))
+
+	// Set state
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,269 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	sonatypeiq "github.com/sonatype-nexus-community/nexus-iq-api-client-go"
+)
+
+// auditLogDateFormat is the date format of the audit log time range.
+const auditLogDateFormat = "2006-01-02"
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &auditLogDataSource{}
+	_ datasource.DataSourceWithConfigure = &auditLogDataSource{}
+)
+
+// AuditLogDataSource is a helper function to simplify the provider implementation.
+func AuditLogDataSource() datasource.DataSource {
+	return &auditLogDataSource{}
+}
+
+// auditLogDataSource is the data source implementation.
+type auditLogDataSource struct {
+	baseDataSource
+}
+
+type auditLogDataSourceModel struct {
+	ID         types.String         `tfsdk:"id"`
+	StartDate  types.String         `tfsdk:"start_date"`
+	EndDate    types.String         `tfsdk:"end_date"`
+	Domain     types.String         `tfsdk:"domain"`
+	MaxResults types.Int64          `tfsdk:"max_results"`
+	TotalCount types.Int64          `tfsdk:"total_count"`
+	Entries    []auditLogEntryModel `tfsdk:"entries"`
+}
+
+type auditLogEntryModel struct {
+	Timestamp types.String `tfsdk:"timestamp"`
+	Initiator types.String `tfsdk:"initiator"`
+	Domain    types.String `tfsdk:"domain"`
+	Type      types.String `tfsdk:"type"`
+	Context   types.String `tfsdk:"context"`
+	Json      types.String `tfsdk:"json"`
+}
+
+// auditLogEntry is a single entry of the IQ Server audit log.
+type auditLogEntry struct {
+	Timestamp *string `json:"timestamp,omitempty"`
+	Initiator *string `json:"initiator,omitempty"`
+	Domain    *string `json:"domain,omitempty"`
+	Type      *string `json:"type,omitempty"`
+	Context   *string `json:"context,omitempty"`
+}
+
+// Metadata returns the data source type name.
+func (d *auditLogDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_audit_log"
+}
+
+// Schema defines the schema for the data source.
+func (d *auditLogDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	dateValidators := []validator.String{
+		stringvalidator.RegexMatches(regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`), "must be a date in the format YYYY-MM-DD"),
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Use this data source to export the entries of the IQ Server audit log for a time range",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"start_date": schema.StringAttribute{
+				Description: "First day (UTC) to include entries for, in the format YYYY-MM-DD",
+				Required:    true,
+				Validators:  dateValidators,
+			},
+			"end_date": schema.StringAttribute{
+				Description: "Last day (UTC) to include entries for, in the format YYYY-MM-DD",
+				Required:    true,
+				Validators:  dateValidators,
+			},
+			"domain": schema.StringAttribute{
+				Description: "Only include entries of this domain (e.g. governance.policy, governance.organization)",
+				Optional:    true,
+			},
+			"max_results": maxResultsAttribute("entries"),
+			"total_count": totalCountAttribute("entries"),
+			"entries": schema.ListNestedAttribute{
+				Description: "Audit log entries, oldest first",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"timestamp": schema.StringAttribute{
+							Description: "Time the change was made",
+							Computed:    true,
+						},
+						"initiator": schema.StringAttribute{
+							Description: "User and address that made the change",
+							Computed:    true,
+						},
+						"domain": schema.StringAttribute{
+							Description: "Domain of the change (e.g. governance.policy)",
+							Computed:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "Type of the change (e.g. create, update, delete)",
+							Computed:    true,
+						},
+						"context": schema.StringAttribute{
+							Description: "Organization or Application the change was made in",
+							Computed:    true,
+						},
+						"json": schema.StringAttribute{
+							Description: "The complete entry as JSON, including the attributes of the change",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *auditLogDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data auditLogDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	startDate, _ := time.Parse(auditLogDateFormat, data.StartDate.ValueString())
+	endDate, _ := time.Parse(auditLogDateFormat, data.EndDate.ValueString())
+	if endDate.Before(startDate) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("end_date"),
+			"Invalid Time Range",
+			fmt.Sprintf("end_date %s is before start_date %s", data.EndDate.ValueString(), data.StartDate.ValueString()),
+		)
+		return
+	}
+
+	ctx = context.WithValue(
+		ctx,
+		sonatypeiq.ContextBasicAuth,
+		d.auth,
+	)
+
+	query := url.Values{}
+	query.Set("startUtcDate", data.StartDate.ValueString())
+	query.Set("endUtcDate", data.EndDate.ValueString())
+	api_response, err := callIqApi(ctx, d.client, http.MethodGet, "/api/v2/auditLogs?"+query.Encode(), nil, nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read IQ audit log",
+			describeApiError(api_response, err),
+		)
+		return
+	}
+
+	lines, err := readAuditLogLines(api_response.Body)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read IQ audit log",
+			err.Error(),
+		)
+		return
+	}
+
+	entries := []auditLogEntryModel{}
+	for _, line := range lines {
+		var entry auditLogEntry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read IQ audit log",
+				fmt.Sprintf("Unexpected audit log entry %s: %s", line, err),
+			)
+			return
+		}
+		if !data.Domain.IsNull() && (entry.Domain == nil || *entry.Domain != data.Domain.ValueString()) {
+			continue
+		}
+		entries = append(entries, auditLogEntryModel{
+			Timestamp: types.StringPointerValue(entry.Timestamp),
+			Initiator: types.StringPointerValue(entry.Initiator),
+			Domain:    types.StringPointerValue(entry.Domain),
+			Type:      types.StringPointerValue(entry.Type),
+			Context:   types.StringPointerValue(entry.Context),
+			Json:      types.StringValue(string(line)),
+		})
+	}
+
+	data.TotalCount = types.Int64Value(int64(len(entries)))
+	data.Entries = limitResults(entries, data.MaxResults, "entries", &resp.Diagnostics)
+	data.ID = types.StringValue(data.StartDate.ValueString() + "_" + data.EndDate.ValueString())
+
+	// Set state
+	diags := resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// readAuditLogLines splits an audit log response into its entries. IQ Server returns one JSON
+// object per line, but a JSON array of entries is accepted as well.
+func readAuditLogLines(body io.Reader) ([][]byte, error) {
+	content, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+
+	if trimmed := bytes.TrimSpace(content); len(trimmed) > 0 && trimmed[0] == '[' {
+		var entries []json.RawMessage
+		if err := json.Unmarshal(trimmed, &entries); err != nil {
+			return nil, err
+		}
+		lines := make([][]byte, 0, len(entries))
+		for _, entry := range entries {
+			lines = append(lines, []byte(entry))
+		}
+		return lines, nil
+	}
+
+	lines := [][]byte{}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(content)+1)
+	for scanner.Scan() {
+		if line := bytes.TrimSpace(scanner.Bytes()); len(line) > 0 {
+			lines = append(lines, append([]byte(nil), line...))
+		}
+	}
+	return lines, scanner.Err()
+}
@@ -0,0 +1,138 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// auditEntry is a line of the audit log file.
+type auditEntry struct {
+	Time          string `json:"time"`
+	Actor         string `json:"actor"`
+	Method        string `json:"method"`
+	Url           string `json:"url"`
+	Status        int    `json:"status,omitempty"`
+	Error         string `json:"error,omitempty"`
+	PayloadSha256 string `json:"payload_sha256,omitempty"`
+	DurationMs    int64  `json:"duration_ms"`
+}
+
+// auditTransport appends every request that could change IQ Server, that is every request other
+// than GET, HEAD and OPTIONS that is not marked as only reading, to a JSON Lines file once it has
+// completed. Payloads are recorded by their SHA-256 digest only, so that no secrets end up in the
+// file.
+type auditTransport struct {
+	next http.RoundTripper
+	path string
+
+	// actor identifies who the requests are sent as
+	actor string
+
+	mu sync.Mutex
+}
+
+func (t *auditTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return t.next.RoundTrip(req)
+	}
+	if markedOnlyReads(req) {
+		return t.next.RoundTrip(req)
+	}
+
+	entry := auditEntry{
+		Time:   time.Now().UTC().Format(time.RFC3339Nano),
+		Actor:  t.actor,
+		Method: req.Method,
+		Url:    req.URL.Redacted(),
+	}
+	if req.Body != nil && req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			digest := sha256.New()
+			_, _ = io.Copy(digest, body)
+			body.Close()
+			entry.PayloadSha256 = hex.EncodeToString(digest.Sum(nil))
+		}
+	}
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	entry.DurationMs = time.Since(start).Milliseconds()
+	if resp != nil {
+		entry.Status = resp.StatusCode
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+
+	if auditErr := t.append(entry); auditErr != nil {
+		tflog.Error(req.Context(), "Unable to write to the audit log file", map[string]interface{}{
+			"path":  t.path,
+			"error": auditErr.Error(),
+		})
+	}
+	return resp, err
+}
+
+// append writes an entry to the audit log file. The file is opened for every entry, so that
+// several Terraform runs, or providers, can share it.
+func (t *auditTransport) append(entry auditEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return appendAuditLog(t.path, append(line, '\n'))
+}
+
+// appendAuditLog appends data to the audit log file at path, creating it when it does not exist.
+func appendAuditLog(path string, data []byte) error {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(data)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// auditActor describes who requests are sent as, without revealing any credentials.
+func auditActor(config SonatypeIqProviderModel) string {
+	switch {
+	case config.AuthMode.ValueString() == authModeBearer:
+		return "bearer token"
+	case config.AuthMode.ValueString() == authModeHeader:
+		return "token in " + config.AuthHeader.ValueString() + " header"
+	case len(config.UserCode.ValueString()) > 0:
+		return "user token"
+	}
+	return config.Username.ValueString()
+}
@@ -0,0 +1,106 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestAuditTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	auditLogFile := filepath.Join(t.TempDir(), "audit.jsonl")
+	client, diags := newHttpClient(SonatypeIqProviderModel{
+		Url:          types.StringValue(server.URL),
+		Username:     types.StringValue("admin"),
+		AuditLogFile: types.StringValue(auditLogFile),
+	})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	resp, err := client.Get(server.URL + "/api/v2/organizations")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	resp.Body.Close()
+
+	payload := `{"name":"Sandbox Organization"}`
+	resp, err = client.Post(server.URL+"/api/v2/organizations", "application/json", strings.NewReader(payload))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	resp.Body.Close()
+
+	// Queries that only read are not audited, although they are sent as a POST
+	req, err := http.NewRequestWithContext(onlyReads(context.Background()), http.MethodPost, server.URL+"/api/v2/reports/metrics", strings.NewReader(`{"timePeriod":"MONTH"}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	resp, err = client.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	resp.Body.Close()
+
+	// The mark does not exempt requests that change IQ Server
+	req, err = http.NewRequestWithContext(onlyReads(context.Background()), http.MethodDelete, server.URL+"/api/v2/labels/organization/ROOT_ORGANIZATION_ID/00000000000000000000000000000000", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	resp, err = client.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	resp.Body.Close()
+
+	content, err := os.ReadFile(auditLogFile)
+	if err != nil {
+		t.Fatalf("unable to read audit log file: %s", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[1], `"method":"DELETE"`) {
+		t.Fatalf("expected the POST and the DELETE to be audited, got: %s", content)
+	}
+
+	var entry auditEntry
+	if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil {
+		t.Fatalf("invalid audit log entry: %s", err)
+	}
+	digest := sha256.Sum256([]byte(payload))
+	if entry.Actor != "admin" || entry.Method != http.MethodPost || entry.Status != http.StatusOK ||
+		!strings.HasSuffix(entry.Url, "/api/v2/organizations") || entry.PayloadSha256 != hex.EncodeToString(digest[:]) {
+		t.Errorf("unexpected audit log entry: %s", lines[0])
+	}
+	if strings.Contains(lines[0], "Sandbox Organization") {
+		t.Errorf("expected the payload not to be recorded: %s", lines[0])
+	}
+}
@@ -0,0 +1,44 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"net/http"
+)
+
+// Supported values of the auth_mode provider attribute.
+const (
+	authModeBasic  = "basic"
+	authModeBearer = "bearer"
+	authModeHeader = "header"
+)
+
+// headerAuthTransport authenticates requests with a token in a header rather than with basic
+// authentication, for IQ Server behind an authenticating proxy. The API client always adds basic
+// authentication to its requests, so that header is removed first.
+type headerAuthTransport struct {
+	next   http.RoundTripper
+	header string
+	value  string
+}
+
+func (t *headerAuthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Del("Authorization")
+	req.Header.Set(t.header, t.value)
+	return t.next.RoundTrip(req)
+}
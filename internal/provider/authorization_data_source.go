@@ -0,0 +1,249 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	sonatypeiq "github.com/sonatype-nexus-community/nexus-iq-api-client-go"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                     = &authorizationDataSource{}
+	_ datasource.DataSourceWithConfigure        = &authorizationDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &authorizationDataSource{}
+	_ datasource.DataSourceWithValidateConfig   = &authorizationDataSource{}
+)
+
+// Permissions that can be checked. IQ Server has no API to list the permissions of a user, so the
+// read permission is checked by viewing the owner, and other permissions can only be probed with a
+// DELETE request that requires them, for something that does not exist. As that is still a
+// request that could change IQ Server, probing must be enabled explicitly.
+const (
+	permissionRead                  = "read"
+	permissionWrite                 = "write"
+	permissionManageAccess          = "manage_access"
+	permissionWaivePolicyViolations = "waive_policy_violations"
+)
+
+// AuthorizationDataSource is a helper function to simplify the provider implementation.
+func AuthorizationDataSource() datasource.DataSource {
+	return &authorizationDataSource{}
+}
+
+// authorizationDataSource is the data source implementation.
+type authorizationDataSource struct {
+	baseDataSource
+}
+
+type authorizationDataSourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	OrganizationId types.String `tfsdk:"organization_id"`
+	ApplicationId  types.String `tfsdk:"application_id"`
+	Permission     types.String `tfsdk:"permission"`
+	Probe          types.Bool   `tfsdk:"probe"`
+	Allowed        types.Bool   `tfsdk:"allowed"`
+	Detail         types.String `tfsdk:"detail"`
+}
+
+// Metadata returns the data source type name.
+func (d *authorizationDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_authorization"
+}
+
+// Schema defines the schema for the data source.
+func (d *authorizationDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Use this data source to check whether the provider credentials hold a permission on an Organization or Application, e.g. in a precondition so that a plan fails with a clear message rather than the apply with a 403. " +
+			"As IQ Server cannot list the permissions of a user, only the read permission is checked with a read request. " +
+			"The other permissions can only be probed by removing a label, role membership or policy waiver with a random ID that does not exist, which requires probe to be enabled",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"organization_id": schema.StringAttribute{
+				Description: "Internal ID of the Organization - use 'ROOT_ORGANIZATION_ID' for the Root Organization",
+				Optional:    true,
+			},
+			"application_id": schema.StringAttribute{
+				Description: "Internal ID of the Application",
+				Optional:    true,
+			},
+			"permission": schema.StringAttribute{
+				Description: "Permission to check: read (View IQ Elements), write (Edit IQ Elements), manage_access (Edit Access Control) or waive_policy_violations (Waive Policy Violations)",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(permissionRead, permissionWrite, permissionManageAccess, permissionWaivePolicyViolations),
+				},
+			},
+			"probe": schema.BoolAttribute{
+				Description: "Whether write, manage_access and waive_policy_violations may be checked by sending a DELETE request for something that does not exist on every plan and refresh. " +
+					"Like any other change, the request is refused when the provider is read_only, recorded in its audit_log_file and in the audit log of IQ Server, and clears cached lookups. Defaults to false",
+				Optional: true,
+			},
+			"allowed": schema.BoolAttribute{
+				Description: "Whether the provider credentials hold the permission",
+				Computed:    true,
+			},
+			"detail": schema.StringAttribute{
+				Description: "Why the permission is not held, as reported by Sonatype IQ Server - null when it is held",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *authorizationDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("organization_id"),
+			path.MatchRoot("application_id"),
+		),
+	}
+}
+
+// ValidateConfig requires probe to be enabled for permissions that can only be probed.
+func (d *authorizationDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var data authorizationDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.Permission.IsUnknown() || data.Probe.IsUnknown() {
+		return
+	}
+	if !data.Permission.IsNull() && data.Permission.ValueString() != permissionRead && !data.Probe.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("probe"),
+			"Probe Not Enabled",
+			fmt.Sprintf("The %s permission can only be checked by sending a DELETE request to Sonatype IQ Server. Set probe to true to allow it", data.Permission.ValueString()),
+		)
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *authorizationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if deferUnknownOwnerRead(req, resp) {
+		return
+	}
+
+	var data authorizationDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = context.WithValue(
+		ctx,
+		sonatypeiq.ContextBasicAuth,
+		d.auth,
+	)
+
+	ownerType, ownerId, ownerPath := "organization", data.OrganizationId.ValueString(), "/api/v2/organizations/"
+	if !data.ApplicationId.IsNull() {
+		ownerType, ownerId, ownerPath = "application", data.ApplicationId.ValueString(), "/api/v2/applications/"
+	}
+	permission := data.Permission.ValueString()
+	data.ID = types.StringValue(fmt.Sprintf("%s/%s/%s", ownerType, ownerId, permission))
+	data.Allowed = types.BoolValue(true)
+	data.Detail = types.StringNull()
+
+	// Every permission requires the owner to be visible, which also tells a missing owner apart
+	// from a probe of something that does not exist
+	apiResponse, err := callIqApi(ctx, d.client, http.MethodGet, ownerPath+url.PathEscape(ownerId), nil, nil)
+	if err != nil && isNotFound(apiResponse) {
+		resp.Diagnostics.AddError(
+			"Unable to Check IQ Authorization",
+			fmt.Sprintf("The %s %s does not exist", ownerType, ownerId),
+		)
+		return
+	}
+
+	if err == nil && permission != permissionRead {
+		if !data.Probe.ValueBool() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("probe"),
+				"Probe Not Enabled",
+				fmt.Sprintf("The %s permission can only be checked by sending a DELETE request to Sonatype IQ Server. Set probe to true to allow it", permission),
+			)
+			return
+		}
+
+		probeId, probeErr := randomId()
+		if probeErr != nil {
+			resp.Diagnostics.AddError("Unable to Check IQ Authorization", probeErr.Error())
+			return
+		}
+
+		var probePath string
+		switch permission {
+		case permissionWrite:
+			probePath = fmt.Sprintf("/api/v2/labels/%s/%s/%s", ownerType, url.PathEscape(ownerId), probeId)
+		case permissionManageAccess:
+			probePath = fmt.Sprintf("/api/v2/roleMemberships/%s/%s/role/%s/user/%s", ownerType, url.PathEscape(ownerId), probeId, probeId)
+		case permissionWaivePolicyViolations:
+			probePath = fmt.Sprintf("/api/v2/policyWaivers/%s/%s/%s", ownerType, url.PathEscape(ownerId), probeId)
+		}
+		// The probe is sent like any other change, so it is refused in read_only mode and audited
+		apiResponse, err = callIqApi(ctx, d.client, http.MethodDelete, probePath, nil, nil)
+
+		// The probe is authorized when IQ Server gets to find out that what it removes does not
+		// exist. Other errors, such as a 400 from validating the request before authorizing it, do
+		// not tell whether the permission is held.
+		if err != nil && isNotFound(apiResponse) {
+			err = nil
+		}
+	}
+
+	if err != nil {
+		if apiResponse == nil || (apiResponse.StatusCode != http.StatusForbidden && apiResponse.StatusCode != http.StatusUnauthorized) {
+			resp.Diagnostics.AddError(
+				"Unable to Check IQ Authorization",
+				describeApiError(apiResponse, err),
+			)
+			return
+		}
+		data.Allowed = types.BoolValue(false)
+		data.Detail = types.StringValue(describeApiError(apiResponse, err))
+	}
+
+	// Set state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// randomId returns a random ID in the format of IQ Server internal IDs, which does not identify
+// anything that exists.
+func randomId() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(id), nil
+}
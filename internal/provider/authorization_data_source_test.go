@@ -0,0 +1,169 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	sonatypeiq "github.com/sonatype-nexus-community/nexus-iq-api-client-go"
+)
+
+func TestAccAuthorizationDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+data "sonatypeiq_authorization" "read" {
+  organization_id = "ROOT_ORGANIZATION_ID"
+  permission      = "read"
+}
+
+data "sonatypeiq_authorization" "write" {
+  organization_id = "ROOT_ORGANIZATION_ID"
+  permission      = "write"
+  probe           = true
+}
+
+data "sonatypeiq_authorization" "manage_access" {
+  organization_id = "ROOT_ORGANIZATION_ID"
+  permission      = "manage_access"
+  probe           = true
+}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.sonatypeiq_authorization.read", "id", "organization/ROOT_ORGANIZATION_ID/read"),
+					resource.TestCheckResourceAttr("data.sonatypeiq_authorization.read", "allowed", "true"),
+					resource.TestCheckNoResourceAttr("data.sonatypeiq_authorization.read", "detail"),
+					resource.TestCheckResourceAttr("data.sonatypeiq_authorization.write", "allowed", "true"),
+					resource.TestCheckResourceAttr("data.sonatypeiq_authorization.manage_access", "allowed", "true"),
+				),
+			},
+			// Owners that do not exist are an error rather than a missing permission
+			{
+				Config: providerConfig + `
+data "sonatypeiq_authorization" "missing" {
+  application_id = "00000000000000000000000000000000"
+  permission     = "read"
+}`,
+				ExpectError: regexp.MustCompile("does not exist"),
+			},
+		},
+	})
+}
+
+func TestAuthorizationDataSourceProbe(t *testing.T) {
+	for name, tc := range map[string]struct {
+		probe       bool
+		readOnly    bool
+		probeStatus int
+		probed      bool
+		allowed     bool
+		error       string
+	}{
+		"allowed":   {probe: true, probeStatus: http.StatusNotFound, probed: true, allowed: true},
+		"forbidden": {probe: true, probeStatus: http.StatusForbidden, probed: true, allowed: false},
+		// A 400 may be reported before the permission is checked at all
+		"bad request": {probe: true, probeStatus: http.StatusBadRequest, probed: true, error: "400"},
+		"disabled":    {error: "Set probe to true"},
+		"read only":   {probe: true, readOnly: true, error: "read_only"},
+	} {
+		var probed bool
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodDelete {
+				probed = true
+				w.WriteHeader(tc.probeStatus)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"id":"ROOT_ORGANIZATION_ID","name":"Root Organization"}`))
+		}))
+
+		auditLogFile := filepath.Join(t.TempDir(), "audit.jsonl")
+		httpClient, diags := newHttpClient(SonatypeIqProviderModel{
+			Url:          types.StringValue(server.URL),
+			ReadOnly:     types.BoolValue(tc.readOnly),
+			AuditLogFile: types.StringValue(auditLogFile),
+		})
+		if diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+		configuration := sonatypeiq.NewConfiguration()
+		configuration.HTTPClient = httpClient
+		configuration.Servers = []sonatypeiq.ServerConfiguration{{URL: server.URL}}
+
+		d := &authorizationDataSource{}
+		d.client = sonatypeiq.NewAPIClient(configuration)
+
+		ctx := context.Background()
+		var schemaResp datasource.SchemaResponse
+		d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+		objectType := schemaResp.Schema.Type().TerraformType(ctx)
+		config := tftypes.NewValue(objectType, map[string]tftypes.Value{
+			"id":              tftypes.NewValue(tftypes.String, nil),
+			"organization_id": tftypes.NewValue(tftypes.String, "ROOT_ORGANIZATION_ID"),
+			"application_id":  tftypes.NewValue(tftypes.String, nil),
+			"permission":      tftypes.NewValue(tftypes.String, permissionWrite),
+			"probe":           tftypes.NewValue(tftypes.Bool, tc.probe),
+			"allowed":         tftypes.NewValue(tftypes.Bool, nil),
+			"detail":          tftypes.NewValue(tftypes.String, nil),
+		})
+
+		resp := datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)}}
+		d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config}}, &resp)
+		server.Close()
+
+		if probed != tc.probed {
+			t.Errorf("%s: expected probed to be %t", name, tc.probed)
+		}
+		// The probe is audited like any other change
+		content, _ := os.ReadFile(auditLogFile)
+		if audited := strings.Contains(string(content), `"method":"DELETE"`); audited != tc.probed {
+			t.Errorf("%s: expected audited to be %t, got: %s", name, tc.probed, content)
+		}
+		if tc.error != "" {
+			if !resp.Diagnostics.HasError() || !strings.Contains(fmt.Sprint(resp.Diagnostics), tc.error) {
+				t.Errorf("%s: expected an error containing %q, got: %v", name, tc.error, resp.Diagnostics)
+			}
+			continue
+		}
+		if resp.Diagnostics.HasError() {
+			t.Errorf("%s: unexpected diagnostics: %v", name, resp.Diagnostics)
+			continue
+		}
+		var allowed types.Bool
+		resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("allowed"), &allowed)...)
+		if allowed.ValueBool() != tc.allowed {
+			t.Errorf("%s: expected allowed to be %t, got %s", name, tc.allowed, allowed)
+		}
+	}
+}
@@ -0,0 +1,324 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	sonatypeiq "github.com/sonatype-nexus-community/nexus-iq-api-client-go"
+)
+
+// autoPolicyWaiverResource is the resource implementation.
+type autoPolicyWaiverResource struct {
+	baseResource
+}
+
+type autoPolicyWaiverModelResource struct {
+	ID             types.String `tfsdk:"id"`
+	OrganizationId types.String `tfsdk:"organization_id"`
+	ApplicationId  types.String `tfsdk:"application_id"`
+	ThreatLevel    types.Int64  `tfsdk:"threat_level"`
+	Reachable      types.Bool   `tfsdk:"reachable"`
+	PathForward    types.Bool   `tfsdk:"path_forward"`
+}
+
+// autoPolicyWaiverDTO is the Auto Policy Waiver configuration of an owner as exchanged with IQ
+// Server.
+type autoPolicyWaiverDTO struct {
+	AutoPolicyWaiverId *string `json:"autoPolicyWaiverId,omitempty"`
+	OwnerId            *string `json:"ownerId,omitempty"`
+	ThreatLevel        *int64  `json:"threatLevel,omitempty"`
+	Reachable          *bool   `json:"reachable,omitempty"`
+	PathForward        *bool   `json:"pathForward,omitempty"`
+}
+
+// NewAutoPolicyWaiverResource is a helper function to simplify the provider implementation.
+func NewAutoPolicyWaiverResource() resource.Resource {
+	return &autoPolicyWaiverResource{}
+}
+
+// Metadata returns the resource type name.
+func (r *autoPolicyWaiverResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_auto_policy_waiver"
+}
+
+// Schema defines the schema for the resource.
+func (r *autoPolicyWaiverResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Use this resource to enable automatic waivers of low risk security violations for an Organization or Application. " +
+			"Destroying this resource disables automatic waivers for the owner, unless they are inherited from a parent Organization",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Internal ID of the Auto Policy Waiver configuration",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"organization_id": schema.StringAttribute{
+				Description: "Internal ID of the Organization - use 'ROOT_ORGANIZATION_ID' for the Root Organization",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					organizationIdValidator,
+				},
+			},
+			"application_id": schema.StringAttribute{
+				Description: "Internal ID or public ID of the Application",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"threat_level": schema.Int64Attribute{
+				Description: "Highest threat level (1-10) of the security violations that are waived automatically",
+				Required:    true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 10),
+				},
+			},
+			"reachable": schema.BoolAttribute{
+				Description: "Whether violations are also waived when the vulnerable code is reachable. Defaults to false",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"path_forward": schema.BoolAttribute{
+				Description: "Whether violations are also waived when an upgrade of the component that resolves them is available. Defaults to false",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+func (r *autoPolicyWaiverResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("organization_id"),
+			path.MatchRoot("application_id"),
+		),
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *autoPolicyWaiverResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan autoPolicyWaiverModelResource
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = context.WithValue(
+		ctx,
+		sonatypeiq.ContextBasicAuth,
+		r.auth,
+	)
+
+	autoPolicyWaiver, diags := r.save(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = types.StringPointerValue(autoPolicyWaiver.AutoPolicyWaiverId)
+
+	// IQ Server may not return the new entity straight away
+	resp.Diagnostics.Append(markCreated(ctx, resp.Private)...)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *autoPolicyWaiverResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state autoPolicyWaiverModelResource
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = context.WithValue(
+		ctx,
+		sonatypeiq.ContextBasicAuth,
+		r.auth,
+	)
+
+	ownerType, ownerId, diags := r.resolveOwner(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Auto Policy Waivers are not exposed by the generated API client. IQ Server returns the
+	// configuration that applies to the owner, which may be inherited from a parent Organization.
+	var autoPolicyWaiver autoPolicyWaiverDTO
+	var apiResponse *http.Response
+	var err error
+	resp.Diagnostics.Append(readConsistently(ctx, req.Private, resp.Private, func(ctx context.Context) bool {
+		autoPolicyWaiver = autoPolicyWaiverDTO{}
+		apiResponse, err = callIqApi(ctx, r.client, http.MethodGet, "/api/v2/autoPolicyWaivers/"+ownerType+"/"+url.PathEscape(ownerId), nil, &autoPolicyWaiver)
+		if err != nil {
+			return isNotFound(apiResponse)
+		}
+		return autoPolicyWaiver.GetId() != state.ID.ValueString()
+	})...)
+	if err != nil {
+		if isNotFound(apiResponse) {
+			resp.State.RemoveResource(ctx)
+		} else {
+			resp.Diagnostics.AddError(
+				"Error Reading IQ Auto Policy Waiver",
+				apiErrorDetail(fmt.Sprintf("Could not read Auto Policy Waiver configuration of %s %s", ownerType, ownerId), apiResponse, err),
+			)
+		}
+		return
+	}
+
+	if autoPolicyWaiver.GetId() != state.ID.ValueString() {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.ThreatLevel = types.Int64PointerValue(autoPolicyWaiver.ThreatLevel)
+	state.Reachable = types.BoolValue(autoPolicyWaiver.Reachable != nil && *autoPolicyWaiver.Reachable)
+	state.PathForward = types.BoolValue(autoPolicyWaiver.PathForward != nil && *autoPolicyWaiver.PathForward)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *autoPolicyWaiverResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan autoPolicyWaiverModelResource
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = context.WithValue(
+		ctx,
+		sonatypeiq.ContextBasicAuth,
+		r.auth,
+	)
+
+	_, diags := r.save(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *autoPolicyWaiverResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state autoPolicyWaiverModelResource
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = context.WithValue(
+		ctx,
+		sonatypeiq.ContextBasicAuth,
+		r.auth,
+	)
+
+	ownerType, ownerId, diags := r.resolveOwner(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiResponse, err := callIqApi(ctx, r.client, http.MethodDelete, "/api/v2/autoPolicyWaivers/"+ownerType+"/"+url.PathEscape(ownerId)+"/"+url.PathEscape(state.ID.ValueString()), nil, nil)
+	if err != nil && !isNotFound(apiResponse) {
+		resp.Diagnostics.AddError(
+			"Error deleting Auto Policy Waiver",
+			apiErrorDetail("Could not delete Auto Policy Waiver configuration with ID "+state.ID.ValueString(), apiResponse, err),
+		)
+	}
+}
+
+// save creates or updates the Auto Policy Waiver configuration of the model, depending on whether
+// it has an ID.
+func (r *autoPolicyWaiverResource) save(ctx context.Context, m *autoPolicyWaiverModelResource) (*autoPolicyWaiverDTO, diag.Diagnostics) {
+	ownerType, ownerId, diags := r.resolveOwner(ctx, m)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	request := autoPolicyWaiverDTO{
+		OwnerId:     &ownerId,
+		ThreatLevel: m.ThreatLevel.ValueInt64Pointer(),
+		Reachable:   m.Reachable.ValueBoolPointer(),
+		PathForward: m.PathForward.ValueBoolPointer(),
+	}
+	method := http.MethodPost
+	requestPath := "/api/v2/autoPolicyWaivers/" + ownerType + "/" + url.PathEscape(ownerId)
+	if !m.ID.IsNull() && !m.ID.IsUnknown() {
+		request.AutoPolicyWaiverId = m.ID.ValueStringPointer()
+		method = http.MethodPut
+		requestPath += "/" + url.PathEscape(m.ID.ValueString())
+	}
+
+	var autoPolicyWaiver autoPolicyWaiverDTO
+	apiResponse, err := callIqApi(ctx, r.client, method, requestPath, request, &autoPolicyWaiver)
+	if err != nil {
+		diags.AddError(
+			"Error saving Auto Policy Waiver",
+			apiErrorDetail(fmt.Sprintf("Could not save Auto Policy Waiver configuration for %s %s", ownerType, ownerId), apiResponse, err),
+		)
+		return nil, diags
+	}
+	return &autoPolicyWaiver, diags
+}
+
+// resolveOwner returns the type and internal ID of the Organization or Application the Auto Policy
+// Waiver configuration belongs to. The config validator makes sure that exactly one of these is
+// configured.
+func (r *autoPolicyWaiverResource) resolveOwner(ctx context.Context, m *autoPolicyWaiverModelResource) (string, string, diag.Diagnostics) {
+	if m.ApplicationId.IsNull() {
+		return "organization", m.OrganizationId.ValueString(), nil
+	}
+	applicationId, diags := resolveApplicationId(ctx, r.client, m.ApplicationId.ValueString())
+	return "application", applicationId, diags
+}
+
+// GetId returns the ID of the Auto Policy Waiver configuration, or an empty string when it has none.
+func (o autoPolicyWaiverDTO) GetId() string {
+	if o.AutoPolicyWaiverId == nil {
+		return ""
+	}
+	return *o.AutoPolicyWaiverId
+}
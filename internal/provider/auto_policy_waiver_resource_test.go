@@ -0,0 +1,71 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccAutoPolicyWaiverResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccSandbox(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccAutoPolicyWaiverResourceConfig(3, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("sonatypeiq_auto_policy_waiver.test", "id"),
+					resource.TestCheckResourceAttr("sonatypeiq_auto_policy_waiver.test", "threat_level", "3"),
+					resource.TestCheckResourceAttr("sonatypeiq_auto_policy_waiver.test", "reachable", "false"),
+					resource.TestCheckResourceAttr("sonatypeiq_auto_policy_waiver.test", "path_forward", "false"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccAutoPolicyWaiverResourceConfig(5, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("sonatypeiq_auto_policy_waiver.test", "threat_level", "5"),
+					resource.TestCheckResourceAttr("sonatypeiq_auto_policy_waiver.test", "reachable", "true"),
+				),
+			},
+			// Threat levels range from 1 to 10
+			{
+				Config:      testAccAutoPolicyWaiverResourceConfig(11, true),
+				ExpectError: regexp.MustCompile("value must be between 1 and 10"),
+			},
+		},
+	})
+}
+
+func testAccAutoPolicyWaiverResourceConfig(threatLevel int, reachable bool) string {
+	return fmt.Sprintf(providerConfig+`
+data "sonatypeiq_organization" "sandbox" {
+  name = "Sandbox Organization"
+}
+
+resource "sonatypeiq_auto_policy_waiver" "test" {
+  organization_id = data.sonatypeiq_organization.sandbox.id
+  threat_level    = %d
+  reachable       = %t
+}
+`, threatLevel, reachable)
+}
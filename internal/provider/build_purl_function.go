@@ -0,0 +1,178 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &buildPurlFunction{}
+
+// NewBuildPurlFunction is a helper function to simplify the provider implementation.
+func NewBuildPurlFunction() function.Function {
+	return &buildPurlFunction{}
+}
+
+// buildPurlFunction is the function implementation.
+type buildPurlFunction struct{}
+
+// Metadata returns the function name.
+func (f *buildPurlFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "build_purl"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *buildPurlFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Build a Package URL",
+		Description: "Builds a canonical Package URL (purl) from its components, e.g. to identify a Component in a Policy Waiver or evaluation",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "type",
+				Description: "Package type or ecosystem, e.g. maven, npm or pypi",
+			},
+			function.StringParameter{
+				Name:           "namespace",
+				Description:    "Package namespace, e.g. the Maven groupId or npm scope - may be null",
+				AllowNullValue: true,
+			},
+			function.StringParameter{
+				Name:        "name",
+				Description: "Package name",
+			},
+			function.StringParameter{
+				Name:           "version",
+				Description:    "Package version - may be null",
+				AllowNullValue: true,
+			},
+			function.MapParameter{
+				Name:           "qualifiers",
+				Description:    "Package qualifiers, e.g. { type = \"jar\" } - may be null",
+				ElementType:    types.StringType,
+				AllowNullValue: true,
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run builds the Package URL.
+func (f *buildPurlFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var purlType, name string
+	var namespace, version *string
+	var qualifiers map[string]*string
+
+	resp.Error = req.Arguments.Get(ctx, &purlType, &namespace, &name, &version, &qualifiers)
+	if resp.Error != nil {
+		return
+	}
+
+	if len(purlType) == 0 {
+		resp.Error = function.NewArgumentFuncError(0, "The Package type must not be empty")
+		return
+	}
+	if len(name) == 0 {
+		resp.Error = function.NewArgumentFuncError(2, "The Package name must not be empty")
+		return
+	}
+
+	purl := buildPurl(purlType, namespace, name, version, qualifiers)
+
+	resp.Error = resp.Result.Set(ctx, purl)
+}
+
+// buildPurl builds a canonical Package URL following https://github.com/package-url/purl-spec.
+func buildPurl(purlType string, namespace *string, name string, version *string, qualifiers map[string]*string) string {
+	purlType = strings.ToLower(purlType)
+
+	ns := ""
+	if namespace != nil {
+		ns = strings.Trim(*namespace, "/")
+	}
+
+	// Some package types have a case-insensitive or normalized namespace and name
+	switch purlType {
+	case "bitbucket", "github":
+		ns, name = strings.ToLower(ns), strings.ToLower(name)
+	case "npm":
+		name = strings.ToLower(name)
+	case "pypi":
+		name = strings.ReplaceAll(strings.ToLower(name), "_", "-")
+	}
+
+	var sb strings.Builder
+	sb.WriteString("pkg:")
+	sb.WriteString(purlType)
+	sb.WriteString("/")
+	if len(ns) > 0 {
+		for _, segment := range strings.Split(ns, "/") {
+			if len(segment) == 0 {
+				continue
+			}
+			sb.WriteString(escapePurlComponent(segment))
+			sb.WriteString("/")
+		}
+	}
+	sb.WriteString(escapePurlComponent(name))
+
+	if version != nil && len(*version) > 0 {
+		sb.WriteString("@")
+		sb.WriteString(escapePurlComponent(*version))
+	}
+
+	keys := make([]string, 0, len(qualifiers))
+	for key, value := range qualifiers {
+		if value != nil && len(*value) > 0 {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return strings.ToLower(keys[i]) < strings.ToLower(keys[j]) })
+	for i, key := range keys {
+		if i == 0 {
+			sb.WriteString("?")
+		} else {
+			sb.WriteString("&")
+		}
+		sb.WriteString(strings.ToLower(key))
+		sb.WriteString("=")
+		sb.WriteString(escapePurlComponent(*qualifiers[key]))
+	}
+
+	return sb.String()
+}
+
+// escapePurlComponent percent-encodes all characters of a Package URL component other than
+// the unreserved characters.
+func escapePurlComponent(component string) string {
+	var sb strings.Builder
+	for _, b := range []byte(component) {
+		switch {
+		case 'a' <= b && b <= 'z', 'A' <= b && b <= 'Z', '0' <= b && b <= '9', b == '.', b == '-', b == '_', b == '~':
+			sb.WriteByte(b)
+		default:
+			sb.WriteString(fmt.Sprintf("%%%02X", b))
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,62 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccBuildPurlFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "maven" {
+  value = provider::sonatypeiq::build_purl("maven", "org.apache.commons", "commons-lang3", "3.12.0", { type = "jar" })
+}
+
+output "npm" {
+  value = provider::sonatypeiq::build_purl("npm", "@angular", "Core", "17.0.0", null)
+}
+
+output "pypi" {
+  value = provider::sonatypeiq::build_purl("pypi", null, "Django_Rest", null, null)
+}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("maven", "pkg:maven/org.apache.commons/commons-lang3@3.12.0?type=jar"),
+					resource.TestCheckOutput("npm", "pkg:npm/%40angular/core@17.0.0"),
+					resource.TestCheckOutput("pypi", "pkg:pypi/django-rest"),
+				),
+			},
+			{
+				Config: `
+output "invalid" {
+  value = provider::sonatypeiq::build_purl("maven", null, "", null, null)
+}`,
+				ExpectError: regexp.MustCompile("The Package name must not be empty"),
+			},
+		},
+	})
+}
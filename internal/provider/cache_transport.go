@@ -0,0 +1,257 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// cachingTransport caches successful GET requests for the lifetime of the provider instance,
+// which is a single Terraform operation, so that e.g. many sonatypeiq_organization data sources
+// share a single lookup, and refreshing many role membership resources of the same owner fetches
+// its role memberships only once. Any other request invalidates the cache, as it may change what
+// IQ Server would return.
+//
+// Identical GET requests in flight at the same time share a single request to IQ Server, whatever
+// its response, even when caching is disabled. Terraform refreshes resources in parallel, so e.g.
+// the role memberships of an owner would otherwise be fetched once per role membership resource.
+//
+// Invalidated responses that carry an ETag or Last-Modified header are revalidated with a
+// conditional request rather than fetched again, so that e.g. large lists of Applications are
+// only transferred again when they actually changed.
+type cachingTransport struct {
+	next http.RoundTripper
+
+	// cache enables keeping responses once their request completed
+	cache bool
+
+	mu       sync.Mutex
+	entries  map[string]*cacheEntry
+	stale    map[string]*cacheEntry
+	inflight map[string]*inflightRequest
+
+	// generation is incremented on every invalidation, so that responses to requests that were
+	// sent before an invalidation are not cached
+	generation int
+}
+
+// cacheBypassKey is the context key marking requests that must not be served from the cache.
+type cacheBypassKey struct{}
+
+// withoutCache returns a context for requests that are always sent to IQ Server, e.g. because a
+// cached response is known to be outdated. Their responses still replace the cached ones.
+func withoutCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, cacheBypassKey{}, true)
+}
+
+type cacheEntry struct {
+	statusCode int
+	status     string
+	header     http.Header
+	body       []byte
+}
+
+// inflightRequest is a GET request that identical requests wait for rather than sending their own.
+type inflightRequest struct {
+	done  chan struct{}
+	entry *cacheEntry
+	err   error
+}
+
+func newCachingTransport(next http.RoundTripper, cache bool) *cachingTransport {
+	return &cachingTransport{
+		next:     next,
+		cache:    cache,
+		entries:  make(map[string]*cacheEntry),
+		stale:    make(map[string]*cacheEntry),
+		inflight: make(map[string]*inflightRequest),
+	}
+}
+
+func (t *cachingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		resp, err := t.next.RoundTrip(req)
+		if !markedOnlyReads(req) {
+			t.invalidate()
+		}
+		return resp, err
+	}
+
+	// Credentials are part of the key as they determine what a request may see
+	key := req.Header.Get("Authorization") + " " + req.URL.String()
+
+	if bypass, _ := req.Context().Value(cacheBypassKey{}).(bool); bypass {
+		entry, err := t.fetch(key, req)
+		if err != nil {
+			return nil, err
+		}
+		return entry.response(req), nil
+	}
+
+	t.mu.Lock()
+	if entry, ok := t.entries[key]; ok {
+		t.mu.Unlock()
+		tflog.Debug(req.Context(), "Using cached IQ API response", map[string]interface{}{
+			"path": req.URL.Path,
+		})
+		return entry.response(req), nil
+	}
+
+	// Wait for an identical request in flight rather than sending it again
+	if inflight, ok := t.inflight[key]; ok {
+		t.mu.Unlock()
+		tflog.Debug(req.Context(), "Joining IQ API request in flight", map[string]interface{}{
+			"path": req.URL.Path,
+		})
+		select {
+		case <-inflight.done:
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+		if inflight.err != nil {
+			return nil, inflight.err
+		}
+		return inflight.entry.response(req), nil
+	}
+
+	inflight := &inflightRequest{done: make(chan struct{})}
+	t.inflight[key] = inflight
+	t.mu.Unlock()
+
+	inflight.entry, inflight.err = t.fetch(key, req)
+
+	t.mu.Lock()
+	if t.inflight[key] == inflight {
+		delete(t.inflight, key)
+	}
+	t.mu.Unlock()
+	close(inflight.done)
+
+	if inflight.err != nil {
+		return nil, inflight.err
+	}
+	return inflight.entry.response(req), nil
+}
+
+// fetch sends the request and reads its response, so that it can be shared with identical requests
+// in flight. Successful responses are cached.
+func (t *cachingTransport) fetch(key string, req *http.Request) (*cacheEntry, error) {
+	t.mu.Lock()
+	generation := t.generation
+	cached, ok := t.entries[key]
+	if !ok {
+		cached = t.stale[key]
+	}
+	t.mu.Unlock()
+
+	// Requests that are conditional already are left to the caller
+	conditional := req
+	if cached != nil && cached.revalidatable() && req.Header.Get("If-None-Match") == "" && req.Header.Get("If-Modified-Since") == "" {
+		conditional = req.Clone(req.Context())
+		if etag := cached.header.Get("ETag"); etag != "" {
+			conditional.Header.Set("If-None-Match", etag)
+		}
+		if lastModified := cached.header.Get("Last-Modified"); lastModified != "" {
+			conditional.Header.Set("If-Modified-Since", lastModified)
+		}
+	}
+
+	resp, err := t.next.RoundTrip(conditional)
+	if err != nil {
+		return nil, err
+	}
+
+	if conditional != req && resp.StatusCode == http.StatusNotModified {
+		// Drain the body so the connection can be reused
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		tflog.Debug(req.Context(), "Cached IQ API response not modified", map[string]interface{}{
+			"path": req.URL.Path,
+		})
+		t.store(key, cached, generation)
+		return cached, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &cacheEntry{
+		statusCode: resp.StatusCode,
+		status:     resp.Status,
+		header:     resp.Header.Clone(),
+		body:       body,
+	}
+	if resp.StatusCode == http.StatusOK {
+		t.store(key, entry, generation)
+	}
+
+	return entry, nil
+}
+
+// store caches the response to a request, unless caching is disabled or the cache was invalidated
+// since the request was sent.
+func (t *cachingTransport) store(key string, entry *cacheEntry, generation int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.cache && generation == t.generation {
+		t.entries[key] = entry
+		delete(t.stale, key)
+	}
+}
+
+// invalidate clears the cache. Requests in flight are no longer joined, as they may have been sent
+// before the change.
+func (t *cachingTransport) invalidate() {
+	t.mu.Lock()
+	for key, entry := range t.entries {
+		if entry.revalidatable() {
+			t.stale[key] = entry
+		}
+	}
+	t.entries = make(map[string]*cacheEntry)
+	t.inflight = make(map[string]*inflightRequest)
+	t.generation++
+	t.mu.Unlock()
+}
+
+// revalidatable reports whether IQ Server supplied a validator to send a conditional request with.
+func (e *cacheEntry) revalidatable() bool {
+	return e.header.Get("ETag") != "" || e.header.Get("Last-Modified") != ""
+}
+
+func (e *cacheEntry) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        e.status,
+		StatusCode:    e.statusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(e.body)),
+		ContentLength: int64(len(e.body)),
+		Request:       req,
+	}
+}
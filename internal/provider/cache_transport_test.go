@@ -0,0 +1,137 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCachingTransportSharesRequestsInFlight(t *testing.T) {
+	release := make(chan struct{})
+	var calls atomic.Int32
+	transport := newCachingTransport(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		calls.Add(1)
+		if req.Method == http.MethodGet {
+			<-release
+		}
+		return &http.Response{StatusCode: http.StatusForbidden, Status: "403 Forbidden", Header: http.Header{}, Body: io.NopCloser(strings.NewReader("denied"))}, nil
+	}), false)
+	get := func(ctx context.Context) (*http.Response, error) {
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "https://iq.example.com/api/v2/roleMemberships/application/app", nil)
+		return transport.RoundTrip(req)
+	}
+
+	const readers = 10
+	var wg sync.WaitGroup
+	bodies := make([]string, readers)
+	for i := 0; i < readers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			response, err := get(context.Background())
+			if err != nil {
+				t.Errorf("Expected the error response to be shared, got %v", err)
+				return
+			}
+			body, _ := io.ReadAll(response.Body)
+			bodies[i] = string(body)
+		}(i)
+	}
+
+	// Give all readers the chance to join the first request before it completes
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		if calls.Load() > 0 {
+			break
+		}
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if calls.Load() != 1 {
+		t.Errorf("Expected a single request, got %d", calls.Load())
+	}
+	for i, body := range bodies {
+		if body != "denied" {
+			t.Errorf("Reader %d got body %q, expected %q", i, body, "denied")
+		}
+	}
+
+	// Requests are not shared once completed when caching is disabled, nor when bypassing the cache
+	calls.Store(0)
+	for _, ctx := range []context.Context{context.Background(), context.Background(), withoutCache(context.Background())} {
+		if _, err := get(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if calls.Load() != 3 {
+		t.Errorf("Expected 3 requests, got %d", calls.Load())
+	}
+}
+
+func TestCachingTransportInvalidatesRequestsInFlight(t *testing.T) {
+	release := make(chan struct{})
+	var gets atomic.Int32
+	transport := newCachingTransport(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		body := "before"
+		if req.Method == http.MethodGet && gets.Add(1) == 1 {
+			<-release
+		} else {
+			body = "after"
+		}
+		return &http.Response{StatusCode: http.StatusOK, Status: "200 OK", Header: http.Header{}, Body: io.NopCloser(strings.NewReader(body))}, nil
+	}), true)
+	request := func(method string) string {
+		req, _ := http.NewRequest(method, "https://iq.example.com/api/v2/sourceControl/application/app", nil)
+		response, err := transport.RoundTrip(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(response.Body)
+		return string(body)
+	}
+
+	done := make(chan string)
+	go func() { done <- request(http.MethodGet) }()
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline) && gets.Load() == 0; time.Sleep(10 * time.Millisecond) {
+	}
+
+	// A change while the first read is in flight makes later reads send their own request
+	request(http.MethodPut)
+	if body := request(http.MethodGet); body != "after" {
+		t.Errorf("Read %q after the change, expected after", body)
+	}
+	close(release)
+	if body := <-done; body != "before" {
+		t.Errorf("Read %q before the change, expected before", body)
+	}
+
+	// The response to the read sent before the change is not cached
+	if body := request(http.MethodGet); body != "after" {
+		t.Errorf("Read %q, expected the cached response after", body)
+	}
+	if gets.Load() != 2 {
+		t.Errorf("Expected 2 reads, got %d", gets.Load())
+	}
+}
@@ -0,0 +1,202 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	sonatypeiq "github.com/sonatype-nexus-community/nexus-iq-api-client-go"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                     = &componentLabelsDataSource{}
+	_ datasource.DataSourceWithConfigure        = &componentLabelsDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &componentLabelsDataSource{}
+)
+
+// ComponentLabelsDataSource is a helper function to simplify the provider implementation.
+func ComponentLabelsDataSource() datasource.DataSource {
+	return &componentLabelsDataSource{}
+}
+
+// componentLabelsDataSource is the data source implementation.
+type componentLabelsDataSource struct {
+	baseDataSource
+}
+
+type componentLabelsDataSourceModel struct {
+	ID             types.String          `tfsdk:"id"`
+	OrganizationId types.String          `tfsdk:"organization_id"`
+	ApplicationId  types.String          `tfsdk:"application_id"`
+	Inherit        types.Bool            `tfsdk:"inherit"`
+	Labels         []componentLabelModel `tfsdk:"labels"`
+}
+
+type componentLabelModel struct {
+	ID          types.String `tfsdk:"id"`
+	Label       types.String `tfsdk:"label"`
+	Description types.String `tfsdk:"description"`
+	Color       types.String `tfsdk:"color"`
+	OwnerId     types.String `tfsdk:"owner_id"`
+	OwnerType   types.String `tfsdk:"owner_type"`
+}
+
+// Metadata returns the data source type name.
+func (d *componentLabelsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_component_labels"
+}
+
+// Schema defines the schema for the data source.
+func (d *componentLabelsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Use this data source to get the Component Labels available to an Organization or Application",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"organization_id": schema.StringAttribute{
+				Description: "Internal ID of the Organization - use 'ROOT_ORGANIZATION_ID' for the Root Organization",
+				Optional:    true,
+			},
+			"application_id": schema.StringAttribute{
+				Description: "Internal ID of the Application",
+				Optional:    true,
+			},
+			"inherit": schema.BoolAttribute{
+				Description: "Whether to include Labels inherited from parent Organizations (defaults to true)",
+				Optional:    true,
+			},
+			"labels": schema.ListNestedAttribute{
+				Description: "List of Component Labels",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Internal ID of the Label",
+							Computed:    true,
+						},
+						"label": schema.StringAttribute{
+							Description: "Name of the Label",
+							Computed:    true,
+						},
+						"description": schema.StringAttribute{
+							Description: "Description of the Label",
+							Computed:    true,
+						},
+						"color": schema.StringAttribute{
+							Description: "Color of the Label",
+							Computed:    true,
+						},
+						"owner_id": schema.StringAttribute{
+							Description: "Internal ID of the Organization or Application that defines the Label",
+							Computed:    true,
+						},
+						"owner_type": schema.StringAttribute{
+							Description: "Type of the owner that defines the Label",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *componentLabelsDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("organization_id"),
+			path.MatchRoot("application_id"),
+		),
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *componentLabelsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if deferUnknownOwnerRead(req, resp) {
+		return
+	}
+
+	var data componentLabelsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = context.WithValue(
+		ctx,
+		sonatypeiq.ContextBasicAuth,
+		d.auth,
+	)
+
+	// The config validator makes sure that exactly one of these is configured.
+	ownerType, ownerId := "organization", data.OrganizationId.ValueString()
+	if !data.ApplicationId.IsNull() {
+		ownerType, ownerId = "application", data.ApplicationId.ValueString()
+	}
+
+	labels_request := d.client.LabelsAPI.GetLabels(ctx, ownerType, ownerId)
+	if !data.Inherit.IsNull() {
+		labels_request = labels_request.Inherit(data.Inherit.ValueBool())
+	}
+	labels, api_response, err := labels_request.Execute()
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read IQ Component Labels",
+			describeApiError(api_response, err),
+		)
+		return
+	}
+	if api_response.StatusCode != 200 {
+		resp.Diagnostics.AddError("Unexpected API Response", api_response.Status)
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Iterating %d Component Labels", len(labels)))
+
+	for _, label := range labels {
+		data.Labels = append(data.Labels, componentLabelModel{
+			ID:          types.StringPointerValue(label.Id),
+			Label:       types.StringPointerValue(label.Label),
+			Description: types.StringPointerValue(label.Description),
+			Color:       types.StringPointerValue(label.Color),
+			OwnerId:     types.StringPointerValue(label.OwnerId),
+			OwnerType:   types.StringPointerValue(label.OwnerType),
+		})
+	}
+
+	data.ID = types.StringValue(fmt.Sprintf("%s_%s", ownerType, ownerId))
+
+	// Set state
+	diags := resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -21,4 +21,20 @@ import sonatypeiq "github.com/sonatype-nexus-community/nexus-iq-api-client-go"
 type SonatypeDataSourceData struct {
 	client *sonatypeiq.APIClient
 	auth   sonatypeiq.BasicAuth
+
+	// authMode is how the provider authenticates with IQ Server
+	authMode string
+
+	// username is the user the provider authenticates as - empty when authenticating with a User
+	// Token or a token
+	username string
+
+	// defaultOrganizationId is used by resources when no organization_id is configured
+	defaultOrganizationId string
+
+	// validateRoles enables checking that configured Roles exist while planning
+	validateRoles bool
+
+	// retained holds the Applications kept in IQ Server by retain_on_destroy in this run
+	retained *retainedApplications
 }
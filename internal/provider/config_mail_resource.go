@@ -18,11 +18,11 @@ package provider
 
 import (
 	"context"
-	"io"
 	"math/rand"
 	"strconv"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
@@ -162,10 +162,9 @@ func (r *configMailResource) Create(ctx context.Context, req resource.CreateRequ
 
 	// Call API
 	if err != nil {
-		error_body, _ := io.ReadAll(api_response.Body)
 		resp.Diagnostics.AddError(
 			"Error creating Mail Configuration",
-			"Could not create Mail Configuration, unexpected error: "+api_response.Status+": "+string(error_body),
+			apiErrorDetail("Could not create Mail Configuration", api_response, err),
 		)
 		return
 	}
@@ -202,12 +201,12 @@ func (r *configMailResource) Read(ctx context.Context, req resource.ReadRequest,
 	mail_config, api_response, err := r.client.ConfigMailAPI.GetConfiguration2(ctx).Execute()
 
 	if err != nil {
-		if api_response.StatusCode == 404 {
+		if isNotFound(api_response) {
 			resp.State.RemoveResource(ctx)
 		} else {
 			resp.Diagnostics.AddError(
 				"Error Reading IQ Mail Configuration",
-				"Could not read Mail Configuration: "+err.Error(),
+				apiErrorDetail("Could not read Mail Configuration", api_response, err),
 			)
 		}
 		return
@@ -273,10 +272,9 @@ func (r *configMailResource) Update(ctx context.Context, req resource.UpdateRequ
 
 	// Call API
 	if err != nil {
-		error_body, _ := io.ReadAll(api_response.Body)
 		resp.Diagnostics.AddError(
 			"Error updating Mail Configuration",
-			"Could not update Mail Configuration, unexpected error: "+api_response.Status+": "+string(error_body),
+			apiErrorDetail("Could not update Mail Configuration", api_response, err),
 		)
 		return
 	}
@@ -305,11 +303,22 @@ func (r *configMailResource) Delete(ctx context.Context, req resource.DeleteRequ
 	api_response, err := r.client.ConfigMailAPI.DeleteConfiguration2(ctx).Execute()
 
 	if err != nil {
-		error_body, _ := io.ReadAll(api_response.Body)
 		resp.Diagnostics.AddError(
 			"Error deleting Mail Configuration",
-			"Could not delete  Mail Configuration, unexpected error: "+api_response.Status+": "+string(error_body),
+			apiErrorDetail("Could not delete Mail Configuration", api_response, err),
 		)
 		return
 	}
 }
+
+// MoveState moves the resource from the Sonatype IQ community provider.
+func (r *configMailResource) MoveState(_ context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		moveResourceState("sonatypeiq_config_mail", nil),
+	}
+}
+
+// ImportState imports the Mail Configuration - as there is only one, any ID is accepted.
+func (r *configMailResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
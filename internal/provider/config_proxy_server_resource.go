@@ -18,11 +18,11 @@ package provider
 
 import (
 	"context"
-	"io"
 	"math/rand"
 	"strconv"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
@@ -91,7 +91,7 @@ func (r *configProxyServerResource) Schema(_ context.Context, _ resource.SchemaR
 			},
 			"exclude_hosts": schema.SetAttribute{
 				Description: "Optional list of hosts to exclude communication via Proxy Server",
-				Default:     setdefault.StaticValue(types.SetNull(nil)),
+				Default:     setdefault.StaticValue(types.SetNull(types.StringType)),
 				Computed:    true,
 				Optional:    true,
 				ElementType: types.StringType,
@@ -144,10 +144,9 @@ func (r *configProxyServerResource) Create(ctx context.Context, req resource.Cre
 
 	// Call API
 	if err != nil {
-		error_body, _ := io.ReadAll(api_response.Body)
 		resp.Diagnostics.AddError(
 			"Error creating Proxy Server Configuration",
-			"Could not create Proxy Server Configuration, unexpected error: "+api_response.Status+": "+string(error_body),
+			apiErrorDetail("Could not create Proxy Server Configuration", api_response, err),
 		)
 		return
 	}
@@ -184,12 +183,12 @@ func (r *configProxyServerResource) Read(ctx context.Context, req resource.ReadR
 	proxy_config, api_response, err := r.client.ConfigProxyServerAPI.GetConfiguration3(ctx).Execute()
 
 	if err != nil {
-		if api_response.StatusCode == 404 {
+		if isNotFound(api_response) {
 			resp.State.RemoveResource(ctx)
 		} else {
 			resp.Diagnostics.AddError(
 				"Error Reading IQ Proxy Server Configuration",
-				"Could not read Proxy Server Configuration: "+err.Error(),
+				apiErrorDetail("Could not read Proxy Server Configuration", api_response, err),
 			)
 		}
 		return
@@ -251,10 +250,9 @@ func (r *configProxyServerResource) Update(ctx context.Context, req resource.Upd
 
 	// Call API
 	if err != nil {
-		error_body, _ := io.ReadAll(api_response.Body)
 		resp.Diagnostics.AddError(
 			"Error updating Proxy Server Configuration",
-			"Could not update Proxy Server Configuration, unexpected error: "+api_response.Status+": "+string(error_body),
+			apiErrorDetail("Could not update Proxy Server Configuration", api_response, err),
 		)
 		return
 	}
@@ -282,11 +280,22 @@ func (r *configProxyServerResource) Delete(ctx context.Context, req resource.Del
 	api_response, err := r.client.ConfigProxyServerAPI.DeleteConfiguration3(ctx).Execute()
 
 	if err != nil {
-		error_body, _ := io.ReadAll(api_response.Body)
 		resp.Diagnostics.AddError(
 			"Error deleting Proxy Server Configuration",
-			"Could not delete  Proxy Server Configuration, unexpected error: "+api_response.Status+": "+string(error_body),
+			apiErrorDetail("Could not delete Proxy Server Configuration", api_response, err),
 		)
 		return
 	}
 }
+
+// MoveState moves the resource from the Sonatype IQ community provider.
+func (r *configProxyServerResource) MoveState(_ context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		moveResourceState("sonatypeiq_config_proxy_server", nil),
+	}
+}
+
+// ImportState imports the Proxy Server Configuration - as there is only one, any ID is accepted.
+func (r *configProxyServerResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
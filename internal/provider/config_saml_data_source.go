@@ -87,7 +87,7 @@ func (d *configSamlDataSource) Read(ctx context.Context, req datasource.ReadRequ
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read IQ System Configuration",
-			err.Error(),
+			describeApiError(api_response, err),
 		)
 		return
 	}
@@ -0,0 +1,94 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// privateKeyCreatedAt is the private state key holding when a resource was created or imported.
+	privateKeyCreatedAt = "created_at"
+
+	// consistencyWindow is how long after creation or import a missing entity is assumed to still
+	// be on its way, rather than deleted outside of Terraform.
+	consistencyWindow = 2 * time.Minute
+
+	// consistencyRetries bounds how often a read is retried within the consistencyWindow.
+	consistencyRetries = 5
+)
+
+// privateState is implemented by the private state of all resource requests and responses.
+type privateState interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// markCreated records in the private state that the resource was just created or imported, so
+// that the next Read tolerates IQ Server not returning it yet.
+func markCreated(ctx context.Context, private privateState) diag.Diagnostics {
+	value, _ := json.Marshal(time.Now().Unix())
+	return private.SetKey(ctx, privateKeyCreatedAt, value)
+}
+
+// readConsistently calls read until it finds the entity, retrying with a growing delay for as long
+// as the resource was created or imported within the consistencyWindow. Some versions of IQ Server
+// return 404 for a second or two after an entity has been created, which would otherwise remove a
+// freshly created resource from the state. The mark is cleared once the entity has been found.
+//
+// Retries are sent with a context that bypasses cached lookups, as a cached response would not
+// include the entity either.
+func readConsistently(ctx context.Context, request privateState, response privateState, read func(ctx context.Context) (notFound bool)) diag.Diagnostics {
+	createdAt := time.Time{}
+	if value, diags := request.GetKey(ctx, privateKeyCreatedAt); !diags.HasError() && value != nil {
+		var unix int64
+		if err := json.Unmarshal(value, &unix); err == nil {
+			createdAt = time.Unix(unix, 0)
+		}
+	}
+
+	delay := 500 * time.Millisecond
+	readContext := ctx
+	for attempt := 1; read(readContext); attempt++ {
+		if createdAt.IsZero() || time.Since(createdAt) > consistencyWindow || attempt > consistencyRetries {
+			return nil
+		}
+
+		tflog.Debug(ctx, "Entity not found shortly after creation, retrying", map[string]interface{}{
+			"attempt": attempt,
+			"delay":   delay.String(),
+		})
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(delay):
+		}
+		delay *= 2
+		readContext = withoutCache(ctx)
+	}
+
+	if createdAt.IsZero() {
+		return nil
+	}
+	return response.SetKey(ctx, privateKeyCreatedAt, nil)
+}
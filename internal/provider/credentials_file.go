@@ -0,0 +1,139 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+const (
+	// defaultCredentialsFile is read when no credentials_file is configured, if it exists.
+	defaultCredentialsFile = "~/.sonatypeiq/credentials.json"
+
+	// defaultProfile is used when no profile is configured.
+	defaultProfile = "default"
+)
+
+// credentialsProfile holds the connection settings of a named profile in a credentials file. The
+// credentials file is a JSON object with a profile per key:
+//
+//	{
+//	  "default":    { "url": "https://iq.example.com", "username": "admin", "password": "..." },
+//	  "production": { "url": "https://iq.example.org", "user_code": "...", "pass_code": "..." }
+//	}
+type credentialsProfile struct {
+	Url        string `json:"url"`
+	Username   string `json:"username"`
+	Password   string `json:"password"`
+	UserCode   string `json:"user_code"`
+	PassCode   string `json:"pass_code"`
+	AuthMode   string `json:"auth_mode"`
+	Token      string `json:"token"`
+	AuthHeader string `json:"auth_header"`
+}
+
+// loadCredentialsProfile reads the selected profile from the credentials file. Without a
+// credentials_file or profile configured, a missing default credentials file or default profile
+// is not an error and results in an empty profile.
+func loadCredentialsProfile(config SonatypeIqProviderModel) (credentialsProfile, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var profile credentialsProfile
+
+	file := envDefault("SONATYPEIQ_CREDENTIALS_FILE")
+	if !config.CredentialsFile.IsNull() && len(config.CredentialsFile.ValueString()) > 0 {
+		file = config.CredentialsFile.ValueString()
+	}
+	name := envDefault("SONATYPEIQ_PROFILE")
+	if !config.Profile.IsNull() && len(config.Profile.ValueString()) > 0 {
+		name = config.Profile.ValueString()
+	}
+	required := len(file) > 0 || len(name) > 0
+	if len(file) == 0 {
+		file = defaultCredentialsFile
+	}
+	if len(name) == 0 {
+		name = defaultProfile
+	}
+
+	if strings.HasPrefix(file, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			if required {
+				diags.AddAttributeError(path.Root("credentials_file"), "Unable to read Credentials File", err.Error())
+			}
+			return profile, diags
+		}
+		file = filepath.Join(home, file[2:])
+	}
+
+	data, err := os.ReadFile(file)
+	if errors.Is(err, fs.ErrNotExist) && !required {
+		return profile, diags
+	}
+	if err != nil {
+		diags.AddAttributeError(path.Root("credentials_file"), "Unable to read Credentials File", err.Error())
+		return profile, diags
+	}
+
+	var profiles map[string]credentialsProfile
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&profiles); err != nil {
+		diags.AddAttributeError(
+			path.Root("credentials_file"),
+			"Invalid Credentials File",
+			fmt.Sprintf("%s is not a JSON object of profiles: %s", file, err),
+		)
+		return profile, diags
+	}
+
+	profile, ok := profiles[name]
+	if !ok && required {
+		names := make([]string, 0, len(profiles))
+		for candidate := range profiles {
+			names = append(names, candidate)
+		}
+		sort.Strings(names)
+		diags.AddAttributeError(
+			path.Root("profile"),
+			"Unknown Profile",
+			fmt.Sprintf("%s has no profile '%s' - available profiles are: %s", file, name, strings.Join(names, ", ")),
+		)
+	}
+	return profile, diags
+}
+
+// firstNonEmpty returns the first of the given values that is not empty.
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if len(value) > 0 {
+			return value
+		}
+	}
+	return ""
+}
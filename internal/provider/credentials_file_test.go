@@ -0,0 +1,80 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestLoadCredentialsProfile(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("SONATYPEIQ_CREDENTIALS_FILE", "")
+	t.Setenv("SONATYPEIQ_PROFILE", "")
+
+	file := filepath.Join(t.TempDir(), "credentials.json")
+	if err := os.WriteFile(file, []byte(`{
+		"default": {"url": "https://iq.example.com", "username": "admin", "password": "admin123"},
+		"production": {"url": "https://iq.example.org", "auth_mode": "bearer", "token": "t0k3n"}
+	}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	invalid := filepath.Join(t.TempDir(), "credentials.json")
+	if err := os.WriteFile(invalid, []byte(`{"default": {"url": "https://iq.example.com", "passwrd": "typo"}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range map[string]struct {
+		credentialsFile string
+		profile         string
+		expectedUrl     string
+		expectError     bool
+	}{
+		"no default file":      {},
+		"default profile":      {credentialsFile: file, expectedUrl: "https://iq.example.com"},
+		"named profile":        {credentialsFile: file, profile: "production", expectedUrl: "https://iq.example.org"},
+		"unknown profile":      {credentialsFile: file, profile: "staging", expectError: true},
+		"missing profile file": {profile: "production", expectError: true},
+		"missing file":         {credentialsFile: filepath.Join(home, "missing.json"), expectError: true},
+		"unknown attribute":    {credentialsFile: invalid, expectError: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			config := SonatypeIqProviderModel{
+				CredentialsFile: types.StringNull(),
+				Profile:         types.StringNull(),
+			}
+			if tc.credentialsFile != "" {
+				config.CredentialsFile = types.StringValue(tc.credentialsFile)
+			}
+			if tc.profile != "" {
+				config.Profile = types.StringValue(tc.profile)
+			}
+
+			profile, diags := loadCredentialsProfile(config)
+			if diags.HasError() != tc.expectError {
+				t.Fatalf("Expected error %t, got %v", tc.expectError, diags)
+			}
+			if profile.Url != tc.expectedUrl {
+				t.Errorf("Expected URL %q, got %q", tc.expectedUrl, profile.Url)
+			}
+		})
+	}
+}
@@ -0,0 +1,327 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	sonatypeiq "github.com/sonatype-nexus-community/nexus-iq-api-client-go"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                     = &currentUserDataSource{}
+	_ datasource.DataSourceWithConfigure        = &currentUserDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &currentUserDataSource{}
+)
+
+// CurrentUserDataSource is a helper function to simplify the provider implementation.
+func CurrentUserDataSource() datasource.DataSource {
+	return &currentUserDataSource{}
+}
+
+// currentUserDataSource is the data source implementation.
+type currentUserDataSource struct {
+	baseDataSource
+}
+
+type currentUserDataSourceModel struct {
+	ID              types.String                `tfsdk:"id"`
+	OrganizationId  types.String                `tfsdk:"organization_id"`
+	ApplicationId   types.String                `tfsdk:"application_id"`
+	AuthMode        types.String                `tfsdk:"auth_mode"`
+	Username        types.String                `tfsdk:"username"`
+	UserCode        types.String                `tfsdk:"user_code"`
+	FirstName       types.String                `tfsdk:"first_name"`
+	LastName        types.String                `tfsdk:"last_name"`
+	Email           types.String                `tfsdk:"email"`
+	Realm           types.String                `tfsdk:"realm"`
+	Roles           types.Set                   `tfsdk:"roles"`
+	RoleMemberships []currentUserRoleMembership `tfsdk:"role_memberships"`
+}
+
+type currentUserRoleMembership struct {
+	RoleId    types.String `tfsdk:"role_id"`
+	RoleName  types.String `tfsdk:"role_name"`
+	OwnerType types.String `tfsdk:"owner_type"`
+	OwnerId   types.String `tfsdk:"owner_id"`
+}
+
+// Metadata returns the data source type name.
+func (d *currentUserDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_current_user"
+}
+
+// Schema defines the schema for the data source.
+func (d *currentUserDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Use this data source to get the user the provider is authenticated as and the roles granted to it, e.g. to check for the Policy Administrator role before applying or to find out why IQ Server denies a request. " +
+			"Only roles granted to the user itself are reported, not those granted to groups it is a member of. Role memberships the user is not allowed to view are left out with a warning",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"organization_id": schema.StringAttribute{
+				Description: "Internal ID of the Organization to report the roles on, including those inherited from parent Organizations - defaults to the Root Organization",
+				Optional:    true,
+			},
+			"application_id": schema.StringAttribute{
+				Description: "Internal ID of the Application to report the roles on, including those inherited from its Organizations",
+				Optional:    true,
+			},
+			"auth_mode": schema.StringAttribute{
+				Description: "How the provider authenticates with Sonatype IQ Server (basic, bearer or header)",
+				Computed:    true,
+			},
+			"username": schema.StringAttribute{
+				Description: "Username the provider is authenticated as - null when authenticating with a User Token or a token, as the user it belongs to cannot be looked up",
+				Computed:    true,
+			},
+			"user_code": schema.StringAttribute{
+				Description: "User Code of the User Token the provider is authenticated with - null when not authenticating with a User Token",
+				Computed:    true,
+			},
+			"first_name": schema.StringAttribute{
+				Description: "First name of the user - null when the user cannot be looked up",
+				Computed:    true,
+			},
+			"last_name": schema.StringAttribute{
+				Description: "Last name of the user - null when the user cannot be looked up",
+				Computed:    true,
+			},
+			"email": schema.StringAttribute{
+				Description: "Email address of the user - null when the user cannot be looked up",
+				Computed:    true,
+			},
+			"realm": schema.StringAttribute{
+				Description: "Realm of the user (e.g. Internal, SAML, Crowd) - null when the user cannot be looked up",
+				Computed:    true,
+			},
+			"roles": schema.SetAttribute{
+				Description: "Names of the roles granted to the user, globally and on the Organization or Application",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"role_memberships": schema.ListNestedAttribute{
+				Description: "Role memberships of the user, globally and on the Organization or Application, including where each role was granted",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"role_id": schema.StringAttribute{
+							Description: "Internal ID of the role",
+							Computed:    true,
+						},
+						"role_name": schema.StringAttribute{
+							Description: "Name of the role - null when the role is not listed by Sonatype IQ Server",
+							Computed:    true,
+						},
+						"owner_type": schema.StringAttribute{
+							Description: "Where the role was granted: global, organization or application",
+							Computed:    true,
+						},
+						"owner_id": schema.StringAttribute{
+							Description: "Internal ID of the Organization or Application the role was granted on - null for global roles",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *currentUserDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.Conflicting(
+			path.MatchRoot("organization_id"),
+			path.MatchRoot("application_id"),
+		),
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *currentUserDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if deferUnknownOwnerRead(req, resp) {
+		return
+	}
+
+	var data currentUserDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = context.WithValue(
+		ctx,
+		sonatypeiq.ContextBasicAuth,
+		d.auth,
+	)
+
+	data.AuthMode = types.StringValue(d.authMode)
+	data.Username = types.StringNull()
+	data.UserCode = types.StringNull()
+	data.FirstName = types.StringNull()
+	data.LastName = types.StringNull()
+	data.Email = types.StringNull()
+	data.Realm = types.StringNull()
+	data.RoleMemberships = []currentUserRoleMembership{}
+
+	switch {
+	case len(d.username) > 0:
+		data.ID = types.StringValue(d.username)
+		data.Username = types.StringValue(d.username)
+	case d.authMode == authModeBasic:
+		data.ID = types.StringValue(d.auth.UserName)
+		data.UserCode = types.StringValue(d.auth.UserName)
+	default:
+		data.ID = types.StringValue(d.authMode)
+	}
+
+	ownerType, ownerId := "organization", "ROOT_ORGANIZATION_ID"
+	if !data.OrganizationId.IsNull() {
+		ownerId = data.OrganizationId.ValueString()
+	}
+	if !data.ApplicationId.IsNull() {
+		ownerType, ownerId = "application", data.ApplicationId.ValueString()
+	}
+
+	// Roles can only be attributed to the user when its username is known
+	if data.Username.IsNull() {
+		resp.Diagnostics.Append(d.setRoles(ctx, &data)...)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	user, apiResponse, err := d.client.UsersAPI.Get1(ctx, d.username).Execute()
+	switch {
+	case err == nil:
+		data.FirstName = types.StringPointerValue(user.FirstName)
+		data.LastName = types.StringPointerValue(user.LastName)
+		data.Email = types.StringPointerValue(user.Email)
+		data.Realm = types.StringPointerValue(user.Realm)
+	case apiResponse != nil && (apiResponse.StatusCode == http.StatusForbidden || apiResponse.StatusCode == http.StatusNotFound):
+		// Only administrators may look up users, and users of external realms are not listed
+	default:
+		resp.Diagnostics.AddError(
+			"Unable to Read IQ User",
+			apiErrorDetail("Could not read user "+d.username, apiResponse, err),
+		)
+		return
+	}
+
+	roleNames := map[string]string{}
+	roleList, apiResponse, err := d.client.RolesAPI.GetRoles(ctx).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read IQ Roles",
+			apiErrorDetail("Could not list the roles", apiResponse, err),
+		)
+		return
+	}
+	for _, role := range roleList.Roles {
+		roleNames[role.GetId()] = role.GetName()
+	}
+
+	var memberships []sonatypeiq.ApiRoleMemberMappingDTO
+	globalMemberships, apiResponse, err := d.client.RoleMembershipsAPI.GetRoleMembershipsGlobalOrRepositoryContainer(ctx, "global").Execute()
+	if err == nil {
+		memberships = append(memberships, globalMemberships.MemberMappings...)
+	} else if apiResponse != nil && apiResponse.StatusCode == http.StatusForbidden {
+		resp.Diagnostics.AddWarning(
+			"Unable to Read Global Role Memberships",
+			"The user is not allowed to view global role memberships, so global roles are not reported",
+		)
+	} else {
+		resp.Diagnostics.AddError(
+			"Unable to Read Global Role Memberships",
+			apiErrorDetail("Could not read the global role memberships", apiResponse, err),
+		)
+		return
+	}
+
+	ownerMemberships, apiResponse, err := d.client.RoleMembershipsAPI.GetRoleMembershipsApplicationOrOrganization(ctx, ownerType, ownerId).Execute()
+	if err == nil {
+		memberships = append(memberships, ownerMemberships.MemberMappings...)
+	} else if apiResponse != nil && apiResponse.StatusCode == http.StatusForbidden {
+		resp.Diagnostics.AddWarning(
+			"Unable to Read Role Memberships",
+			"The user is not allowed to view the role memberships of "+ownerType+" "+ownerId+", so roles granted on it are not reported",
+		)
+	} else {
+		resp.Diagnostics.AddError(
+			"Unable to Read Role Memberships",
+			apiErrorDetail("Could not read the role memberships of "+ownerType+" "+ownerId, apiResponse, err),
+		)
+		return
+	}
+
+	for _, mapping := range memberships {
+		for _, member := range mapping.Members {
+			if !strings.EqualFold(member.GetType(), "USER") || member.GetUserOrGroupName() != d.username {
+				continue
+			}
+			membership := currentUserRoleMembership{
+				RoleId:    types.StringValue(mapping.GetRoleId()),
+				RoleName:  types.StringNull(),
+				OwnerType: types.StringValue(strings.ToLower(member.GetOwnerType())),
+				OwnerId:   types.StringPointerValue(member.OwnerId),
+			}
+			if name, ok := roleNames[mapping.GetRoleId()]; ok {
+				membership.RoleName = types.StringValue(name)
+			}
+			if member.OwnerType == nil {
+				membership.OwnerType = types.StringValue("global")
+			}
+			if membership.OwnerType.ValueString() == "global" {
+				membership.OwnerId = types.StringNull()
+			}
+			data.RoleMemberships = append(data.RoleMemberships, membership)
+		}
+	}
+
+	resp.Diagnostics.Append(d.setRoles(ctx, &data)...)
+
+	// Set state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// setRoles sets the roles to the sorted names of the roles of the role memberships.
+func (d *currentUserDataSource) setRoles(ctx context.Context, data *currentUserDataSourceModel) diag.Diagnostics {
+	roles := []string{}
+	for _, membership := range data.RoleMemberships {
+		if !membership.RoleName.IsNull() {
+			roles = append(roles, membership.RoleName.ValueString())
+		}
+	}
+	sort.Strings(roles)
+
+	var diags diag.Diagnostics
+	data.Roles, diags = types.SetValueFrom(ctx, types.StringType, roles)
+	return diags
+}
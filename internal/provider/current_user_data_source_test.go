@@ -0,0 +1,58 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccCurrentUserDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `data "sonatypeiq_current_user" "me" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.sonatypeiq_current_user.me", "auth_mode", "basic"),
+					resource.TestCheckResourceAttrSet("data.sonatypeiq_current_user.me", "username"),
+					resource.TestCheckResourceAttrSet("data.sonatypeiq_current_user.me", "roles.#"),
+					resource.TestCheckResourceAttrSet("data.sonatypeiq_current_user.me", "role_memberships.#"),
+				),
+			},
+			// Roles on an Application include those inherited from its Organizations
+			{
+				Config: providerConfig + `
+resource "sonatypeiq_application" "app" {
+  name            = "Current User Test Application"
+  public_id       = "current-user-test-application"
+  organization_id = "ROOT_ORGANIZATION_ID"
+}
+
+data "sonatypeiq_current_user" "me" {
+  application_id = sonatypeiq_application.app.id
+}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.sonatypeiq_current_user.me", "username"),
+					resource.TestCheckResourceAttrSet("data.sonatypeiq_current_user.me", "role_memberships.#"),
+				),
+			},
+		},
+	})
+}
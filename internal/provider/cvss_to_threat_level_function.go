@@ -0,0 +1,173 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &cvssToThreatLevelFunction{}
+
+// NewCvssToThreatLevelFunction is a helper function to simplify the provider implementation.
+func NewCvssToThreatLevelFunction() function.Function {
+	return &cvssToThreatLevelFunction{}
+}
+
+// cvssToThreatLevelFunction is the function implementation.
+type cvssToThreatLevelFunction struct{}
+
+// Metadata returns the function name.
+func (f *cvssToThreatLevelFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "cvss_to_threat_level"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *cvssToThreatLevelFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Convert a CVSS score or vector to an IQ Threat Level",
+		Description: "Converts a CVSS score (e.g. \"7.5\") or CVSS v3 vector (e.g. \"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H\") to the IQ Threat Level scale of 0 to 10",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "cvss",
+				Description: "CVSS score or CVSS v3 vector",
+			},
+		},
+		Return: function.Int64Return{},
+	}
+}
+
+// Run converts the CVSS score or vector.
+func (f *cvssToThreatLevelFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var cvss string
+
+	resp.Error = req.Arguments.Get(ctx, &cvss)
+	if resp.Error != nil {
+		return
+	}
+
+	score, err := parseCvss(cvss)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, cvssToThreatLevel(score))
+}
+
+// cvssToThreatLevel maps a CVSS score to an IQ Threat Level, e.g. a score of 7.0 to 8.9 maps to
+// Threat Level 7 or 8 which IQ Server considers high.
+func cvssToThreatLevel(score float64) int64 {
+	return int64(math.Floor(math.Min(math.Max(score, 0), 10)))
+}
+
+// parseCvss returns the score of a CVSS score or the base score of a CVSS v3 vector.
+func parseCvss(cvss string) (float64, error) {
+	cvss = strings.TrimSpace(cvss)
+
+	if !strings.HasPrefix(strings.ToUpper(cvss), "CVSS:") {
+		score, err := strconv.ParseFloat(cvss, 64)
+		if err != nil || score < 0 || score > 10 {
+			return 0, fmt.Errorf("'%s' is neither a CVSS score between 0 and 10 nor a CVSS v3 vector", cvss)
+		}
+		return score, nil
+	}
+
+	return cvssV3BaseScore(cvss)
+}
+
+var cvssV3Weights = map[string]map[string]float64{
+	"AV": {"N": 0.85, "A": 0.62, "L": 0.55, "P": 0.2},
+	"AC": {"L": 0.77, "H": 0.44},
+	"PR": {"N": 0.85, "L": 0.62, "H": 0.27},
+	"UI": {"N": 0.85, "R": 0.62},
+	"C":  {"H": 0.56, "L": 0.22, "N": 0},
+	"I":  {"H": 0.56, "L": 0.22, "N": 0},
+	"A":  {"H": 0.56, "L": 0.22, "N": 0},
+}
+
+// cvssV3BaseScore calculates the base score of a CVSS v3.0 or v3.1 vector as specified in
+// https://www.first.org/cvss/v3.1/specification-document.
+func cvssV3BaseScore(vector string) (float64, error) {
+	metrics := strings.Split(vector, "/")
+	if version := strings.ToUpper(metrics[0]); version != "CVSS:3.0" && version != "CVSS:3.1" {
+		return 0, fmt.Errorf("'%s' is not a supported CVSS version - only CVSS v3 vectors are supported", metrics[0])
+	}
+
+	values := make(map[string]string)
+	for _, metric := range metrics[1:] {
+		key, value, found := strings.Cut(metric, ":")
+		if !found {
+			return 0, fmt.Errorf("'%s' is not a valid CVSS metric", metric)
+		}
+		values[key] = value
+	}
+
+	scope := values["S"]
+	if scope != "U" && scope != "C" {
+		return 0, fmt.Errorf("CVSS vector '%s' has no valid Scope (S) metric", vector)
+	}
+
+	weights := make(map[string]float64)
+	for key, options := range cvssV3Weights {
+		weight, ok := options[values[key]]
+		if !ok {
+			return 0, fmt.Errorf("CVSS vector '%s' has no valid %s metric", vector, key)
+		}
+		weights[key] = weight
+	}
+
+	// Privileges Required weigh more when the Scope is changed
+	if scope == "C" {
+		switch values["PR"] {
+		case "L":
+			weights["PR"] = 0.68
+		case "H":
+			weights["PR"] = 0.5
+		}
+	}
+
+	iss := 1 - (1-weights["C"])*(1-weights["I"])*(1-weights["A"])
+	impact := 6.42 * iss
+	if scope == "C" {
+		impact = 7.52*(iss-0.029) - 3.25*math.Pow(iss-0.02, 15)
+	}
+	exploitability := 8.22 * weights["AV"] * weights["AC"] * weights["PR"] * weights["UI"]
+
+	if impact <= 0 {
+		return 0, nil
+	}
+	if scope == "C" {
+		return cvssRoundUp(math.Min(1.08*(impact+exploitability), 10)), nil
+	}
+	return cvssRoundUp(math.Min(impact+exploitability, 10)), nil
+}
+
+// cvssRoundUp rounds up to one decimal as specified in Appendix A of the CVSS v3.1 specification.
+func cvssRoundUp(value float64) float64 {
+	scaled := int64(math.Round(value * 100000))
+	if scaled%10000 == 0 {
+		return float64(scaled) / 100000
+	}
+	return (math.Floor(float64(scaled)/10000) + 1) / 10
+}
@@ -0,0 +1,57 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccCvssToThreatLevelFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "score" {
+  value = provider::sonatypeiq::cvss_to_threat_level("7.5")
+}
+
+output "vector" {
+  value = provider::sonatypeiq::cvss_to_threat_level("CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H")
+}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("score", "7"),
+					resource.TestCheckOutput("vector", "9"),
+				),
+			},
+			{
+				Config: `
+output "invalid" {
+  value = provider::sonatypeiq::cvss_to_threat_level("AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H")
+}`,
+				ExpectError: regexp.MustCompile("is neither a CVSS score"),
+			},
+		},
+	})
+}
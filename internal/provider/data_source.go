@@ -20,7 +20,13 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	sonatypeiq "github.com/sonatype-nexus-community/nexus-iq-api-client-go"
 )
@@ -33,8 +39,10 @@ var (
 
 // applicationsDataSource is the data source implementation.
 type baseDataSource struct {
-	client *sonatypeiq.APIClient
-	auth   sonatypeiq.BasicAuth
+	client   *sonatypeiq.APIClient
+	auth     sonatypeiq.BasicAuth
+	authMode string
+	username string
 }
 
 // Configure implements datasource.DataSourceWithConfigure.
@@ -55,6 +63,8 @@ func (d *baseDataSource) Configure(_ context.Context, req datasource.ConfigureRe
 
 	d.client = config.client
 	d.auth = config.auth
+	d.authMode = config.authMode
+	d.username = config.username
 }
 
 // Metadata implements datasource.DataSource.
@@ -71,3 +81,57 @@ func (*baseDataSource) Read(context.Context, datasource.ReadRequest, *datasource
 func (*baseDataSource) Schema(context.Context, datasource.SchemaRequest, *datasource.SchemaResponse) {
 	panic("unimplemented")
 }
+
+// deferUnknownOwnerRead defers reading a data source whose owner is not known yet, when Terraform
+// supports deferred actions, and reports whether the owner is unknown. Reading the data source is
+// pointless without its owner, so it is an error when Terraform does not support deferred actions.
+func deferUnknownOwnerRead(req datasource.ReadRequest, resp *datasource.ReadResponse) bool {
+	if !hasUnknownOwner(req.Config.Raw) {
+		return false
+	}
+	if req.ClientCapabilities.DeferralAllowed {
+		resp.Deferred = &datasource.Deferred{
+			Reason: datasource.DeferredReasonDataSourceConfigUnknown,
+		}
+		return true
+	}
+	resp.Diagnostics.AddError(
+		"Unknown Owner",
+		"The Organization or Application to read from is not known yet - upgrade to a version of Terraform that supports deferred actions to read it once it has been created in the same run",
+	)
+	return true
+}
+
+// maxResultsAttribute returns the schema of the max_results attribute of list data sources.
+func maxResultsAttribute(kind string) schema.Int64Attribute {
+	return schema.Int64Attribute{
+		Description: fmt.Sprintf("Maximum number of %s to return - all %s are returned when not set", kind, kind),
+		Optional:    true,
+		Validators: []validator.Int64{
+			int64validator.AtLeast(1),
+		},
+	}
+}
+
+// totalCountAttribute returns the schema of the total_count attribute of list data sources.
+func totalCountAttribute(kind string) schema.Int64Attribute {
+	return schema.Int64Attribute{
+		Description: fmt.Sprintf("Total number of %s in IQ Server, including any beyond max_results", kind),
+		Computed:    true,
+	}
+}
+
+// limitResults caps the items of a list data source at max_results. IQ Server returns these lists
+// in full, so a warning is raised whenever the cap leaves items out rather than truncating silently.
+func limitResults[T any](items []T, maxResults types.Int64, kind string, diags *diag.Diagnostics) []T {
+	if maxResults.IsNull() || maxResults.IsUnknown() || int64(len(items)) <= maxResults.ValueInt64() {
+		return items
+	}
+
+	diags.AddAttributeWarning(
+		path.Root("max_results"),
+		"Results Truncated",
+		fmt.Sprintf("Returning the first %d of %d %s - increase or remove max_results to return all of them", maxResults.ValueInt64(), len(items), kind),
+	)
+	return items[:maxResults.ValueInt64()]
+}
@@ -0,0 +1,250 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	sonatypeiq "github.com/sonatype-nexus-community/nexus-iq-api-client-go"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                     = &dependencyTreeDataSource{}
+	_ datasource.DataSourceWithConfigure        = &dependencyTreeDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &dependencyTreeDataSource{}
+)
+
+// DependencyTreeDataSource is a helper function to simplify the provider implementation.
+func DependencyTreeDataSource() datasource.DataSource {
+	return &dependencyTreeDataSource{}
+}
+
+// dependencyTreeDataSource is the data source implementation.
+type dependencyTreeDataSource struct {
+	baseDataSource
+}
+
+type dependencyTreeDataSourceModel struct {
+	ID                    types.String          `tfsdk:"id"`
+	ApplicationId         types.String          `tfsdk:"application_id"`
+	StageId               types.String          `tfsdk:"stage_id"`
+	ScanId                types.String          `tfsdk:"scan_id"`
+	Dependencies          []dependencyTreeModel `tfsdk:"dependencies"`
+	DirectPackageUrls     []types.String        `tfsdk:"direct_package_urls"`
+	TransitivePackageUrls []types.String        `tfsdk:"transitive_package_urls"`
+}
+
+type dependencyTreeModel struct {
+	PackageUrl       types.String `tfsdk:"package_url"`
+	Direct           types.Bool   `tfsdk:"direct"`
+	Depth            types.Int64  `tfsdk:"depth"`
+	ParentPackageUrl types.String `tfsdk:"parent_package_url"`
+}
+
+// Metadata returns the data source type name.
+func (d *dependencyTreeDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dependency_tree"
+}
+
+// Schema defines the schema for the data source.
+func (d *dependencyTreeDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Use this data source to get the dependency tree of an evaluation report of an Application, e.g. to tell violations of direct dependencies apart from those of transitive dependencies",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"application_id": schema.StringAttribute{
+				Description: "Internal ID or public ID of the Application",
+				Required:    true,
+			},
+			"stage_id": schema.StringAttribute{
+				Description: "Use the latest evaluation in this Stage (e.g. build, release) - the most recent evaluation of any Stage is used when neither stage_id nor scan_id is set",
+				Optional:    true,
+				Computed:    true,
+			},
+			"scan_id": schema.StringAttribute{
+				Description: "ID of the evaluation report to read the dependency tree from - the latest report is used when not set",
+				Optional:    true,
+				Computed:    true,
+			},
+			"dependencies": schema.ListNestedAttribute{
+				Description: "Nodes of the dependency tree, depth first - a component that is depended upon in several places is listed once per place",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"package_url": schema.StringAttribute{
+							Description: "Package URL of the component",
+							Computed:    true,
+						},
+						"direct": schema.BoolAttribute{
+							Description: "Whether the component is a direct dependency of the Application",
+							Computed:    true,
+						},
+						"depth": schema.Int64Attribute{
+							Description: "Depth of the node in the tree - 1 for direct dependencies",
+							Computed:    true,
+						},
+						"parent_package_url": schema.StringAttribute{
+							Description: "Package URL of the component that depends on the component - null for direct dependencies",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"direct_package_urls": schema.ListAttribute{
+				Description: "Package URLs of the direct dependencies, sorted",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"transitive_package_urls": schema.ListAttribute{
+				Description: "Package URLs of the components that are only transitive dependencies, sorted",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *dependencyTreeDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.Conflicting(
+			path.MatchRoot("stage_id"),
+			path.MatchRoot("scan_id"),
+		),
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *dependencyTreeDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if deferUnknownOwnerRead(req, resp) {
+		return
+	}
+
+	var data dependencyTreeDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = context.WithValue(
+		ctx,
+		sonatypeiq.ContextBasicAuth,
+		d.auth,
+	)
+
+	applicationId, diags := resolveApplicationId(ctx, d.client, data.ApplicationId.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	reports, api_response, err := d.client.ReportsAPI.GetByApplicationId(ctx, applicationId).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read IQ application reports",
+			describeApiError(api_response, err),
+		)
+		return
+	}
+
+	// The dependency tree is addressed by the public ID of the Application, which the report
+	// data URLs contain
+	report := findReport(reports, data.StageId.ValueString(), data.ScanId.ValueString())
+	if report == nil {
+		resp.Diagnostics.AddError(
+			"No IQ evaluation report found",
+			fmt.Sprintf("Application %s has no evaluation report for the requested Stage or scan", data.ApplicationId.ValueString()),
+		)
+		return
+	}
+
+	publicId, scanId, ok := parseReportDataUrl(report.GetReportDataUrl())
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unable to Read IQ evaluation report",
+			fmt.Sprintf("Unexpected report data URL: %s", report.GetReportDataUrl()),
+		)
+		return
+	}
+
+	tree, api_response, err := d.client.ApplicationsAPI.GetDependencyTree(ctx, publicId, scanId).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read IQ dependency tree",
+			describeApiError(api_response, err),
+		)
+		return
+	}
+
+	data.Dependencies = []dependencyTreeModel{}
+	direct := map[string]bool{}
+	var walk func(node sonatypeiq.ApiDependencyTreeNodeDTO, depth int64, parent *string)
+	walk = func(node sonatypeiq.ApiDependencyTreeNodeDTO, depth int64, parent *string) {
+		for _, child := range node.Children {
+			isDirect := child.GetDirect() || depth == 1
+			data.Dependencies = append(data.Dependencies, dependencyTreeModel{
+				PackageUrl:       types.StringPointerValue(child.PackageUrl),
+				Direct:           types.BoolValue(isDirect),
+				Depth:            types.Int64Value(depth),
+				ParentPackageUrl: types.StringPointerValue(parent),
+			})
+			if child.PackageUrl != nil {
+				direct[child.GetPackageUrl()] = direct[child.GetPackageUrl()] || isDirect
+			}
+			walk(child, depth+1, child.PackageUrl)
+		}
+	}
+	if tree.DependencyTree != nil {
+		// The root of the tree is the Application itself
+		walk(*tree.DependencyTree, 1, nil)
+	}
+
+	var directPurls, transitivePurls []string
+	for purl, isDirect := range direct {
+		if isDirect {
+			directPurls = append(directPurls, purl)
+		} else {
+			transitivePurls = append(transitivePurls, purl)
+		}
+	}
+	data.DirectPackageUrls = []types.String{}
+	for _, purl := range uniqueSorted(directPurls) {
+		data.DirectPackageUrls = append(data.DirectPackageUrls, types.StringValue(purl))
+	}
+	data.TransitivePackageUrls = []types.String{}
+	for _, purl := range uniqueSorted(transitivePurls) {
+		data.TransitivePackageUrls = append(data.TransitivePackageUrls, types.StringValue(purl))
+	}
+
+	data.ID = types.StringValue(applicationId + "/" + scanId)
+	data.StageId = types.StringPointerValue(report.Stage)
+	data.ScanId = types.StringValue(scanId)
+
+	// Set state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
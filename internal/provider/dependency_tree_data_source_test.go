@@ -0,0 +1,47 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDependencyTreeDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccSandbox(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `data "sonatypeiq_dependency_tree" "sandbox" {
+					application_id = "sandbox-application"
+					stage_id       = "build"
+				}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.sonatypeiq_dependency_tree.sandbox", "id"),
+					resource.TestCheckResourceAttrSet("data.sonatypeiq_dependency_tree.sandbox", "scan_id"),
+					resource.TestCheckResourceAttr("data.sonatypeiq_dependency_tree.sandbox", "stage_id", "build"),
+					resource.TestCheckResourceAttr("data.sonatypeiq_dependency_tree.sandbox", "dependencies.0.direct", "true"),
+					resource.TestCheckResourceAttr("data.sonatypeiq_dependency_tree.sandbox", "dependencies.0.depth", "1"),
+					resource.TestCheckNoResourceAttr("data.sonatypeiq_dependency_tree.sandbox", "dependencies.0.parent_package_url"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,489 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	sonatypeiq "github.com/sonatype-nexus-community/nexus-iq-api-client-go"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                     = &effectiveConfigurationDataSource{}
+	_ datasource.DataSourceWithConfigure        = &effectiveConfigurationDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &effectiveConfigurationDataSource{}
+)
+
+// EffectiveConfigurationDataSource is a helper function to simplify the provider implementation.
+func EffectiveConfigurationDataSource() datasource.DataSource {
+	return &effectiveConfigurationDataSource{}
+}
+
+// effectiveConfigurationDataSource is the data source implementation.
+type effectiveConfigurationDataSource struct {
+	baseDataSource
+}
+
+type effectiveConfigurationDataSourceModel struct {
+	ID                    types.String                   `tfsdk:"id"`
+	OrganizationId        types.String                   `tfsdk:"organization_id"`
+	ApplicationId         types.String                   `tfsdk:"application_id"`
+	SourceControl         *effectiveSourceControlModel   `tfsdk:"source_control"`
+	DataRetention         *effectiveDataRetentionModel   `tfsdk:"data_retention"`
+	ApplicationCategories []effectiveApplicationCategory `tfsdk:"application_categories"`
+}
+
+type effectiveSourceControlModel struct {
+	Provider                        types.String `tfsdk:"scm_provider"`
+	RepositoryUrl                   types.String `tfsdk:"repository_url"`
+	BaseBranch                      types.String `tfsdk:"base_branch"`
+	Username                        types.String `tfsdk:"username"`
+	RemediationPullRequestsEnabled  types.Bool   `tfsdk:"remediation_pull_requests_enabled"`
+	PullRequestCommentingEnabled    types.Bool   `tfsdk:"pull_request_commenting_enabled"`
+	SourceControlEvaluationsEnabled types.Bool   `tfsdk:"source_control_evaluations_enabled"`
+	CommitStatusEnabled             types.Bool   `tfsdk:"commit_status_enabled"`
+	StatusChecksEnabled             types.Bool   `tfsdk:"status_checks_enabled"`
+	SshEnabled                      types.Bool   `tfsdk:"ssh_enabled"`
+	SourceControlScanTarget         types.String `tfsdk:"source_control_scan_target"`
+	InheritedFrom                   types.Map    `tfsdk:"inherited_from"`
+}
+
+type effectiveDataRetentionModel struct {
+	ApplicationReports map[string]effectiveRetentionPolicyModel `tfsdk:"application_reports"`
+	SuccessMetrics     *effectiveSuccessMetricsPolicyModel      `tfsdk:"success_metrics"`
+}
+
+type effectiveRetentionPolicyModel struct {
+	EnablePurging  types.Bool   `tfsdk:"enable_purging"`
+	MaxAge         types.String `tfsdk:"max_age"`
+	MaxCount       types.Int64  `tfsdk:"max_count"`
+	OrganizationId types.String `tfsdk:"organization_id"`
+}
+
+type effectiveSuccessMetricsPolicyModel struct {
+	EnablePurging  types.Bool   `tfsdk:"enable_purging"`
+	MaxAge         types.String `tfsdk:"max_age"`
+	OrganizationId types.String `tfsdk:"organization_id"`
+}
+
+type effectiveApplicationCategory struct {
+	ID             types.String `tfsdk:"id"`
+	Name           types.String `tfsdk:"name"`
+	Description    types.String `tfsdk:"description"`
+	Color          types.String `tfsdk:"color"`
+	OrganizationId types.String `tfsdk:"organization_id"`
+}
+
+// Metadata returns the data source type name.
+func (d *effectiveConfigurationDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_effective_configuration"
+}
+
+// Schema defines the schema for the data source.
+func (d *effectiveConfigurationDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	retentionPolicyAttributes := func(withMaxCount bool) map[string]schema.Attribute {
+		attributes := map[string]schema.Attribute{
+			"enable_purging": schema.BoolAttribute{
+				Description: "Whether data is purged",
+				Computed:    true,
+			},
+			"max_age": schema.StringAttribute{
+				Description: "Age after which data is purged (e.g. '3 months')",
+				Computed:    true,
+			},
+			"max_count": schema.Int64Attribute{
+				Description: "Number of reports that are kept",
+				Computed:    true,
+			},
+			"organization_id": schema.StringAttribute{
+				Description: "Internal ID of the Organization that defines the policy",
+				Computed:    true,
+			},
+		}
+		if !withMaxCount {
+			delete(attributes, "max_count")
+		}
+		return attributes
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Use this data source to get the effective configuration of an Organization or Application, with inherited settings resolved through the Organization hierarchy",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"organization_id": schema.StringAttribute{
+				Description: "Internal ID of the Organization - use 'ROOT_ORGANIZATION_ID' for the Root Organization",
+				Optional:    true,
+			},
+			"application_id": schema.StringAttribute{
+				Description: "Internal ID of the Application",
+				Optional:    true,
+			},
+			"source_control": schema.SingleNestedAttribute{
+				Description: "Effective Source Control configuration - null when Source Control is not configured anywhere in the hierarchy",
+				Computed:    true,
+				Attributes: map[string]schema.Attribute{
+					"scm_provider": schema.StringAttribute{
+						Description: "SCM provider (e.g. github, gitlab, azure, bitbucket)",
+						Computed:    true,
+					},
+					"repository_url": schema.StringAttribute{
+						Description: "Repository URL - only available for Applications",
+						Computed:    true,
+					},
+					"base_branch": schema.StringAttribute{
+						Description: "Default branch",
+						Computed:    true,
+					},
+					"username": schema.StringAttribute{
+						Description: "Username used to authenticate with the SCM provider",
+						Computed:    true,
+					},
+					"remediation_pull_requests_enabled": schema.BoolAttribute{
+						Description: "Whether automated remediation Pull Requests are enabled",
+						Computed:    true,
+					},
+					"pull_request_commenting_enabled": schema.BoolAttribute{
+						Description: "Whether Pull Request commenting is enabled",
+						Computed:    true,
+					},
+					"source_control_evaluations_enabled": schema.BoolAttribute{
+						Description: "Whether Source Control evaluations are enabled",
+						Computed:    true,
+					},
+					"commit_status_enabled": schema.BoolAttribute{
+						Description: "Whether the status of policy evaluations is reported on commits",
+						Computed:    true,
+					},
+					"status_checks_enabled": schema.BoolAttribute{
+						Description: "Whether Pull Requests are blocked by failing status checks",
+						Computed:    true,
+					},
+					"ssh_enabled": schema.BoolAttribute{
+						Description: "Whether repositories are accessed over SSH rather than HTTPS",
+						Computed:    true,
+					},
+					"source_control_scan_target": schema.StringAttribute{
+						Description: "Branch or target that is scanned for Source Control evaluations",
+						Computed:    true,
+					},
+					"inherited_from": schema.MapAttribute{
+						Description: "Name of the Organization each inherited setting comes from, keyed by attribute name",
+						Computed:    true,
+						ElementType: types.StringType,
+					},
+				},
+			},
+			"data_retention": schema.SingleNestedAttribute{
+				Description: "Effective Data Retention policies of the Organization, or of the Organization the Application belongs to",
+				Computed:    true,
+				Attributes: map[string]schema.Attribute{
+					"application_reports": schema.MapNestedAttribute{
+						Description: "Retention policy for Application reports, keyed by stage",
+						Computed:    true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: retentionPolicyAttributes(true),
+						},
+					},
+					"success_metrics": schema.SingleNestedAttribute{
+						Description: "Retention policy for Success Metrics",
+						Computed:    true,
+						Attributes:  retentionPolicyAttributes(false),
+					},
+				},
+			},
+			"application_categories": schema.ListNestedAttribute{
+				Description: "Application Categories that can be applied, including those defined by parent Organizations",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Internal ID of the Category",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Name of the Category",
+							Computed:    true,
+						},
+						"description": schema.StringAttribute{
+							Description: "Description of the Category",
+							Computed:    true,
+						},
+						"color": schema.StringAttribute{
+							Description: "Color of the Category",
+							Computed:    true,
+						},
+						"organization_id": schema.StringAttribute{
+							Description: "Internal ID of the Organization that defines the Category",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *effectiveConfigurationDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("organization_id"),
+			path.MatchRoot("application_id"),
+		),
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *effectiveConfigurationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if deferUnknownOwnerRead(req, resp) {
+		return
+	}
+
+	var data effectiveConfigurationDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = context.WithValue(
+		ctx,
+		sonatypeiq.ContextBasicAuth,
+		d.auth,
+	)
+
+	// The config validator makes sure that exactly one of these is configured.
+	ownerType, ownerId := "organization", data.OrganizationId.ValueString()
+	organizationId := ownerId
+	var categories *sonatypeiq.ApplicableTagsDTO
+	if !data.ApplicationId.IsNull() {
+		ownerType, ownerId = "application", data.ApplicationId.ValueString()
+		application, api_response, err := d.client.ApplicationsAPI.GetApplication(ctx, ownerId).Execute()
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read IQ Application",
+				describeApiError(api_response, err),
+			)
+			return
+		}
+		organizationId = application.GetOrganizationId()
+
+		categories, api_response, err = d.client.ApplicationCategoriesAPI.GetApplicationApplicableTags(ctx, application.GetPublicId()).Execute()
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read IQ Application Categories for Application",
+				describeApiError(api_response, err),
+			)
+			return
+		}
+	} else {
+		var err error
+		var api_response *http.Response
+		categories, api_response, err = d.client.ApplicationCategoriesAPI.GetApplicableTags(ctx, organizationId).Execute()
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read IQ Application Categories for Organization",
+				describeApiError(api_response, err),
+			)
+			return
+		}
+	}
+
+	data.ApplicationCategories = []effectiveApplicationCategory{}
+	for _, owner := range categories.ApplicationCategoriesByOwner {
+		for _, category := range owner.ApplicationCategories {
+			organizationId := category.OrganizationId
+			if organizationId == nil {
+				organizationId = owner.OwnerId
+			}
+			data.ApplicationCategories = append(data.ApplicationCategories, effectiveApplicationCategory{
+				ID:             types.StringPointerValue(category.Id),
+				Name:           types.StringPointerValue(category.Name),
+				Description:    types.StringPointerValue(category.Description),
+				Color:          types.StringPointerValue(category.Color),
+				OrganizationId: types.StringPointerValue(organizationId),
+			})
+		}
+	}
+
+	sourceControl, api_response, err := d.client.CompositeSourceControlAPI.GetCompositeSourceControlByOwner(ctx, ownerType, ownerId).Execute()
+	if err != nil && !isNotFound(api_response) {
+		resp.Diagnostics.AddError(
+			"Unable to Read IQ Source Control configuration",
+			describeApiError(api_response, err),
+		)
+		return
+	}
+	data.SourceControl = nil
+	if err == nil {
+		var diags diag.Diagnostics
+		data.SourceControl, diags = effectiveSourceControl(ctx, sourceControl)
+		resp.Diagnostics.Append(diags...)
+	}
+
+	var diags diag.Diagnostics
+	data.DataRetention, diags = d.effectiveDataRetention(ctx, organizationId)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(ownerType + "_" + ownerId)
+
+	// Set state
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// effectiveSourceControl resolves each value of a composite Source Control configuration to either
+// the value of the owner itself or the value it inherits, and records where inherited values come from.
+func effectiveSourceControl(ctx context.Context, sourceControl *sonatypeiq.ApiCompositeSourceControlDTO) (*effectiveSourceControlModel, diag.Diagnostics) {
+	inheritedFrom := map[string]string{}
+	stringValue := func(attribute string, composite *sonatypeiq.ApiCompositeValueDTOString) types.String {
+		if composite == nil {
+			return types.StringNull()
+		}
+		if composite.Value != nil {
+			return types.StringValue(*composite.Value)
+		}
+		if composite.ParentValue != nil && composite.ParentName != nil {
+			inheritedFrom[attribute] = *composite.ParentName
+		}
+		return types.StringPointerValue(composite.ParentValue)
+	}
+	boolValue := func(attribute string, composite *sonatypeiq.ApiCompositeValueDTOBoolean) types.Bool {
+		if composite == nil {
+			return types.BoolValue(false)
+		}
+		if composite.Value != nil {
+			return types.BoolValue(*composite.Value)
+		}
+		if composite.ParentValue != nil && composite.ParentName != nil {
+			inheritedFrom[attribute] = *composite.ParentName
+		}
+		// Flags that are not set anywhere in the hierarchy are disabled
+		return types.BoolValue(composite.ParentValue != nil && *composite.ParentValue)
+	}
+
+	m := &effectiveSourceControlModel{
+		Provider:                        stringValue("scm_provider", sourceControl.Provider),
+		RepositoryUrl:                   types.StringPointerValue(sourceControl.RepositoryUrl),
+		BaseBranch:                      stringValue("base_branch", sourceControl.BaseBranch),
+		Username:                        stringValue("username", sourceControl.Username),
+		RemediationPullRequestsEnabled:  boolValue("remediation_pull_requests_enabled", sourceControl.RemediationPullRequestsEnabled),
+		PullRequestCommentingEnabled:    boolValue("pull_request_commenting_enabled", sourceControl.PullRequestCommentingEnabled),
+		SourceControlEvaluationsEnabled: boolValue("source_control_evaluations_enabled", sourceControl.SourceControlEvaluationsEnabled),
+		CommitStatusEnabled:             boolValue("commit_status_enabled", sourceControl.CommitStatusEnabled),
+		StatusChecksEnabled:             boolValue("status_checks_enabled", sourceControl.StatusChecksEnabled),
+		SshEnabled:                      boolValue("ssh_enabled", sourceControl.SshEnabled),
+		SourceControlScanTarget:         stringValue("source_control_scan_target", sourceControl.SourceControlScanTarget),
+	}
+
+	var diags diag.Diagnostics
+	m.InheritedFrom, diags = types.MapValueFrom(ctx, types.StringType, inheritedFrom)
+	return m, diags
+}
+
+// effectiveDataRetention walks up the Organization hierarchy until every Data Retention policy of the
+// Organization is resolved to a policy that is not inherited.
+func (d *effectiveConfigurationDataSource) effectiveDataRetention(ctx context.Context, organizationId string) (*effectiveDataRetentionModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	m := &effectiveDataRetentionModel{
+		ApplicationReports: map[string]effectiveRetentionPolicyModel{},
+	}
+
+	var pendingStages map[string]bool
+	pendingSuccessMetrics := true
+	hierarchy := d.organizationHierarchy()
+	currentId := &organizationId
+	for depth := 0; currentId != nil && depth < maxOrganizationDepth; depth++ {
+		policies, api_response, err := d.client.DataRetentionPoliciesAPI.GetDataRetentionPolicies(ctx, *currentId).Execute()
+		if err != nil {
+			diags.AddError("Unable to Read IQ Data Retention Policies", apiErrorDetail("Could not read Data Retention Policies for Organization "+*currentId, api_response, err))
+			return nil, diags
+		}
+
+		stages := map[string]sonatypeiq.ApiReportRetentionPolicyDTO{}
+		if policies.ApplicationReports != nil && policies.ApplicationReports.Stages != nil {
+			stages = *policies.ApplicationReports.Stages
+		}
+		// The policies of the Organization itself determine which stages are reported
+		if pendingStages == nil {
+			pendingStages = map[string]bool{}
+			for stage := range stages {
+				pendingStages[stage] = true
+			}
+		}
+
+		for stage := range pendingStages {
+			policy, ok := stages[stage]
+			if !ok || policy.GetInheritPolicy() {
+				continue
+			}
+			m.ApplicationReports[stage] = effectiveRetentionPolicyModel{
+				EnablePurging:  types.BoolValue(policy.GetEnablePurging()),
+				MaxAge:         types.StringPointerValue(policy.MaxAge),
+				MaxCount:       int32PointerValue(policy.MaxCount),
+				OrganizationId: types.StringValue(*currentId),
+			}
+			delete(pendingStages, stage)
+		}
+
+		if pendingSuccessMetrics && policies.SuccessMetrics != nil && !policies.SuccessMetrics.GetInheritPolicy() {
+			m.SuccessMetrics = &effectiveSuccessMetricsPolicyModel{
+				EnablePurging:  types.BoolValue(policies.SuccessMetrics.GetEnablePurging()),
+				MaxAge:         types.StringPointerValue(policies.SuccessMetrics.MaxAge),
+				OrganizationId: types.StringValue(*currentId),
+			}
+			pendingSuccessMetrics = false
+		}
+
+		if len(pendingStages) == 0 && !pendingSuccessMetrics {
+			break
+		}
+
+		parentId, err := hierarchy.parentId(ctx, *currentId)
+		if err != nil {
+			diags.AddError("Unable to Read IQ Organization", err.Error())
+			return nil, diags
+		}
+		currentId = parentId
+	}
+
+	return m, diags
+}
+
+func int32PointerValue(value *int32) types.Int64 {
+	if value == nil {
+		return types.Int64Null()
+	}
+	return types.Int64Value(int64(*value))
+}
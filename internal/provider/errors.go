@@ -0,0 +1,138 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// maxErrorBodyLength limits how much of an error response is included in a diagnostic.
+const maxErrorBodyLength = 1024
+
+// requestIdHeaders are response headers that identify a request in the logs of IQ Server or of a
+// proxy or gateway in front of it.
+var requestIdHeaders = []string{"X-Request-Id", "X-Correlation-Id", "X-Amzn-Trace-Id", "Traceparent"}
+
+// apiErrorDetail describes a failed API call for use in a diagnostic. It is safe to use when no
+// response was received at all, e.g. on DNS, TLS or connection failures.
+func apiErrorDetail(message string, apiResponse *http.Response, err error) string {
+	return message + ", " + describeApiError(apiResponse, err)
+}
+
+// describeApiError describes why an API call failed, including the error message returned by
+// IQ Server and the request that failed so that it can be traced in the IQ Server logs.
+func describeApiError(apiResponse *http.Response, err error) string {
+	if apiResponse == nil {
+		if err == nil {
+			return "unexpected error"
+		}
+		if errors.Is(err, context.DeadlineExceeded) {
+			return "operation did not complete within its timeout - consider increasing the timeouts of this resource: " + err.Error()
+		}
+		var netErr net.Error
+		if errors.As(err, &netErr) && netErr.Timeout() {
+			return "request to Sonatype IQ Server timed out: " + err.Error()
+		}
+		return "unable to reach Sonatype IQ Server: " + err.Error()
+	}
+
+	// The request succeeded, but its response could not be processed
+	if apiResponse.StatusCode < http.StatusMultipleChoices && err != nil {
+		return "unexpected error: " + err.Error()
+	}
+
+	detail := "unexpected error: " + apiResponse.Status
+	if body := errorBody(apiResponse); len(body) > 0 {
+		detail += ": " + body
+	}
+
+	if apiResponse.Request != nil && apiResponse.Request.URL != nil {
+		detail += "\n\nRequest: " + apiResponse.Request.Method + " " + apiResponse.Request.URL.Path
+	}
+	for _, header := range requestIdHeaders {
+		if value := apiResponse.Header.Get(header); len(value) > 0 {
+			detail += "\n" + header + ": " + value
+		}
+	}
+
+	return detail
+}
+
+// errorBody returns the error message of an error response. IQ Server returns either a plain
+// text message or a JSON payload, from which the message is extracted. Bodies that are of no use
+// in a diagnostic, such as HTML error pages of proxies in front of IQ Server, are left out.
+func errorBody(apiResponse *http.Response) string {
+	if apiResponse.Body == nil {
+		return ""
+	}
+	if strings.HasPrefix(apiResponse.Header.Get("Content-Type"), "text/html") {
+		return ""
+	}
+
+	body, err := io.ReadAll(io.LimitReader(apiResponse.Body, maxErrorBodyLength+1))
+	if err != nil {
+		return ""
+	}
+
+	text := strings.TrimSpace(string(body))
+	if message := errorMessage(body); len(message) > 0 {
+		text = message
+	}
+	if len(text) > maxErrorBodyLength {
+		text = text[:maxErrorBodyLength] + "..."
+	}
+	return text
+}
+
+// errorMessage extracts the message(s) of a structured JSON error payload.
+func errorMessage(body []byte) string {
+	var payload struct {
+		Message      string `json:"message"`
+		ErrorMessage string `json:"errorMessage"`
+		Detail       string `json:"detail"`
+		Errors       []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return ""
+	}
+
+	var messages []string
+	for _, message := range []string{payload.Message, payload.ErrorMessage, payload.Detail} {
+		if len(message) > 0 {
+			messages = append(messages, message)
+		}
+	}
+	for _, e := range payload.Errors {
+		if len(e.Message) > 0 {
+			messages = append(messages, e.Message)
+		}
+	}
+	return strings.Join(messages, "; ")
+}
+
+// isNotFound reports whether IQ Server responded that the requested object does not exist.
+func isNotFound(apiResponse *http.Response) bool {
+	return apiResponse != nil && apiResponse.StatusCode == http.StatusNotFound
+}
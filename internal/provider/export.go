@@ -0,0 +1,361 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/zclconf/go-cty/cty"
+
+	sonatypeiq "github.com/sonatype-nexus-community/nexus-iq-api-client-go"
+)
+
+// ExportOptions configures how Export connects to Sonatype IQ Server.
+type ExportOptions struct {
+	Url                string
+	Username           string
+	Password           string
+	InsecureSkipVerify bool
+	UserAgent          string
+}
+
+// exporter writes the configuration of an existing Sonatype IQ Server as Terraform resources
+// with import blocks, so that the server can be brought under management of this provider.
+type exporter struct {
+	client *sonatypeiq.APIClient
+	body   *hclwrite.Body
+
+	// labels holds the resource labels in use per resource type
+	labels map[string]map[string]bool
+	// organizations holds the label of the sonatypeiq_organization resource per Organization ID
+	organizations map[string]string
+	// roleNames holds the name of each Role per Role ID
+	roleNames map[string]string
+}
+
+// Export walks the Organizations, Applications, Role Memberships and Source Control configurations
+// of Sonatype IQ Server and writes them to w as Terraform configuration together with import
+// blocks. The HTTP client is set up in the same way as for the provider.
+func Export(ctx context.Context, options ExportOptions, w io.Writer) error {
+	httpClient, diags := newHttpClient(SonatypeIqProviderModel{
+		InsecureSkipVerify: types.BoolValue(options.InsecureSkipVerify),
+	})
+	if diags.HasError() {
+		return fmt.Errorf("%s: %s", diags[0].Summary(), diags[0].Detail())
+	}
+
+	configuration := sonatypeiq.NewConfiguration()
+	configuration.HTTPClient = httpClient
+	configuration.UserAgent = options.UserAgent
+	configuration.Servers = []sonatypeiq.ServerConfiguration{
+		{
+			URL:         options.Url,
+			Description: "Sonatype IQ Server",
+		},
+	}
+
+	ctx = context.WithValue(
+		ctx,
+		sonatypeiq.ContextBasicAuth,
+		sonatypeiq.BasicAuth{UserName: options.Username, Password: options.Password},
+	)
+
+	file := hclwrite.NewEmptyFile()
+	e := &exporter{
+		client:        sonatypeiq.NewAPIClient(configuration),
+		body:          file.Body(),
+		labels:        map[string]map[string]bool{},
+		organizations: map[string]string{},
+		roleNames:     map[string]string{},
+	}
+	if err := e.export(ctx); err != nil {
+		return err
+	}
+
+	_, err := w.Write(file.Bytes())
+	return err
+}
+
+// export appends all resources and their import blocks to the body of the exporter.
+func (e *exporter) export(ctx context.Context) error {
+	roles, apiResponse, err := e.client.RolesAPI.GetRoles(ctx).Execute()
+	if err != nil {
+		return fmt.Errorf("unable to read Roles: %s", describeApiError(apiResponse, err))
+	}
+	for _, role := range roles.Roles {
+		e.roleNames[role.GetId()] = role.GetName()
+	}
+
+	hierarchy := newOrganizationHierarchy(e.client)
+	organizations, err := hierarchy.all(ctx)
+	if err != nil {
+		return err
+	}
+	// Sort parents before their children, so that labels of parents are known when they are referenced
+	organizations = append([]sonatypeiq.ApiOrganizationDTO{}, organizations...)
+	depths := map[string]int{}
+	for _, organization := range organizations {
+		if depths[organization.GetId()], err = hierarchy.depth(ctx, organization.GetId()); err != nil {
+			return err
+		}
+	}
+	sort.SliceStable(organizations, func(i, j int) bool {
+		return depths[organizations[i].GetId()] < depths[organizations[j].GetId()]
+	})
+	for _, organization := range organizations {
+		if err := e.exportOrganization(ctx, organization); err != nil {
+			return err
+		}
+	}
+
+	applications, apiResponse, err := e.client.ApplicationsAPI.GetApplications(ctx).Execute()
+	if err != nil {
+		return fmt.Errorf("unable to read Applications: %s", describeApiError(apiResponse, err))
+	}
+	for _, application := range applications.Applications {
+		if err := e.exportApplication(ctx, application); err != nil {
+			return err
+		}
+	}
+
+	e.body.AppendUnstructuredTokens(hclwrite.Tokens{{
+		Type:  hclsyntax.TokenComment,
+		Bytes: []byte("# Policies are not exported, as this provider does not manage them\n"),
+	}})
+	return nil
+}
+
+// exportOrganization appends the Organization, its Role Memberships and its Source Control
+// configuration. The Root Organization itself cannot be managed, only its Role Memberships and
+// Source Control configuration are exported.
+func (e *exporter) exportOrganization(ctx context.Context, organization sonatypeiq.ApiOrganizationDTO) error {
+	owner := cty.StringVal(organization.GetId())
+	if organization.GetId() != "ROOT_ORGANIZATION_ID" {
+		label := e.label("sonatypeiq_organization", organization.GetName())
+		e.organizations[organization.GetId()] = label
+
+		block := e.appendResource("sonatypeiq_organization", label, organization.GetId())
+		block.SetAttributeValue("name", cty.StringVal(organization.GetName()))
+		e.setOrganizationId(block, "parent_organization_id", organization.GetParentOrganizationId())
+		owner = cty.NullVal(cty.String)
+	}
+
+	ownerLabel := e.organizations[organization.GetId()]
+	if ownerLabel == "" {
+		ownerLabel = "root"
+	}
+	if err := e.exportRoleMemberships(ctx, "organization", organization.GetId(), ownerLabel, "sonatypeiq_organization", owner); err != nil {
+		return err
+	}
+	return e.exportSourceControl(ctx, "organization", organization.GetId(), ownerLabel, "sonatypeiq_organization", owner)
+}
+
+// exportApplication appends the Application, its Role Memberships and its Source Control
+// configuration.
+func (e *exporter) exportApplication(ctx context.Context, application sonatypeiq.ApiApplicationDTO) error {
+	label := e.label("sonatypeiq_application", application.GetPublicId())
+
+	block := e.appendResource("sonatypeiq_application", label, application.GetId())
+	block.SetAttributeValue("name", cty.StringVal(application.GetName()))
+	block.SetAttributeValue("public_id", cty.StringVal(application.GetPublicId()))
+	e.setOrganizationId(block, "organization_id", application.GetOrganizationId())
+	if application.GetContactUserName() != "" {
+		block.SetAttributeValue("contact_user_name", cty.StringVal(application.GetContactUserName()))
+	}
+
+	owner := cty.NullVal(cty.String)
+	if err := e.exportRoleMemberships(ctx, "application", application.GetId(), label, "sonatypeiq_application", owner); err != nil {
+		return err
+	}
+	return e.exportSourceControl(ctx, "application", application.GetId(), label, "sonatypeiq_application", owner)
+}
+
+// exportRoleMemberships appends a sonatypeiq_role_memberships resource with the members that are
+// granted Roles on the owner itself, if there are any. A known owner value is written as is,
+// otherwise the owner resource is referenced.
+func (e *exporter) exportRoleMemberships(ctx context.Context, ownerType string, ownerId string, ownerLabel string, ownerResourceType string, owner cty.Value) error {
+	apiRequest := e.client.RoleMembershipsAPI.GetRoleMembershipsApplicationOrOrganization(ctx, ownerType, ownerId)
+	roleMemberships, apiResponse, err := e.client.RoleMembershipsAPI.GetRoleMembershipsApplicationOrOrganizationExecute(apiRequest)
+	if err != nil {
+		return fmt.Errorf("unable to read role memberships of %s %s: %s", ownerType, ownerId, describeApiError(apiResponse, err))
+	}
+
+	granted := map[string]map[string][]cty.Value{}
+	for _, roleMembership := range roleMemberships.MemberMappings {
+		for _, member := range roleMembership.Members {
+			if !strings.EqualFold(member.GetOwnerType(), ownerType) || member.GetOwnerId() != ownerId {
+				continue
+			}
+			role := e.roleNames[roleMembership.GetRoleId()]
+			if role == "" {
+				role = roleMembership.GetRoleId()
+			}
+			if granted[role] == nil {
+				granted[role] = map[string][]cty.Value{}
+			}
+			memberType := strings.ToLower(member.GetType()) + "s"
+			granted[role][memberType] = append(granted[role][memberType], cty.StringVal(member.GetUserOrGroupName()))
+		}
+	}
+	if len(granted) == 0 {
+		return nil
+	}
+
+	roles := map[string]cty.Value{}
+	for role, members := range granted {
+		memberSets := map[string]cty.Value{}
+		for _, memberType := range []string{"users", "groups"} {
+			if len(members[memberType]) > 0 {
+				memberSets[memberType] = cty.SetVal(members[memberType])
+			}
+		}
+		roles[role] = cty.ObjectVal(memberSets)
+	}
+
+	label := e.label("sonatypeiq_role_memberships", ownerLabel)
+	block := e.appendResource("sonatypeiq_role_memberships", label, formatEscapedId(ownerType, ownerId))
+	e.setOwner(block, ownerType, ownerResourceType, ownerLabel, owner)
+	block.SetAttributeValue("roles", cty.ObjectVal(roles))
+	return nil
+}
+
+// exportSourceControl appends a sonatypeiq_source_control resource when the owner has a Source
+// Control configuration of its own. The token is write-only and therefore left out.
+func (e *exporter) exportSourceControl(ctx context.Context, ownerType string, ownerId string, ownerLabel string, ownerResourceType string, owner cty.Value) error {
+	sourceControl, apiResponse, err := e.client.SourceControlAPI.GetSourceControl1(ctx, ownerType, ownerId).Execute()
+	if err != nil {
+		if isNotFound(apiResponse) {
+			return nil
+		}
+		return fmt.Errorf("unable to read Source Control configuration of %s %s: %s", ownerType, ownerId, describeApiError(apiResponse, err))
+	}
+
+	label := e.label("sonatypeiq_source_control", ownerLabel)
+	block := e.appendResource("sonatypeiq_source_control", label, formatEscapedId(ownerType, ownerId))
+	e.setOwner(block, ownerType, ownerResourceType, ownerLabel, owner)
+	setString := func(attribute string, value *string) {
+		if value != nil {
+			block.SetAttributeValue(attribute, cty.StringVal(*value))
+		}
+	}
+	setBool := func(attribute string, value *bool) {
+		if value != nil {
+			block.SetAttributeValue(attribute, cty.BoolVal(*value))
+		}
+	}
+	setString("scm_provider", sourceControl.Provider)
+	setString("repository_url", sourceControl.RepositoryUrl)
+	setString("base_branch", sourceControl.BaseBranch)
+	setString("username", sourceControl.Username)
+	setBool("remediation_pull_requests_enabled", sourceControl.RemediationPullRequestsEnabled)
+	setBool("pull_request_commenting_enabled", sourceControl.PullRequestCommentingEnabled)
+	setBool("source_control_evaluations_enabled", sourceControl.SourceControlEvaluationsEnabled)
+	setBool("commit_status_enabled", sourceControl.CommitStatusEnabled)
+	setBool("status_checks_enabled", sourceControl.StatusChecksEnabled)
+	setBool("ssh_enabled", sourceControl.SshEnabled)
+	setString("source_control_scan_target", sourceControl.SourceControlScanTarget)
+	return nil
+}
+
+// appendResource appends an empty resource block and the import block for it.
+func (e *exporter) appendResource(resourceType string, label string, id string) *hclwrite.Body {
+	imported := e.body.AppendNewBlock("import", nil).Body()
+	imported.SetAttributeTraversal("to", hcl.Traversal{
+		hcl.TraverseRoot{Name: resourceType},
+		hcl.TraverseAttr{Name: label},
+	})
+	imported.SetAttributeValue("id", cty.StringVal(id))
+	e.body.AppendNewline()
+
+	block := e.body.AppendNewBlock("resource", []string{resourceType, label}).Body()
+	defer e.body.AppendNewline()
+	return block
+}
+
+// setOrganizationId sets the attribute to a reference to the exported Organization, or to the ID
+// itself for the Root Organization.
+func (e *exporter) setOrganizationId(block *hclwrite.Body, attribute string, organizationId string) {
+	label, ok := e.organizations[organizationId]
+	if !ok {
+		block.SetAttributeValue(attribute, cty.StringVal(organizationId))
+		return
+	}
+	block.SetAttributeTraversal(attribute, hcl.Traversal{
+		hcl.TraverseRoot{Name: "sonatypeiq_organization"},
+		hcl.TraverseAttr{Name: label},
+		hcl.TraverseAttr{Name: "id"},
+	})
+}
+
+// setOwner sets the organization_id or application_id attribute of a resource that belongs to an
+// owner, either to the known owner value or to a reference to the owner resource.
+func (e *exporter) setOwner(block *hclwrite.Body, ownerType string, ownerResourceType string, ownerLabel string, owner cty.Value) {
+	attribute := ownerType + "_id"
+	if !owner.IsNull() {
+		block.SetAttributeValue(attribute, owner)
+		return
+	}
+	block.SetAttributeTraversal(attribute, hcl.Traversal{
+		hcl.TraverseRoot{Name: ownerResourceType},
+		hcl.TraverseAttr{Name: ownerLabel},
+		hcl.TraverseAttr{Name: "id"},
+	})
+}
+
+// label derives a unique resource label from a name, replacing characters that are not allowed in
+// labels and adding a numeric suffix when the label is already in use for the resource type.
+func (e *exporter) label(resourceType string, name string) string {
+	if e.labels[resourceType] == nil {
+		e.labels[resourceType] = map[string]bool{}
+	}
+
+	base := exportLabel(name)
+	label := base
+	for i := 2; e.labels[resourceType][label]; i++ {
+		label = fmt.Sprintf("%s_%d", base, i)
+	}
+	e.labels[resourceType][label] = true
+	return label
+}
+
+// exportLabel turns a name into a valid resource label, e.g. "My App (v2)" into "my_app_v2".
+func exportLabel(name string) string {
+	var label strings.Builder
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			label.WriteRune(r)
+		case label.Len() > 0 && !strings.HasSuffix(label.String(), "_"):
+			label.WriteRune('_')
+		}
+	}
+
+	result := strings.TrimSuffix(label.String(), "_")
+	if len(result) == 0 || unicode.IsDigit(rune(result[0])) {
+		result = "_" + result
+	}
+	return result
+}
@@ -0,0 +1,218 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	sonatypeiq "github.com/sonatype-nexus-community/nexus-iq-api-client-go"
+)
+
+func TestExport(t *testing.T) {
+	const (
+		roleId          = "1cddabf7fdaa47d6833454af10e0a3ef"
+		engineeringId   = "a3f4e5d6c7b8a9f0e1d2c3b4a5f6e7d8"
+		securityId      = "b4a5f6e7d8c9b0a1f2e3d4c5b6a7f8e9"
+		webAppId        = "c5b6a7f8e9d0c1b2a3f4e5d6c7b8a9f0"
+		rootId          = "ROOT_ORGANIZATION_ID"
+		organizationKey = "organization/" + engineeringId
+	)
+
+	m := newMockIqServer(t)
+	m.roles = []sonatypeiq.ApiRoleDTO{{Id: sonatypeiq.PtrString(roleId), Name: sonatypeiq.PtrString("Developer")}}
+	m.organizations[engineeringId] = sonatypeiq.ApiOrganizationDTO{
+		Id:                   sonatypeiq.PtrString(engineeringId),
+		Name:                 sonatypeiq.PtrString("Engineering"),
+		ParentOrganizationId: sonatypeiq.PtrString(rootId),
+	}
+	m.organizations[securityId] = sonatypeiq.ApiOrganizationDTO{
+		Id:                   sonatypeiq.PtrString(securityId),
+		Name:                 sonatypeiq.PtrString("Platform Security"),
+		ParentOrganizationId: sonatypeiq.PtrString(engineeringId),
+	}
+	m.applications[webAppId] = sonatypeiq.ApiApplicationDTO{
+		Id:             sonatypeiq.PtrString(webAppId),
+		PublicId:       sonatypeiq.PtrString("web-app"),
+		Name:           sonatypeiq.PtrString("Web App"),
+		OrganizationId: sonatypeiq.PtrString(securityId),
+	}
+	m.roleMemberships[organizationKey] = []sonatypeiq.ApiRoleMemberMappingDTO{{
+		RoleId: sonatypeiq.PtrString(roleId),
+		Members: []sonatypeiq.ApiMemberDTO{
+			{
+				OwnerType:       sonatypeiq.PtrString("ORGANIZATION"),
+				OwnerId:         sonatypeiq.PtrString(engineeringId),
+				Type:            sonatypeiq.PtrString("USER"),
+				UserOrGroupName: sonatypeiq.PtrString("alice"),
+			},
+			// Inherited from the Root Organization, so not exported for Engineering
+			{
+				OwnerType:       sonatypeiq.PtrString("ORGANIZATION"),
+				OwnerId:         sonatypeiq.PtrString(rootId),
+				Type:            sonatypeiq.PtrString("GROUP"),
+				UserOrGroupName: sonatypeiq.PtrString("developers"),
+			},
+		},
+	}}
+	m.sourceControls["application/"+webAppId] = sonatypeiq.ApiSourceControlDTO{
+		Provider:      sonatypeiq.PtrString("github"),
+		RepositoryUrl: sonatypeiq.PtrString("https://github.com/example/web-app.git"),
+		BaseBranch:    sonatypeiq.PtrString("main"),
+	}
+
+	var output bytes.Buffer
+	if err := Export(context.Background(), ExportOptions{Url: m.URL, Username: "admin", Password: "admin123"}, &output); err != nil {
+		t.Fatal(err)
+	}
+
+	file, diags := hclsyntax.ParseConfig(output.Bytes(), "export.tf", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatalf("Unable to parse the exported configuration: %s\n%s", diags, output.String())
+	}
+
+	// Collect the import IDs and resources by address, in the order in which they are written
+	imports := map[string]string{}
+	var resources []*hclsyntax.Block
+	for _, block := range file.Body.(*hclsyntax.Body).Blocks {
+		switch block.Type {
+		case "import":
+			to, diags := hcl.AbsTraversalForExpr(block.Body.Attributes["to"].Expr)
+			if diags.HasErrors() {
+				t.Fatalf("Invalid import target: %s", diags)
+			}
+			id, diags := block.Body.Attributes["id"].Expr.Value(nil)
+			if diags.HasErrors() {
+				t.Fatalf("Invalid import ID: %s", diags)
+			}
+			imports[to.RootName()+"."+to[1].(hcl.TraverseAttr).Name] = id.AsString()
+		case "resource":
+			resources = append(resources, block)
+		}
+	}
+
+	expected := map[string]string{
+		"sonatypeiq_organization.engineering":       engineeringId,
+		"sonatypeiq_organization.platform_security": securityId,
+		"sonatypeiq_application.web_app":            webAppId,
+		"sonatypeiq_role_memberships.engineering":   formatEscapedId("organization", engineeringId),
+		"sonatypeiq_source_control.web_app":         formatEscapedId("application", webAppId),
+	}
+	if len(imports) != len(expected) || len(resources) != len(expected) {
+		t.Errorf("Expected %d imports and resources, got %d imports and %d resources:\n%s", len(expected), len(imports), len(resources), output.String())
+	}
+	for address, id := range expected {
+		if imports[address] != id {
+			t.Errorf("Expected import of %s with ID %q, got %q", address, id, imports[address])
+		}
+	}
+	if !strings.Contains(output.String(), `"alice"`) || strings.Contains(output.String(), `"developers"`) {
+		t.Errorf("Expected only the role membership granted on Engineering itself:\n%s", output.String())
+	}
+
+	// Import every resource and check that the imported state identifies the same owner as the
+	// exported configuration
+	server, schemas := testProviderServer(t, m)
+	declared := map[string]bool{}
+	for _, block := range resources {
+		resourceType, address := block.Labels[0], block.Labels[0]+"."+block.Labels[1]
+		id, ok := imports[address]
+		if !ok {
+			t.Errorf("No import block for %s", address)
+			continue
+		}
+
+		imported, err := server.ImportResourceState(context.Background(), &tfprotov6.ImportResourceStateRequest{
+			TypeName: resourceType,
+			ID:       id,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !checkDiagnostics(t, "import of "+address, imported.Diagnostics, "") {
+			continue
+		}
+		if len(imported.ImportedResources) != 1 {
+			t.Errorf("Expected 1 imported resource for %s, got %d", address, len(imported.ImportedResources))
+			continue
+		}
+		var state map[string]tftypes.Value
+		if err := testStateValue(t, schemas[resourceType], imported.ImportedResources[0].State).As(&state); err != nil {
+			t.Fatal(err)
+		}
+
+		attributes := map[string]string{}
+		for name, attribute := range block.Body.Attributes {
+			if traversal, ok := attribute.Expr.(*hclsyntax.ScopeTraversalExpr); ok {
+				referenced := traversal.Traversal.RootName() + "." + traversal.Traversal[1].(hcl.TraverseAttr).Name
+				if !declared[referenced] {
+					t.Errorf("%s references %s before it is declared", address, referenced)
+				}
+				attributes[name] = imports[referenced]
+			} else if value, diags := attribute.Expr.Value(nil); !diags.HasErrors() && value.Type().FriendlyName() == "string" {
+				attributes[name] = value.AsString()
+			}
+		}
+		switch resourceType {
+		case "sonatypeiq_organization", "sonatypeiq_application":
+			attributes = map[string]string{"id": id}
+		}
+		for name, value := range attributes {
+			var actual *string
+			if err := state[name].As(&actual); err != nil {
+				continue
+			}
+			if actual == nil {
+				if name == "id" || strings.HasSuffix(name, "_id") {
+					t.Errorf("Import of %s did not set %s", address, name)
+				}
+				continue
+			}
+			if *actual != value {
+				t.Errorf("Import of %s set %s to %q, expected %q", address, name, *actual, value)
+			}
+		}
+		declared[address] = true
+	}
+}
+
+func TestExportLabel(t *testing.T) {
+	e := &exporter{labels: map[string]map[string]bool{}}
+	for _, test := range []struct {
+		resourceType string
+		name         string
+		expected     string
+	}{
+		{"sonatypeiq_application", "My App (v2)", "my_app_v2"},
+		{"sonatypeiq_application", "my-app-v2", "my_app_v2_2"},
+		{"sonatypeiq_organization", "My App", "my_app"},
+		{"sonatypeiq_application", "1st", "_1st"},
+		{"sonatypeiq_application", "Überapp", "berapp"},
+		{"sonatypeiq_application", "---", "_"},
+	} {
+		if label := e.label(test.resourceType, test.name); label != test.expected {
+			t.Errorf("label(%q, %q) = %q, expected %q", test.resourceType, test.name, label, test.expected)
+		}
+	}
+}
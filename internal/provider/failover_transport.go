@@ -0,0 +1,106 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// failoverTransport sends requests to the first of several IQ Server endpoints that can be
+// connected to, e.g. the active and standby instances of a highly available deployment behind
+// different load balancers. Requests are addressed to the first endpoint and rewritten for the
+// others. Once an endpoint has been failed over to, later requests are sent to it first.
+//
+// Only failures to connect are failed over, as the request has not reached IQ Server then. Any
+// other error could leave a change half applied on the endpoint that failed.
+type failoverTransport struct {
+	next      http.RoundTripper
+	endpoints []*url.URL
+
+	mu     sync.Mutex
+	active int
+}
+
+func (t *failoverTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	active := t.active
+	t.mu.Unlock()
+
+	for attempt := 0; ; attempt++ {
+		index := (active + attempt) % len(t.endpoints)
+		endpointReq := req
+		if index != 0 {
+			endpointReq = req.Clone(req.Context())
+			endpointReq.URL = t.rewrite(req.URL, t.endpoints[index])
+			endpointReq.Host = ""
+		}
+		if attempt > 0 && req.Body != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			endpointReq.Body = body
+		}
+
+		resp, err := t.next.RoundTrip(endpointReq)
+
+		last := attempt == len(t.endpoints)-1 || (req.Body != nil && req.GetBody == nil)
+		if err == nil || last || !isConnectionError(err) || req.Context().Err() != nil {
+			if err == nil && index != active {
+				t.mu.Lock()
+				t.active = index
+				t.mu.Unlock()
+			}
+			return resp, err
+		}
+
+		tflog.Warn(req.Context(), "Unable to connect to Sonatype IQ Server, failing over", map[string]interface{}{
+			"endpoint": t.endpoints[index].Host,
+			"next":     t.endpoints[(index+1)%len(t.endpoints)].Host,
+			"error":    err.Error(),
+		})
+	}
+}
+
+// rewrite addresses a URL of the first endpoint to the given endpoint, keeping the path below the
+// base URL of the endpoint.
+func (t *failoverTransport) rewrite(u *url.URL, endpoint *url.URL) *url.URL {
+	rewritten := *u
+	rewritten.Scheme = endpoint.Scheme
+	rewritten.Host = endpoint.Host
+	rewritten.Path = strings.TrimSuffix(endpoint.Path, "/") + strings.TrimPrefix(u.Path, strings.TrimSuffix(t.endpoints[0].Path, "/"))
+	rewritten.RawPath = ""
+	return &rewritten
+}
+
+// isConnectionError reports whether a request failed because IQ Server could not be connected to,
+// including when its host name could not be resolved.
+func isConnectionError(err error) bool {
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr)
+}
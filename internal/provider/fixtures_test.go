@@ -0,0 +1,129 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	sonatypeiq "github.com/sonatype-nexus-community/nexus-iq-api-client-go"
+)
+
+const (
+	sandboxOrganizationName    = "Sandbox Organization"
+	sandboxApplicationName     = "Sandbox Application"
+	sandboxApplicationPublicId = "sandbox-application"
+)
+
+// testAccClient returns a client for the IQ Server used for acceptance testing, configured with
+// the same environment variables as the provider, together with an authenticated context.
+func testAccClient(t *testing.T) (context.Context, *sonatypeiq.APIClient) {
+	ctx, client, err := newTestAccClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ctx, client
+}
+
+func newTestAccClient() (context.Context, *sonatypeiq.APIClient, error) {
+	httpClient, diags := newHttpClient(SonatypeIqProviderModel{})
+	if diags.HasError() {
+		return nil, nil, fmt.Errorf("%s: %s", diags[0].Summary(), diags[0].Detail())
+	}
+
+	configuration := sonatypeiq.NewConfiguration()
+	configuration.HTTPClient = httpClient
+	configuration.Servers = []sonatypeiq.ServerConfiguration{
+		{
+			URL:         envDefault("SONATYPEIQ_URL", "IQ_SERVER_URL"),
+			Description: "Sonatype IQ Server",
+		},
+	}
+
+	ctx := context.WithValue(
+		context.Background(),
+		sonatypeiq.ContextBasicAuth,
+		sonatypeiq.BasicAuth{
+			UserName: envDefault("SONATYPEIQ_USERNAME", "IQ_SERVER_USERNAME"),
+			Password: envDefault("SONATYPEIQ_PASSWORD", "IQ_SERVER_PASSWORD"),
+		},
+	)
+	return ctx, sonatypeiq.NewAPIClient(configuration), nil
+}
+
+// testAccSandbox makes sure that the "Sandbox Organization" and its "sandbox-application"
+// Application, which acceptance tests rely on, exist on IQ Server. Fixtures created here are
+// deleted once the test has finished, existing fixtures are left untouched. Use it as PreCheck, so
+// that IQ Server is only contacted when acceptance tests run.
+func testAccSandbox(t *testing.T) {
+	ctx, client := testAccClient(t)
+
+	organizations, apiResponse, err := client.OrganizationsAPI.GetOrganizations(ctx).OrganizationName([]string{sandboxOrganizationName}).Execute()
+	if err != nil {
+		t.Fatalf("Unable to read the %s: %s", sandboxOrganizationName, describeApiError(apiResponse, err))
+	}
+
+	var organizationId string
+	for _, organization := range organizations.Organizations {
+		if organization.GetName() == sandboxOrganizationName {
+			organizationId = organization.GetId()
+		}
+	}
+	if organizationId == "" {
+		organization, apiResponse, err := client.OrganizationsAPI.AddOrganization(ctx).ApiOrganizationDTO(sonatypeiq.ApiOrganizationDTO{
+			Name:                 sonatypeiq.PtrString(sandboxOrganizationName),
+			ParentOrganizationId: sonatypeiq.PtrString("ROOT_ORGANIZATION_ID"),
+		}).Execute()
+		if err != nil {
+			t.Fatalf("Unable to create the %s: %s", sandboxOrganizationName, describeApiError(apiResponse, err))
+		}
+		organizationId = organization.GetId()
+
+		t.Cleanup(func() {
+			apiResponse, err := client.OrganizationsAPI.DeleteOrganization(ctx, organizationId).Execute()
+			if err != nil && !isNotFound(apiResponse) {
+				t.Errorf("Unable to delete the %s: %s", sandboxOrganizationName, describeApiError(apiResponse, err))
+			}
+		})
+	}
+
+	applications, apiResponse, err := client.ApplicationsAPI.GetApplications(ctx).PublicId([]string{sandboxApplicationPublicId}).Execute()
+	if err != nil {
+		t.Fatalf("Unable to read Application %s: %s", sandboxApplicationPublicId, describeApiError(apiResponse, err))
+	}
+	if len(applications.Applications) > 0 {
+		return
+	}
+
+	application, apiResponse, err := client.ApplicationsAPI.AddApplication(ctx).ApiApplicationDTO(sonatypeiq.ApiApplicationDTO{
+		Name:           sonatypeiq.PtrString(sandboxApplicationName),
+		PublicId:       sonatypeiq.PtrString(sandboxApplicationPublicId),
+		OrganizationId: sonatypeiq.PtrString(organizationId),
+	}).Execute()
+	if err != nil {
+		t.Fatalf("Unable to create Application %s: %s", sandboxApplicationPublicId, describeApiError(apiResponse, err))
+	}
+
+	// Cleanups run last in first out, so the Application is deleted before its Organization
+	t.Cleanup(func() {
+		apiResponse, err := client.ApplicationsAPI.DeleteApplication(ctx, application.GetId()).Execute()
+		if err != nil && !isNotFound(apiResponse) {
+			t.Errorf("Unable to delete Application %s: %s", sandboxApplicationPublicId, describeApiError(apiResponse, err))
+		}
+	})
+}
@@ -0,0 +1,179 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	sonatypeiq "github.com/sonatype-nexus-community/nexus-iq-api-client-go"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &healthDataSource{}
+	_ datasource.DataSourceWithConfigure = &healthDataSource{}
+)
+
+// HealthDataSource is a helper function to simplify the provider implementation.
+func HealthDataSource() datasource.DataSource {
+	return &healthDataSource{}
+}
+
+// healthDataSource is the data source implementation.
+type healthDataSource struct {
+	baseDataSource
+}
+
+type healthDataSourceModel struct {
+	ID                   types.String `tfsdk:"id"`
+	Reachable            types.Bool   `tfsdk:"reachable"`
+	Version              types.String `tfsdk:"version"`
+	Build                types.String `tfsdk:"build"`
+	MinimumVersion       types.String `tfsdk:"minimum_version"`
+	MeetsMinimumVersion  types.Bool   `tfsdk:"meets_minimum_version"`
+	LicenseValid         types.Bool   `tfsdk:"license_valid"`
+	LicenseDaysRemaining types.Int64  `tfsdk:"license_days_remaining"`
+	HdsReachable         types.Bool   `tfsdk:"hds_reachable"`
+}
+
+// hdsStatusDTO is the status of the connection of IQ Server to the Sonatype Data Services (HDS).
+type hdsStatusDTO struct {
+	Connected *bool `json:"connected,omitempty"`
+}
+
+// Metadata returns the data source type name.
+func (d *healthDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_health"
+}
+
+// Schema defines the schema for the data source.
+func (d *healthDataSource) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Use this data source to check the health of Sonatype IQ Server, e.g. in a check block. " +
+			"Reading it does not fail when IQ Server is unhealthy; the attributes report what could not be reached instead. " +
+			"Set skip_connectivity_check on the provider so that an unreachable IQ Server does not already fail the provider configuration",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"reachable": schema.BoolAttribute{
+				Description: "Whether Sonatype IQ Server could be reached",
+				Computed:    true,
+			},
+			"version": schema.StringAttribute{
+				Description: "Version of Sonatype IQ Server - null when it could not be reached",
+				Computed:    true,
+			},
+			"build": schema.StringAttribute{
+				Description: "Build of Sonatype IQ Server - null when it could not be reached or does not report it",
+				Computed:    true,
+			},
+			"minimum_version": schema.StringAttribute{
+				Description: "Minimum version (e.g. 1.170.0) to compare the version of Sonatype IQ Server with, e.g. in a precondition of resources that require a recent version",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^\d+(\.\d+)*(-\d+)?$`), "must be a version such as 1.170.0"),
+				},
+			},
+			"meets_minimum_version": schema.BoolAttribute{
+				Description: "Whether the version of Sonatype IQ Server is at least the minimum_version - null when no minimum_version is set or IQ Server could not be reached",
+				Computed:    true,
+			},
+			"license_valid": schema.BoolAttribute{
+				Description: "Whether the product license of Sonatype IQ Server has not expired - null when it could not be determined",
+				Computed:    true,
+			},
+			"license_days_remaining": schema.Int64Attribute{
+				Description: "Number of days until the product license expires, negative once it has expired - null when it could not be determined",
+				Computed:    true,
+			},
+			"hds_reachable": schema.BoolAttribute{
+				Description: "Whether Sonatype IQ Server can reach the Sonatype Data Services (HDS) - null when it could not be determined",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *healthDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data healthDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = context.WithValue(
+		ctx,
+		sonatypeiq.ContextBasicAuth,
+		d.auth,
+	)
+
+	data.ID = types.StringValue("health")
+	data.Reachable = types.BoolValue(false)
+	data.Version = types.StringNull()
+	data.Build = types.StringNull()
+	data.MeetsMinimumVersion = types.BoolNull()
+	data.LicenseValid = types.BoolNull()
+	data.LicenseDaysRemaining = types.Int64Null()
+	data.HdsReachable = types.BoolNull()
+
+	// Failures are logged rather than reported, so that check blocks can assert on the attributes.
+	var product productVersionDTO
+	if apiResponse, err := callIqApi(ctx, d.client, http.MethodGet, "/rest/product/version", nil, &product); err != nil {
+		tflog.Warn(ctx, "Sonatype IQ Server is not reachable: "+describeApiError(apiResponse, err))
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+	data.Reachable = types.BoolValue(true)
+	data.Version = types.StringValue(product.Version)
+	data.Build = types.StringPointerValue(product.Build)
+	if !data.MinimumVersion.IsNull() {
+		data.MeetsMinimumVersion = types.BoolValue(compareVersions(product.Version, data.MinimumVersion.ValueString()) >= 0)
+	}
+
+	var license productLicenseDTO
+	if apiResponse, err := callIqApi(ctx, d.client, http.MethodGet, "/rest/product/license", nil, &license); err != nil {
+		tflog.Warn(ctx, "Unable to read the product license of Sonatype IQ Server: "+describeApiError(apiResponse, err))
+	} else if license.ExpiryTimestamp != nil {
+		remaining := time.Until(time.UnixMilli(*license.ExpiryTimestamp))
+		data.LicenseValid = types.BoolValue(remaining > 0)
+		data.LicenseDaysRemaining = types.Int64Value(int64(remaining / (24 * time.Hour)))
+	}
+
+	var hds hdsStatusDTO
+	if apiResponse, err := callIqApi(ctx, d.client, http.MethodGet, "/rest/product/hds/status", nil, &hds); err != nil {
+		tflog.Warn(ctx, "Unable to read the HDS connection status of Sonatype IQ Server: "+describeApiError(apiResponse, err))
+	} else {
+		data.HdsReachable = types.BoolPointerValue(hds.Connected)
+	}
+
+	// Set state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,232 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+const defaultRequestTimeout = 300 * time.Second
+
+// wrapTransport, when set, wraps the transport that sends requests to Sonatype IQ Server. Tests use
+// it to record and replay the interactions with IQ Server.
+var wrapTransport func(http.RoundTripper) http.RoundTripper
+
+// newHttpClient builds the HTTP client used to talk to Sonatype IQ Server from the provider
+// configuration.
+func newHttpClient(config SonatypeIqProviderModel) (*http.Client, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: config.InsecureSkipVerify.ValueBool(),
+	}
+
+	var caCert []byte
+	if !config.CaCertFile.IsNull() {
+		pem, err := os.ReadFile(config.CaCertFile.ValueString())
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("ca_cert_file"),
+				"Unable to read CA Certificate file",
+				err.Error(),
+			)
+			return nil, diags
+		}
+		caCert = pem
+	}
+	if !config.CaCertPem.IsNull() {
+		caCert = []byte(config.CaCertPem.ValueString())
+	}
+
+	if len(caCert) > 0 {
+		certPool, err := x509.SystemCertPool()
+		if err != nil {
+			certPool = x509.NewCertPool()
+		}
+		if !certPool.AppendCertsFromPEM(caCert) {
+			diags.AddError(
+				"Invalid CA Certificate",
+				"No PEM encoded certificates could be parsed from the supplied CA Certificate",
+			)
+			return nil, diags
+		}
+		tlsConfig.RootCAs = certPool
+	}
+
+	if !config.ClientCert.IsNull() && !config.ClientKey.IsNull() {
+		clientCert, err := tls.X509KeyPair([]byte(config.ClientCert.ValueString()), []byte(config.ClientKey.ValueString()))
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("client_cert"),
+				"Invalid Client Certificate",
+				"The Client Certificate and Key could not be loaded: "+err.Error(),
+			)
+			return nil, diags
+		}
+		tlsConfig.Certificates = []tls.Certificate{clientCert}
+	}
+
+	// The default transport honors the HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	if !config.ProxyUrl.IsNull() && len(config.ProxyUrl.ValueString()) > 0 {
+		proxyUrl, err := url.Parse(config.ProxyUrl.ValueString())
+		if err != nil || len(proxyUrl.Host) == 0 {
+			diags.AddAttributeError(
+				path.Root("proxy_url"),
+				"Invalid Proxy URL",
+				fmt.Sprintf("The Proxy URL '%s' is not a valid URL", config.ProxyUrl.ValueString()),
+			)
+			return nil, diags
+		}
+		transport.Proxy = http.ProxyURL(proxyUrl)
+	}
+
+	// The standard transport asks for gzip compressed responses, which matters for large reports
+	// The timeout applies to every attempt rather than to all retries combined, and covers reading
+	// the response body
+	requestTimeout := defaultRequestTimeout
+	if !config.RequestTimeout.IsNull() {
+		requestTimeout = time.Duration(config.RequestTimeout.ValueInt64()) * time.Second
+	}
+	var next http.RoundTripper = &gzipTransport{next: &timeoutTransport{next: transport, timeout: requestTimeout}}
+	switch config.AuthMode.ValueString() {
+	case authModeBearer:
+		next = &headerAuthTransport{next: next, header: "Authorization", value: "Bearer " + config.Token.ValueString()}
+	case authModeHeader:
+		next = &headerAuthTransport{next: next, header: config.AuthHeader.ValueString(), value: config.Token.ValueString()}
+	}
+
+	if !config.FailoverUrls.IsNull() && len(config.FailoverUrls.Elements()) > 0 {
+		var failoverUrls []string
+		diags.Append(config.FailoverUrls.ElementsAs(context.Background(), &failoverUrls, false)...)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		failover := &failoverTransport{next: next}
+		for i, endpoint := range append([]string{config.Url.ValueString()}, failoverUrls...) {
+			endpointUrl, err := url.ParseRequestURI(endpoint)
+			if err != nil || len(endpointUrl.Host) == 0 {
+				attribute := path.Root("failover_urls").AtListIndex(i - 1)
+				if i == 0 {
+					attribute = path.Root("url")
+				}
+				diags.AddAttributeError(
+					attribute,
+					"Invalid Sonatype IQ Server URL",
+					fmt.Sprintf("'%s' is not a valid URL to fail over between", endpoint),
+				)
+				return nil, diags
+			}
+			failover.endpoints = append(failover.endpoints, endpointUrl)
+		}
+		next = failover
+	}
+	if wrapTransport != nil {
+		next = wrapTransport(next)
+	}
+
+	var metrics *apiMetrics
+	next = &loggingTransport{next: next, secrets: providerSecrets(config)}
+	if !config.MetricsFile.IsNull() && len(config.MetricsFile.ValueString()) > 0 {
+		// The metrics file is only written when the provider shuts down, when errors go unnoticed
+		if info, err := os.Stat(filepath.Dir(config.MetricsFile.ValueString())); err != nil || !info.IsDir() {
+			diags.AddAttributeError(
+				path.Root("metrics_file"),
+				"Invalid Metrics File",
+				fmt.Sprintf("The directory of the metrics file '%s' does not exist", config.MetricsFile.ValueString()),
+			)
+			return nil, diags
+		}
+		metrics = metricsFor(config.MetricsFile.ValueString())
+		next = &metricsTransport{next: next, metrics: metrics}
+	}
+
+	throttle := newThrottleTransport(
+		next,
+		int(config.MaxConcurrentRequests.ValueInt64()),
+		config.RequestsPerSecond.ValueFloat64(),
+	)
+
+	// Every attempt of a retried request is subject to throttling
+	retry := &retryTransport{
+		next:         throttle,
+		maxRetries:   defaultMaxRetries,
+		retryWaitMin: defaultRetryWaitMin,
+		retryWaitMax: defaultRetryWaitMax,
+		metrics:      metrics,
+	}
+	if !config.MaxRetries.IsNull() {
+		retry.maxRetries = int(config.MaxRetries.ValueInt64())
+	}
+	if !config.RetryWaitMin.IsNull() {
+		retry.retryWaitMin = time.Duration(config.RetryWaitMin.ValueInt64()) * time.Second
+	}
+	if !config.RetryWaitMax.IsNull() {
+		retry.retryWaitMax = time.Duration(config.RetryWaitMax.ValueInt64()) * time.Second
+	}
+	if retry.retryWaitMax < retry.retryWaitMin {
+		diags.AddAttributeError(
+			path.Root("retry_wait_max"),
+			"Invalid Retry Configuration",
+			"retry_wait_max must be greater than or equal to retry_wait_min",
+		)
+		return nil, diags
+	}
+
+	var roundTripper http.RoundTripper = newCachingTransport(retry, config.CacheLookups.IsNull() || config.CacheLookups.ValueBool())
+	if !config.AuditLogFile.IsNull() && len(config.AuditLogFile.ValueString()) > 0 {
+		// Fail early rather than losing the record of the first change
+		if err := appendAuditLog(config.AuditLogFile.ValueString(), nil); err != nil {
+			diags.AddAttributeError(
+				path.Root("audit_log_file"),
+				"Invalid Audit Log File",
+				fmt.Sprintf("The audit log file cannot be written: %s", err),
+			)
+			return nil, diags
+		}
+		roundTripper = &auditTransport{next: roundTripper, path: config.AuditLogFile.ValueString(), actor: auditActor(config)}
+	}
+	if config.ReadOnly.ValueBool() {
+		roundTripper = &readOnlyTransport{next: roundTripper}
+	}
+
+	return &http.Client{Transport: roundTripper}, diags
+}
+
+// providerSecrets returns the credentials the provider is configured with. These may come from
+// ephemeral values and must never end up in logs.
+func providerSecrets(config SonatypeIqProviderModel) []string {
+	return []string{
+		config.Password.ValueString(),
+		config.PassCode.ValueString(),
+		config.Token.ValueString(),
+	}
+}
@@ -0,0 +1,137 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+// compositeIdSeparator separates the parts of the synthetic IDs of resources that do not have
+// an ID of their own in IQ Server, such as Source Control configurations.
+const compositeIdSeparator = "_"
+
+// parseCompositeId splits a composite ID into the expected number of parts. The last part may
+// itself contain the separator, so that e.g. user and group names with underscores are supported.
+//
+// The ID of the Root Organization contains the separator itself and is therefore recognized as
+// the first part.
+func parseCompositeId(id string, parts ...string) ([]string, error) {
+	rootPrefix := "ROOT_ORGANIZATION_ID" + compositeIdSeparator
+	if strings.HasPrefix(id, rootPrefix) && len(parts) > 1 {
+		values, err := parseCompositeId(strings.TrimPrefix(id, rootPrefix), parts[1:]...)
+		if err != nil {
+			return nil, fmt.Errorf("expected an ID of the form %s, got: %s", strings.Join(parts, compositeIdSeparator), id)
+		}
+		return append([]string{"ROOT_ORGANIZATION_ID"}, values...), nil
+	}
+
+	values := strings.SplitN(id, compositeIdSeparator, len(parts))
+	if len(values) != len(parts) {
+		return nil, fmt.Errorf("expected an ID of the form %s, got: %s", strings.Join(parts, compositeIdSeparator), id)
+	}
+	for i, value := range values {
+		if len(value) == 0 {
+			return nil, fmt.Errorf("the %s part of ID %s must not be empty", parts[i], id)
+		}
+	}
+	return values, nil
+}
+
+// escapedIdSeparator separates the URL-escaped parts of the synthetic IDs of role memberships, so
+// that the parts themselves, such as user and group names, may contain any character.
+const escapedIdSeparator = "/"
+
+// formatEscapedId joins the URL-escaped parts into a composite ID.
+func formatEscapedId(parts ...string) string {
+	escaped := make([]string, len(parts))
+	for i, part := range parts {
+		escaped[i] = url.PathEscape(part)
+	}
+	return strings.Join(escaped, escapedIdSeparator)
+}
+
+// parseEscapedId splits a composite ID created by formatEscapedId into the expected number of
+// parts. IDs created by earlier versions of the provider are parsed by parseCompositeId.
+func parseEscapedId(id string, parts ...string) ([]string, error) {
+	values := strings.Split(id, escapedIdSeparator)
+	if len(values) != len(parts) {
+		return parseCompositeId(id, parts...)
+	}
+	for i, value := range values {
+		unescaped, err := url.PathUnescape(value)
+		if err != nil {
+			return nil, fmt.Errorf("the %s part of ID %s is not properly escaped: %s", parts[i], id, err)
+		}
+		if len(unescaped) == 0 {
+			return nil, fmt.Errorf("the %s part of ID %s must not be empty", parts[i], id)
+		}
+		values[i] = unescaped
+	}
+	return values, nil
+}
+
+// upgradeRoleMembershipIdV0 upgrades the state of a role membership resource from schema version
+// 0, whose ID joined its parts with compositeIdSeparator, to an ID created by formatEscapedId.
+// ownerAttribute is the attribute holding the ID of the Organization or Application.
+func upgradeRoleMembershipIdV0(ownerAttribute string) resource.StateUpgrader {
+	return resource.StateUpgrader{
+		StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+			if req.RawState == nil {
+				resp.Diagnostics.AddError("Unable to upgrade role membership state", "No prior state to upgrade")
+				return
+			}
+
+			var state map[string]interface{}
+			if err := json.Unmarshal(req.RawState.JSON, &state); err != nil {
+				resp.Diagnostics.AddError("Unable to upgrade role membership state", err.Error())
+				return
+			}
+
+			upgradeRoleMembershipState(state, ownerAttribute)
+
+			upgraded, err := json.Marshal(state)
+			if err != nil {
+				resp.Diagnostics.AddError("Unable to upgrade role membership state", err.Error())
+				return
+			}
+			resp.DynamicValue = &tfprotov6.DynamicValue{JSON: upgraded}
+		},
+	}
+}
+
+// upgradeRoleMembershipState sets the ID of the raw state of a role membership resource to an ID
+// created by formatEscapedId, and defaults the attributes that were added later.
+func upgradeRoleMembershipState(state map[string]interface{}, ownerAttribute string) {
+	owner, _ := state[ownerAttribute].(string)
+	roleId, _ := state["role_id"].(string)
+	memberType := "user"
+	memberName, _ := state["user_name"].(string)
+	if groupName, ok := state["group_name"].(string); ok {
+		memberType, memberName = "group", groupName
+	}
+	state["id"] = formatEscapedId(owner, roleId, memberType, memberName)
+
+	// Added after schema version 0 was introduced, without changing the version
+	setDefault(state, "ignore_member_name_case", false)
+}
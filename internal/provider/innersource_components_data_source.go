@@ -0,0 +1,256 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	sonatypeiq "github.com/sonatype-nexus-community/nexus-iq-api-client-go"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &innerSourceComponentsDataSource{}
+	_ datasource.DataSourceWithConfigure = &innerSourceComponentsDataSource{}
+)
+
+// InnerSourceComponentsDataSource is a helper function to simplify the provider implementation.
+func InnerSourceComponentsDataSource() datasource.DataSource {
+	return &innerSourceComponentsDataSource{}
+}
+
+// innerSourceComponentsDataSource is the data source implementation.
+type innerSourceComponentsDataSource struct {
+	baseDataSource
+}
+
+type innerSourceComponentsDataSourceModel struct {
+	ID            types.String                `tfsdk:"id"`
+	ApplicationId types.String                `tfsdk:"application_id"`
+	StageId       types.String                `tfsdk:"stage_id"`
+	ScanId        types.String                `tfsdk:"scan_id"`
+	Components    []innerSourceComponentModel `tfsdk:"components"`
+}
+
+type innerSourceComponentModel struct {
+	PackageUrl            types.String `tfsdk:"package_url"`
+	DisplayName           types.String `tfsdk:"display_name"`
+	DirectDependency      types.Bool   `tfsdk:"direct_dependency"`
+	InnerSourcePackageUrl types.String `tfsdk:"innersource_package_url"`
+	OwnerApplicationId    types.String `tfsdk:"owner_application_id"`
+	OwnerApplicationName  types.String `tfsdk:"owner_application_name"`
+}
+
+// Metadata returns the data source type name.
+func (d *innerSourceComponentsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_innersource_components"
+}
+
+// Schema defines the schema for the data source.
+func (d *innerSourceComponentsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Use this data source to get the InnerSource components (components produced by other Applications) consumed by an Application",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"application_id": schema.StringAttribute{
+				Description: "Internal ID or public ID of the Application",
+				Required:    true,
+			},
+			"stage_id": schema.StringAttribute{
+				Description: "Use the latest evaluation in this Stage (e.g. build, release) - the most recent evaluation of any Stage is used when not set",
+				Optional:    true,
+				Computed:    true,
+			},
+			"scan_id": schema.StringAttribute{
+				Description: "ID of the evaluation report the components were read from",
+				Computed:    true,
+			},
+			"components": schema.ListNestedAttribute{
+				Description: "InnerSource components consumed by the Application - a component produced by several Applications is listed once per producing Application",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"package_url": schema.StringAttribute{
+							Description: "Package URL of the component as found in the Application",
+							Computed:    true,
+						},
+						"display_name": schema.StringAttribute{
+							Description: "Display name of the component",
+							Computed:    true,
+						},
+						"direct_dependency": schema.BoolAttribute{
+							Description: "Whether the component is a direct dependency of the Application",
+							Computed:    true,
+						},
+						"innersource_package_url": schema.StringAttribute{
+							Description: "Package URL of the component as produced by the owning Application",
+							Computed:    true,
+						},
+						"owner_application_id": schema.StringAttribute{
+							Description: "Internal ID of the Application that produces the component",
+							Computed:    true,
+						},
+						"owner_application_name": schema.StringAttribute{
+							Description: "Name of the Application that produces the component",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *innerSourceComponentsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if deferUnknownOwnerRead(req, resp) {
+		return
+	}
+
+	var data innerSourceComponentsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = context.WithValue(
+		ctx,
+		sonatypeiq.ContextBasicAuth,
+		d.auth,
+	)
+
+	applicationId, diags := resolveApplicationId(ctx, d.client, data.ApplicationId.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	reports, api_response, err := d.client.ReportsAPI.GetByApplicationId(ctx, applicationId).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read IQ application reports",
+			describeApiError(api_response, err),
+		)
+		return
+	}
+
+	latest := latestReport(reports, data.StageId.ValueString())
+	if latest == nil {
+		resp.Diagnostics.AddError(
+			"No IQ evaluation report found",
+			fmt.Sprintf("Application %s has no evaluation report for the requested Stage", data.ApplicationId.ValueString()),
+		)
+		return
+	}
+
+	publicId, scanId, ok := parseReportDataUrl(latest.GetReportDataUrl())
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unable to Read IQ evaluation report",
+			fmt.Sprintf("Unexpected report data URL: %s", latest.GetReportDataUrl()),
+		)
+		return
+	}
+
+	raw, api_response, err := d.client.ApplicationsAPI.GetRawData(ctx, publicId, scanId).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read IQ evaluation report data",
+			describeApiError(api_response, err),
+		)
+		return
+	}
+
+	data.Components = []innerSourceComponentModel{}
+	for _, component := range raw.Components {
+		if component.DependencyData == nil || !component.DependencyData.GetInnerSource() {
+			continue
+		}
+		for _, producer := range component.DependencyData.InnerSourceData {
+			data.Components = append(data.Components, innerSourceComponentModel{
+				PackageUrl:            types.StringPointerValue(component.PackageUrl),
+				DisplayName:           types.StringPointerValue(component.DisplayName),
+				DirectDependency:      types.BoolPointerValue(component.DependencyData.DirectDependency),
+				InnerSourcePackageUrl: types.StringPointerValue(producer.InnerSourceComponentPurl),
+				OwnerApplicationId:    types.StringPointerValue(producer.OwnerApplicationId),
+				OwnerApplicationName:  types.StringPointerValue(producer.OwnerApplicationName),
+			})
+		}
+	}
+
+	data.ID = types.StringValue(applicationId)
+	data.StageId = types.StringPointerValue(latest.Stage)
+	data.ScanId = types.StringValue(scanId)
+
+	// Set state
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// latestReport returns the most recent of the reports in the given Stage, or of all reports when
+// no Stage is given. It returns nil when there is no such report.
+func latestReport(reports []sonatypeiq.ApiApplicationReportDTOV2, stageId string) *sonatypeiq.ApiApplicationReportDTOV2 {
+	var latest *sonatypeiq.ApiApplicationReportDTOV2
+	for i, report := range reports {
+		if len(stageId) > 0 && report.GetStage() != stageId {
+			continue
+		}
+		if latest == nil || report.GetEvaluationDate().After(latest.GetEvaluationDate()) {
+			latest = &reports[i]
+		}
+	}
+	return latest
+}
+
+// findReport returns the report of the scan with the given ID, or the latest report in the given
+// Stage when no scan ID is given. It returns nil when there is no such report.
+func findReport(reports []sonatypeiq.ApiApplicationReportDTOV2, stageId string, scanId string) *sonatypeiq.ApiApplicationReportDTOV2 {
+	if len(scanId) == 0 {
+		return latestReport(reports, stageId)
+	}
+	for i := range reports {
+		if _, reportScanId, ok := parseReportDataUrl(reports[i].GetReportDataUrl()); ok && reportScanId == scanId {
+			return &reports[i]
+		}
+	}
+	return nil
+}
+
+// parseReportDataUrl extracts the application public ID and scan ID from a report data URL
+// of the form api/v2/applications/{applicationPublicId}/reports/{scanId}/raw.
+func parseReportDataUrl(reportDataUrl string) (string, string, bool) {
+	parts := strings.Split(strings.Trim(reportDataUrl, "/"), "/")
+	for i := 0; i+3 < len(parts); i++ {
+		if parts[i] == "applications" && parts[i+2] == "reports" {
+			return parts[i+1], parts[i+3], true
+		}
+	}
+	return "", "", false
+}
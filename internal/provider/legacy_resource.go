@@ -0,0 +1,135 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// legacyResourceTypes maps the type names that resources were renamed from to their current type
+// names. A legacy type name remains usable, with a deprecation warning, and is backed by the
+// implementation of the current type, so that existing state keeps working after an upgrade. Its
+// state can be moved to the current type name with a moved block, provided the resource of the
+// current type supports moves through moveResourceState.
+var legacyResourceTypes = map[string]string{}
+
+// legacyResources returns the resources registered under the legacy type names in
+// legacyResourceTypes, for the current resources of the provider.
+func legacyResources(ctx context.Context, providerTypeName string, resources []func() resource.Resource) []func() resource.Resource {
+	current := map[string]func() resource.Resource{}
+	for _, newResource := range resources {
+		resp := &resource.MetadataResponse{}
+		newResource().Metadata(ctx, resource.MetadataRequest{ProviderTypeName: providerTypeName}, resp)
+		current[resp.TypeName] = newResource
+	}
+
+	var legacy []func() resource.Resource
+	for legacyTypeName, typeName := range legacyResourceTypes {
+		newResource, ok := current[typeName]
+		if !ok {
+			continue
+		}
+		legacyTypeName, typeName := legacyTypeName, typeName
+		legacy = append(legacy, func() resource.Resource {
+			return &legacyResource{
+				Resource:       newResource(),
+				legacyTypeName: legacyTypeName,
+				typeName:       typeName,
+			}
+		})
+	}
+	return legacy
+}
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.ResourceWithConfigure        = &legacyResource{}
+	_ resource.ResourceWithConfigValidators = &legacyResource{}
+	_ resource.ResourceWithValidateConfig   = &legacyResource{}
+	_ resource.ResourceWithModifyPlan       = &legacyResource{}
+	_ resource.ResourceWithImportState      = &legacyResource{}
+	_ resource.ResourceWithUpgradeState     = &legacyResource{}
+)
+
+// legacyResource registers a resource under the type name it was renamed from. All operations are
+// passed on to the resource of the current type name.
+type legacyResource struct {
+	resource.Resource
+	legacyTypeName string
+	typeName       string
+}
+
+// Metadata returns the legacy type name.
+func (r *legacyResource) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = r.legacyTypeName
+}
+
+// Schema returns the schema of the current resource, deprecated in favor of its current type name.
+func (r *legacyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	r.Resource.Schema(ctx, req, resp)
+	resp.Schema.DeprecationMessage = fmt.Sprintf(
+		"%s has been renamed to %s - use a moved block to move existing resources to the new type name",
+		r.legacyTypeName, r.typeName,
+	)
+}
+
+func (r *legacyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if current, ok := r.Resource.(resource.ResourceWithConfigure); ok {
+		current.Configure(ctx, req, resp)
+	}
+}
+
+func (r *legacyResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	if current, ok := r.Resource.(resource.ResourceWithConfigValidators); ok {
+		return current.ConfigValidators(ctx)
+	}
+	return nil
+}
+
+func (r *legacyResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	if current, ok := r.Resource.(resource.ResourceWithValidateConfig); ok {
+		current.ValidateConfig(ctx, req, resp)
+	}
+}
+
+func (r *legacyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if current, ok := r.Resource.(resource.ResourceWithModifyPlan); ok {
+		current.ModifyPlan(ctx, req, resp)
+	}
+}
+
+func (r *legacyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	current, ok := r.Resource.(resource.ResourceWithImportState)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Resource Import Not Implemented",
+			fmt.Sprintf("%s does not support import", r.legacyTypeName),
+		)
+		return
+	}
+	current.ImportState(ctx, req, resp)
+}
+
+func (r *legacyResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	if current, ok := r.Resource.(resource.ResourceWithUpgradeState); ok {
+		return current.UpgradeState(ctx)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// TestLegacyResourceTypes makes sure that every legacy type name maps to an existing resource.
+func TestLegacyResourceTypes(t *testing.T) {
+	ctx := context.Background()
+	resources := (&SonatypeIqProvider{}).Resources(ctx)
+
+	names := map[string]bool{}
+	for _, newResource := range resources {
+		resp := &resource.MetadataResponse{}
+		newResource().Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "sonatypeiq"}, resp)
+		names[resp.TypeName] = true
+	}
+	for legacyTypeName, typeName := range legacyResourceTypes {
+		if !names[typeName] || !names[legacyTypeName] {
+			t.Errorf("expected legacy type %s to be registered for %s", legacyTypeName, typeName)
+		}
+	}
+}
+
+func TestLegacyResource(t *testing.T) {
+	ctx := context.Background()
+	legacyResourceTypes["sonatypeiq_app"] = "sonatypeiq_application"
+	defer delete(legacyResourceTypes, "sonatypeiq_app")
+
+	legacy := legacyResources(ctx, "sonatypeiq", []func() resource.Resource{NewApplicationResource, NewOrganizationResource})
+	if len(legacy) != 1 {
+		t.Fatalf("expected 1 legacy resource, got: %d", len(legacy))
+	}
+	r := legacy[0]()
+
+	metadata := &resource.MetadataResponse{}
+	r.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "sonatypeiq"}, metadata)
+	if metadata.TypeName != "sonatypeiq_app" {
+		t.Errorf("expected legacy type name, got: %s", metadata.TypeName)
+	}
+
+	schema := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schema)
+	if !strings.Contains(schema.Schema.DeprecationMessage, "renamed to sonatypeiq_application") {
+		t.Errorf("expected the legacy type to be deprecated, got: %q", schema.Schema.DeprecationMessage)
+	}
+	if _, ok := schema.Schema.Attributes["public_id"]; !ok {
+		t.Errorf("expected the schema of the current type")
+	}
+}
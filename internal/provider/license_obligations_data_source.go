@@ -0,0 +1,226 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	sonatypeiq "github.com/sonatype-nexus-community/nexus-iq-api-client-go"
+)
+
+// licenseObligationStatuses are the review statuses of a license obligation.
+var licenseObligationStatuses = []string{"OPEN", "IGNORED", "FLAGGED", "FULFILLED"}
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &licenseObligationsDataSource{}
+	_ datasource.DataSourceWithConfigure = &licenseObligationsDataSource{}
+)
+
+// LicenseObligationsDataSource is a helper function to simplify the provider implementation.
+func LicenseObligationsDataSource() datasource.DataSource {
+	return &licenseObligationsDataSource{}
+}
+
+// licenseObligationsDataSource is the data source implementation.
+type licenseObligationsDataSource struct {
+	baseDataSource
+}
+
+type licenseObligationsDataSourceModel struct {
+	ID            types.String             `tfsdk:"id"`
+	ApplicationId types.String             `tfsdk:"application_id"`
+	StageId       types.String             `tfsdk:"stage_id"`
+	Status        types.String             `tfsdk:"status"`
+	Obligations   []licenseObligationModel `tfsdk:"obligations"`
+}
+
+type licenseObligationModel struct {
+	ID                    types.String `tfsdk:"id"`
+	Name                  types.String `tfsdk:"name"`
+	Status                types.String `tfsdk:"status"`
+	Comment               types.String `tfsdk:"comment"`
+	ComponentDisplayName  types.String `tfsdk:"component_display_name"`
+	PackageUrl            types.String `tfsdk:"package_url"`
+	OwnerId               types.String `tfsdk:"owner_id"`
+	LastUpdatedAt         types.String `tfsdk:"last_updated_at"`
+	LastUpdatedByUsername types.String `tfsdk:"last_updated_by_username"`
+}
+
+// Metadata returns the data source type name.
+func (d *licenseObligationsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_license_obligations"
+}
+
+// Schema defines the schema for the data source.
+func (d *licenseObligationsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Use this data source to get the review status of the license obligations of the components of an Application (Advanced Legal Pack)",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"application_id": schema.StringAttribute{
+				Description: "Internal ID or public ID of the Application",
+				Required:    true,
+			},
+			"stage_id": schema.StringAttribute{
+				Description: "Only include components of the latest evaluation in this Stage (e.g. build, release) - all Stages are included when not set",
+				Optional:    true,
+			},
+			"status": schema.StringAttribute{
+				Description: "Only include obligations with this status, one of " + strings.Join(licenseObligationStatuses, ", "),
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(licenseObligationStatuses...),
+				},
+			},
+			"obligations": schema.ListNestedAttribute{
+				Description: "License obligations of the components of the Application",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Internal ID of the obligation review",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Name of the obligation",
+							Computed:    true,
+						},
+						"status": schema.StringAttribute{
+							Description: "Review status of the obligation",
+							Computed:    true,
+						},
+						"comment": schema.StringAttribute{
+							Description: "Comment provided when the obligation was reviewed",
+							Computed:    true,
+						},
+						"component_display_name": schema.StringAttribute{
+							Description: "Display name of the component",
+							Computed:    true,
+						},
+						"package_url": schema.StringAttribute{
+							Description: "Package URL of the component",
+							Computed:    true,
+						},
+						"owner_id": schema.StringAttribute{
+							Description: "Internal ID of the Organization or Application the review was recorded for",
+							Computed:    true,
+						},
+						"last_updated_at": schema.StringAttribute{
+							Description: "Time the obligation was last reviewed (RFC3339)",
+							Computed:    true,
+						},
+						"last_updated_by_username": schema.StringAttribute{
+							Description: "Name of the user that last reviewed the obligation",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *licenseObligationsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if deferUnknownOwnerRead(req, resp) {
+		return
+	}
+
+	var data licenseObligationsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = context.WithValue(
+		ctx,
+		sonatypeiq.ContextBasicAuth,
+		d.auth,
+	)
+
+	applicationId, diags := resolveApplicationId(ctx, d.client, data.ApplicationId.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var report *sonatypeiq.ApiLicenseLegalApplicationReportDTO
+	var api_response *http.Response
+	var err error
+	if data.StageId.IsNull() {
+		report, api_response, err = d.client.LicenseLegalMetadataAPI.GetLicenseLegalApplicationReport(ctx, applicationId).Execute()
+	} else {
+		report, api_response, err = d.client.LicenseLegalMetadataAPI.GetLicenseLegalApplicationReport1(ctx, applicationId, data.StageId.ValueString()).Execute()
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read IQ license legal report",
+			describeApiError(api_response, err),
+		)
+		return
+	}
+
+	data.Obligations = []licenseObligationModel{}
+	for _, component := range report.Components {
+		if component.LicenseLegalData == nil {
+			continue
+		}
+		for _, obligation := range component.LicenseLegalData.Obligations {
+			if !data.Status.IsNull() && obligation.GetStatus() != data.Status.ValueString() {
+				continue
+			}
+			obligationState := licenseObligationModel{
+				ID:                    types.StringPointerValue(obligation.Id),
+				Name:                  types.StringPointerValue(obligation.Name),
+				Status:                types.StringPointerValue(obligation.Status),
+				Comment:               types.StringPointerValue(obligation.Comment),
+				ComponentDisplayName:  types.StringPointerValue(component.DisplayName),
+				PackageUrl:            types.StringPointerValue(component.PackageUrl),
+				OwnerId:               types.StringPointerValue(obligation.OwnerId),
+				LastUpdatedAt:         types.StringNull(),
+				LastUpdatedByUsername: types.StringPointerValue(obligation.LastUpdatedByUsername),
+			}
+			if obligation.LastUpdatedAt != nil {
+				obligationState.LastUpdatedAt = types.StringValue(obligation.LastUpdatedAt.Format(time.RFC3339))
+			}
+			data.Obligations = append(data.Obligations, obligationState)
+		}
+	}
+
+	data.ID = types.StringValue(applicationId)
+
+	// Set state
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,404 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	sonatypeiq "github.com/sonatype-nexus-community/nexus-iq-api-client-go"
+)
+
+// licenseOverrideStatuses are the statuses a License Override can have.
+var licenseOverrideStatuses = []string{"OPEN", "ACKNOWLEDGED", "OVERRIDDEN", "SELECTED", "CONFIRMED"}
+
+// licenseOverrideResource is the resource implementation.
+type licenseOverrideResource struct {
+	baseResource
+}
+
+type licenseOverrideModelResource struct {
+	ID                   types.String `tfsdk:"id"`
+	OrganizationId       types.String `tfsdk:"organization_id"`
+	ApplicationId        types.String `tfsdk:"application_id"`
+	ComponentFormat      types.String `tfsdk:"component_format"`
+	ComponentCoordinates types.Map    `tfsdk:"component_coordinates"`
+	Status               types.String `tfsdk:"status"`
+	LicenseIds           types.Set    `tfsdk:"license_ids"`
+	Comment              types.String `tfsdk:"comment"`
+}
+
+// licenseOverrideDTO is a License Override as exchanged with IQ Server.
+type licenseOverrideDTO struct {
+	Id                  *string                         `json:"id,omitempty"`
+	OwnerId             *string                         `json:"ownerId,omitempty"`
+	Comment             *string                         `json:"comment,omitempty"`
+	LicenseIds          []string                        `json:"licenseIds"`
+	Status              *string                         `json:"status,omitempty"`
+	ComponentIdentifier *sonatypeiq.ComponentIdentifier `json:"componentIdentifier,omitempty"`
+}
+
+// licenseOverridesDTO holds the License Overrides of a component that apply to an owner, grouped
+// by the owner that defines them.
+type licenseOverridesDTO struct {
+	LicenseOverridesByOwner []struct {
+		OwnerId          *string              `json:"ownerId,omitempty"`
+		OwnerType        *string              `json:"ownerType,omitempty"`
+		LicenseOverrides []licenseOverrideDTO `json:"licenseOverrides"`
+	} `json:"licenseOverridesByOwner"`
+}
+
+// NewLicenseOverrideResource is a helper function to simplify the provider implementation.
+func NewLicenseOverrideResource() resource.Resource {
+	return &licenseOverrideResource{}
+}
+
+// Metadata returns the resource type name.
+func (r *licenseOverrideResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_license_override"
+}
+
+// Schema defines the schema for the resource.
+func (r *licenseOverrideResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Use this resource to manage the license status of a component for an Organization or Application",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Internal ID of the License Override",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"organization_id": schema.StringAttribute{
+				Description: "Internal ID of the Organization - use 'ROOT_ORGANIZATION_ID' for the Root Organization",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					organizationIdValidator,
+				},
+			},
+			"application_id": schema.StringAttribute{
+				Description: "Internal ID or public ID of the Application",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"component_format": schema.StringAttribute{
+				Description: "Format of the component (e.g. maven, npm, pypi)",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"component_coordinates": schema.MapAttribute{
+				Description: "Coordinates of the component, e.g. groupId, artifactId, extension, classifier and version for maven",
+				Required:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"status": schema.StringAttribute{
+				Description: "License status, one of " + strings.Join(licenseOverrideStatuses, ", "),
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(licenseOverrideStatuses...),
+				},
+			},
+			"license_ids": schema.SetAttribute{
+				Description: "IDs of the licenses that apply to the component - required when the status is OVERRIDDEN or SELECTED",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"comment": schema.StringAttribute{
+				Description: "Rationale of the license determination",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (r *licenseOverrideResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("organization_id"),
+			path.MatchRoot("application_id"),
+		),
+	}
+}
+
+// ValidateConfig checks that licenses are configured for statuses that select licenses.
+func (r *licenseOverrideResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config licenseOverrideModelResource
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() || config.Status.IsUnknown() || config.LicenseIds.IsUnknown() {
+		return
+	}
+
+	status := config.Status.ValueString()
+	if (status == "OVERRIDDEN" || status == "SELECTED") && len(config.LicenseIds.Elements()) == 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("license_ids"),
+			"Missing Attribute Configuration",
+			fmt.Sprintf("At least one license must be configured when the status is %s", status),
+		)
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *licenseOverrideResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan licenseOverrideModelResource
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = context.WithValue(
+		ctx,
+		sonatypeiq.ContextBasicAuth,
+		r.auth,
+	)
+
+	licenseOverride, diags := r.save(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = types.StringPointerValue(licenseOverride.Id)
+
+	// IQ Server may not return the new entity straight away
+	resp.Diagnostics.Append(markCreated(ctx, resp.Private)...)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *licenseOverrideResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state licenseOverrideModelResource
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = context.WithValue(
+		ctx,
+		sonatypeiq.ContextBasicAuth,
+		r.auth,
+	)
+
+	ownerType, ownerId, diags := r.resolveOwner(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	componentIdentifier, diags := state.componentIdentifier(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query, err := json.Marshal(componentIdentifier)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading IQ License Override", err.Error())
+		return
+	}
+
+	// License Overrides are not exposed by the generated API client. IQ Server returns the License
+	// Overrides of all owners that apply, so only the one of the owner itself is looked up.
+	var licenseOverride *licenseOverrideDTO
+	var apiResponse *http.Response
+	resp.Diagnostics.Append(readConsistently(ctx, req.Private, resp.Private, func(ctx context.Context) bool {
+		var licenseOverrides licenseOverridesDTO
+		apiResponse, err = callIqApi(ctx, r.client, http.MethodGet, "/rest/licenseOverride/"+ownerType+"/"+url.PathEscape(ownerId)+"?componentIdentifier="+url.QueryEscape(string(query)), nil, &licenseOverrides)
+		if err != nil {
+			return isNotFound(apiResponse)
+		}
+		for _, owner := range licenseOverrides.LicenseOverridesByOwner {
+			for i, candidate := range owner.LicenseOverrides {
+				if candidate.GetId() == state.ID.ValueString() {
+					licenseOverride = &owner.LicenseOverrides[i]
+				}
+			}
+		}
+		return licenseOverride == nil
+	})...)
+	if err != nil {
+		if isNotFound(apiResponse) {
+			resp.State.RemoveResource(ctx)
+		} else {
+			resp.Diagnostics.AddError(
+				"Error Reading IQ License Override",
+				apiErrorDetail("Could not read License Override with ID "+state.ID.ValueString(), apiResponse, err),
+			)
+		}
+		return
+	}
+
+	if licenseOverride == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.Status = types.StringPointerValue(licenseOverride.Status)
+	state.Comment = types.StringPointerValue(licenseOverride.Comment)
+	if licenseOverride.Comment != nil && len(*licenseOverride.Comment) == 0 {
+		state.Comment = types.StringNull()
+	}
+	state.LicenseIds = types.SetNull(types.StringType)
+	if len(licenseOverride.LicenseIds) > 0 {
+		state.LicenseIds, diags = types.SetValueFrom(ctx, types.StringType, licenseOverride.LicenseIds)
+		resp.Diagnostics.Append(diags...)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *licenseOverrideResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan licenseOverrideModelResource
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = context.WithValue(
+		ctx,
+		sonatypeiq.ContextBasicAuth,
+		r.auth,
+	)
+
+	_, diags := r.save(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *licenseOverrideResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state licenseOverrideModelResource
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = context.WithValue(
+		ctx,
+		sonatypeiq.ContextBasicAuth,
+		r.auth,
+	)
+
+	ownerType, ownerId, diags := r.resolveOwner(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiResponse, err := callIqApi(ctx, r.client, http.MethodDelete, "/rest/licenseOverride/"+ownerType+"/"+url.PathEscape(ownerId)+"/"+url.PathEscape(state.ID.ValueString()), nil, nil)
+	if err != nil && !isNotFound(apiResponse) {
+		resp.Diagnostics.AddError(
+			"Error deleting License Override",
+			apiErrorDetail("Could not delete License Override with ID "+state.ID.ValueString(), apiResponse, err),
+		)
+	}
+}
+
+// save creates or updates the License Override of the model, depending on whether it has an ID.
+func (r *licenseOverrideResource) save(ctx context.Context, m *licenseOverrideModelResource) (*licenseOverrideDTO, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	ownerType, ownerId, ownerDiags := r.resolveOwner(ctx, m)
+	diags.Append(ownerDiags...)
+	componentIdentifier, identifierDiags := m.componentIdentifier(ctx)
+	diags.Append(identifierDiags...)
+	licenseIds := []string{}
+	if !m.LicenseIds.IsNull() {
+		diags.Append(m.LicenseIds.ElementsAs(ctx, &licenseIds, false)...)
+	}
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	request := licenseOverrideDTO{
+		OwnerId:             &ownerId,
+		Comment:             m.Comment.ValueStringPointer(),
+		LicenseIds:          licenseIds,
+		Status:              m.Status.ValueStringPointer(),
+		ComponentIdentifier: componentIdentifier,
+	}
+	if !m.ID.IsNull() && !m.ID.IsUnknown() {
+		request.Id = m.ID.ValueStringPointer()
+	}
+
+	var licenseOverride licenseOverrideDTO
+	apiResponse, err := callIqApi(ctx, r.client, http.MethodPut, "/rest/licenseOverride/"+ownerType+"/"+url.PathEscape(ownerId), request, &licenseOverride)
+	if err != nil {
+		diags.AddError(
+			"Error saving License Override",
+			apiErrorDetail(fmt.Sprintf("Could not save License Override for %s %s", ownerType, ownerId), apiResponse, err),
+		)
+		return nil, diags
+	}
+	return &licenseOverride, diags
+}
+
+// resolveOwner returns the type and internal ID of the Organization or Application the License
+// Override belongs to. The config validator makes sure that exactly one of these is configured.
+func (r *licenseOverrideResource) resolveOwner(ctx context.Context, m *licenseOverrideModelResource) (string, string, diag.Diagnostics) {
+	if m.ApplicationId.IsNull() {
+		return "organization", m.OrganizationId.ValueString(), nil
+	}
+	applicationId, diags := resolveApplicationId(ctx, r.client, m.ApplicationId.ValueString())
+	return "application", applicationId, diags
+}
+
+// componentIdentifier returns the identifier of the component the License Override applies to.
+func (m *licenseOverrideModelResource) componentIdentifier(ctx context.Context) (*sonatypeiq.ComponentIdentifier, diag.Diagnostics) {
+	coordinates := map[string]string{}
+	diags := m.ComponentCoordinates.ElementsAs(ctx, &coordinates, false)
+	return &sonatypeiq.ComponentIdentifier{
+		Format:      m.ComponentFormat.ValueStringPointer(),
+		Coordinates: &coordinates,
+	}, diags
+}
+
+// GetId returns the ID of the License Override, or an empty string when it has none.
+func (o licenseOverrideDTO) GetId() string {
+	if o.Id == nil {
+		return ""
+	}
+	return *o.Id
+}
@@ -0,0 +1,74 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccLicenseOverrideResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccLicenseOverrideResourceConfig("SELECTED", `["Apache-2.0"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("sonatypeiq_license_override.test", "id"),
+					resource.TestCheckResourceAttr("sonatypeiq_license_override.test", "status", "SELECTED"),
+					resource.TestCheckResourceAttr("sonatypeiq_license_override.test", "license_ids.#", "1"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccLicenseOverrideResourceConfig("OVERRIDDEN", `["Apache-2.0", "MIT"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("sonatypeiq_license_override.test", "status", "OVERRIDDEN"),
+					resource.TestCheckResourceAttr("sonatypeiq_license_override.test", "license_ids.#", "2"),
+				),
+			},
+			// Licenses are required when selecting licenses
+			{
+				Config:      testAccLicenseOverrideResourceConfig("SELECTED", `[]`),
+				ExpectError: regexp.MustCompile("At least one license must be configured"),
+			},
+		},
+	})
+}
+
+func testAccLicenseOverrideResourceConfig(status string, licenseIds string) string {
+	return fmt.Sprintf(providerConfig+`
+resource "sonatypeiq_license_override" "test" {
+  organization_id  = "ROOT_ORGANIZATION_ID"
+  component_format = "maven"
+  component_coordinates = {
+    groupId    = "commons-collections"
+    artifactId = "commons-collections"
+    version    = "3.2.1"
+    extension  = "jar"
+    classifier = ""
+  }
+  status      = %q
+  license_ids = %s
+  comment     = "Reviewed by legal"
+}
+`, status, licenseIds)
+}
@@ -0,0 +1,191 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	sonatypeiq "github.com/sonatype-nexus-community/nexus-iq-api-client-go"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &licenseThreatGroupsDataSource{}
+	_ datasource.DataSourceWithConfigure = &licenseThreatGroupsDataSource{}
+)
+
+// LicenseThreatGroupsDataSource is a helper function to simplify the provider implementation.
+func LicenseThreatGroupsDataSource() datasource.DataSource {
+	return &licenseThreatGroupsDataSource{}
+}
+
+// licenseThreatGroupsDataSource is the data source implementation.
+type licenseThreatGroupsDataSource struct {
+	baseDataSource
+}
+
+type licenseThreatGroupsDataSourceModel struct {
+	ID                  types.String              `tfsdk:"id"`
+	OrganizationId      types.String              `tfsdk:"organization_id"`
+	LicenseThreatGroups []licenseThreatGroupModel `tfsdk:"license_threat_groups"`
+}
+
+type licenseThreatGroupModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	ThreatLevel types.Int64  `tfsdk:"threat_level"`
+	Licenses    []string     `tfsdk:"licenses"`
+}
+
+// licenseThreatGroupDTO is a License Threat Group as returned by IQ Server.
+type licenseThreatGroupDTO struct {
+	Id          *string `json:"id,omitempty"`
+	Name        *string `json:"name,omitempty"`
+	ThreatLevel *int32  `json:"threatLevel,omitempty"`
+}
+
+// licenseThreatGroupLicenseDTO links a License to a License Threat Group.
+type licenseThreatGroupLicenseDTO struct {
+	LicenseId *string `json:"licenseId,omitempty"`
+}
+
+// Metadata returns the data source type name.
+func (d *licenseThreatGroupsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_license_threat_groups"
+}
+
+// Schema defines the schema for the data source.
+func (d *licenseThreatGroupsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Use this data source to get the License Threat Groups (and their Licenses) for an Organization",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"organization_id": schema.StringAttribute{
+				Description: "Internal ID of the Organization - use 'ROOT_ORGANIZATION_ID' for the Root Organization",
+				Required:    true,
+			},
+			"license_threat_groups": schema.ListNestedAttribute{
+				Description: "List of License Threat Groups defined for this Organization",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Internal ID of the License Threat Group",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Name of the License Threat Group",
+							Computed:    true,
+						},
+						"threat_level": schema.Int64Attribute{
+							Description: "Threat Level (0-10) of the License Threat Group",
+							Computed:    true,
+						},
+						"licenses": schema.ListAttribute{
+							Description: "IDs of the Licenses that are members of the License Threat Group",
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *licenseThreatGroupsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if deferUnknownOwnerRead(req, resp) {
+		return
+	}
+
+	var data licenseThreatGroupsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = context.WithValue(
+		ctx,
+		sonatypeiq.ContextBasicAuth,
+		d.auth,
+	)
+
+	organizationId := url.PathEscape(data.OrganizationId.ValueString())
+
+	// License Threat Groups are not exposed by the generated API client
+	var threatGroups []licenseThreatGroupDTO
+	api_response, err := callIqApi(ctx, d.client, http.MethodGet, "/rest/licenseThreatGroup/organization/"+organizationId, nil, &threatGroups)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read IQ License Threat Groups for Organization",
+			describeApiError(api_response, err),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Iterating %d License Threat Groups", len(threatGroups)))
+
+	for _, threatGroup := range threatGroups {
+		var groupLicenses []licenseThreatGroupLicenseDTO
+		api_response, err := callIqApi(ctx, d.client, http.MethodGet, "/rest/licenseThreatGroupLicense/organization/"+organizationId+"/"+url.PathEscape(*threatGroup.Id), nil, &groupLicenses)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read IQ Licenses for License Threat Group",
+				describeApiError(api_response, err),
+			)
+			return
+		}
+
+		threatGroupState := licenseThreatGroupModel{
+			ID:       types.StringPointerValue(threatGroup.Id),
+			Name:     types.StringPointerValue(threatGroup.Name),
+			Licenses: []string{},
+		}
+		if threatGroup.ThreatLevel != nil {
+			threatGroupState.ThreatLevel = types.Int64Value(int64(*threatGroup.ThreatLevel))
+		}
+		for _, groupLicense := range groupLicenses {
+			if groupLicense.LicenseId != nil {
+				threatGroupState.Licenses = append(threatGroupState.Licenses, *groupLicense.LicenseId)
+			}
+		}
+
+		data.LicenseThreatGroups = append(data.LicenseThreatGroups, threatGroupState)
+	}
+
+	data.ID = data.OrganizationId
+
+	// Set state
+	diags := resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
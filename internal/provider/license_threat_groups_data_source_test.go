@@ -0,0 +1,42 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccLicenseThreatGroupsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `data "sonatypeiq_license_threat_groups" "root_groups" {
+					organization_id = "ROOT_ORGANIZATION_ID"
+				}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.sonatypeiq_license_threat_groups.root_groups", "id", "ROOT_ORGANIZATION_ID"),
+					resource.TestCheckResourceAttr("data.sonatypeiq_license_threat_groups.root_groups", "organization_id", "ROOT_ORGANIZATION_ID"),
+					resource.TestCheckResourceAttrSet("data.sonatypeiq_license_threat_groups.root_groups", "license_threat_groups.#"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,158 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// redactedKeys are the (lowercase) JSON keys whose values are never logged.
+var redactedKeys = []string{"password", "passcode", "token", "secret", "authorization"}
+
+// loggingTransport logs every request sent to IQ Server at DEBUG level, and the request and
+// response bodies at TRACE level, so they show up when running Terraform with TF_LOG set.
+type loggingTransport struct {
+	next http.RoundTripper
+
+	// secrets are the credentials of the provider, which are masked wherever they would be logged
+	secrets []string
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := withMaskedSecrets(req.Context(), t.secrets)
+	fields := map[string]interface{}{
+		"method": req.Method,
+		"path":   req.URL.Path,
+	}
+	if req.URL.RawQuery != "" {
+		fields["query"] = req.URL.RawQuery
+	}
+
+	if req.Body != nil && req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			payload, _ := io.ReadAll(body)
+			body.Close()
+			tflog.Trace(ctx, "Sending IQ API request body", mergeFields(fields, map[string]interface{}{
+				"body": redactBody(payload),
+			}))
+		}
+	}
+
+	tflog.Debug(ctx, "Sending IQ API request", fields)
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	fields["duration_ms"] = time.Since(start).Milliseconds()
+
+	if err != nil {
+		tflog.Debug(ctx, "IQ API request failed", mergeFields(fields, map[string]interface{}{
+			"error": err.Error(),
+		}))
+		return resp, err
+	}
+
+	fields["status"] = resp.StatusCode
+	tflog.Debug(ctx, "Received IQ API response", fields)
+
+	payload, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(payload))
+	if err != nil {
+		return resp, err
+	}
+	tflog.Trace(ctx, "Received IQ API response body", mergeFields(fields, map[string]interface{}{
+		"body": redactBody(payload),
+	}))
+
+	return resp, nil
+}
+
+// withMaskedSecrets masks the given secrets in everything logged with the returned context, e.g.
+// when IQ Server or a proxy in front of it echoes them in an error. Empty secrets are ignored.
+func withMaskedSecrets(ctx context.Context, secrets []string) context.Context {
+	var masked []string
+	for _, secret := range secrets {
+		if len(secret) > 0 {
+			masked = append(masked, secret)
+		}
+	}
+	if len(masked) == 0 {
+		return ctx
+	}
+	return tflog.MaskLogStrings(ctx, masked...)
+}
+
+func mergeFields(fields map[string]interface{}, extra map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(fields)+len(extra))
+	for k, v := range fields {
+		merged[k] = v
+	}
+	for k, v := range extra {
+		merged[k] = v
+	}
+	return merged
+}
+
+// redactBody replaces the values of sensitive keys in a JSON payload. Payloads that are not
+// JSON are logged as is.
+func redactBody(payload []byte) string {
+	var body interface{}
+	if err := json.Unmarshal(payload, &body); err != nil {
+		return string(payload)
+	}
+	redacted, err := json.Marshal(redactValue(body))
+	if err != nil {
+		return string(payload)
+	}
+	return string(redacted)
+}
+
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, nested := range v {
+			if isRedactedKey(key) {
+				v[key] = "***"
+			} else {
+				v[key] = redactValue(nested)
+			}
+		}
+	case []interface{}:
+		for i, nested := range v {
+			v[i] = redactValue(nested)
+		}
+	}
+	return value
+}
+
+func isRedactedKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, redacted := range redactedKeys {
+		if strings.Contains(key, redacted) {
+			return true
+		}
+	}
+	return false
+}
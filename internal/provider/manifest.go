@@ -0,0 +1,232 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Supported dependency manifest formats, named after the manifest files.
+const (
+	manifestPomXml          = "pom.xml"
+	manifestPackageLockJson = "package-lock.json"
+	manifestRequirementsTxt = "requirements.txt"
+)
+
+// parseManifest returns the Package URLs of the dependencies declared in a dependency manifest,
+// sorted and without duplicates, along with the dependencies that were skipped because their
+// version is not pinned in the manifest. Dependencies are not resolved any further: a pom.xml only
+// yields its direct dependencies, while a package-lock.json lists all dependencies already.
+func parseManifest(format string, content string) ([]string, []string, error) {
+	var purls, skipped []string
+	var err error
+	switch format {
+	case manifestPomXml:
+		purls, skipped, err = parsePomXml(content)
+	case manifestPackageLockJson:
+		purls, err = parsePackageLockJson(content)
+	case manifestRequirementsTxt:
+		purls, skipped = parseRequirementsTxt(content)
+	default:
+		return nil, nil, fmt.Errorf("unsupported manifest format '%s'", format)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return uniqueSorted(purls), skipped, nil
+}
+
+type pomProject struct {
+	GroupId      string          `xml:"groupId"`
+	Version      string          `xml:"version"`
+	Parent       pomParent       `xml:"parent"`
+	Properties   pomProperties   `xml:"properties"`
+	Dependencies []pomDependency `xml:"dependencies>dependency"`
+}
+
+type pomParent struct {
+	GroupId string `xml:"groupId"`
+	Version string `xml:"version"`
+}
+
+type pomDependency struct {
+	GroupId    string `xml:"groupId"`
+	ArtifactId string `xml:"artifactId"`
+	Version    string `xml:"version"`
+	Type       string `xml:"type"`
+	Classifier string `xml:"classifier"`
+}
+
+// pomProperties collects the properties of a POM, which are elements named after the property.
+type pomProperties map[string]string
+
+func (p *pomProperties) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	*p = pomProperties{}
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch element := token.(type) {
+		case xml.StartElement:
+			var value string
+			if err := d.DecodeElement(&value, &element); err != nil {
+				return err
+			}
+			(*p)[element.Name.Local] = strings.TrimSpace(value)
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+var pomPropertyPattern = regexp.MustCompile(`\$\{([^}]+)\}`)
+
+func parsePomXml(content string) ([]string, []string, error) {
+	var project pomProject
+	if err := xml.Unmarshal([]byte(content), &project); err != nil {
+		return nil, nil, fmt.Errorf("invalid pom.xml: %w", err)
+	}
+
+	properties := map[string]string{}
+	for name, value := range project.Properties {
+		properties[name] = value
+	}
+	properties["project.groupId"] = firstNonEmpty(project.GroupId, project.Parent.GroupId)
+	properties["project.version"] = firstNonEmpty(project.Version, project.Parent.Version)
+	properties["project.parent.version"] = project.Parent.Version
+
+	// Properties may refer to other properties, so resolve them a few levels deep
+	resolve := func(value string) string {
+		for i := 0; i < 5 && strings.Contains(value, "${"); i++ {
+			value = pomPropertyPattern.ReplaceAllStringFunc(value, func(reference string) string {
+				if resolved, ok := properties[reference[2:len(reference)-1]]; ok && len(resolved) > 0 {
+					return resolved
+				}
+				return reference
+			})
+		}
+		return strings.TrimSpace(value)
+	}
+
+	var purls, skipped []string
+	for _, dependency := range project.Dependencies {
+		groupId, artifactId, version := resolve(dependency.GroupId), resolve(dependency.ArtifactId), resolve(dependency.Version)
+		coordinates := groupId + ":" + artifactId
+		if len(version) == 0 || strings.Contains(version, "${") || strings.ContainsAny(version, "[(,)]") {
+			skipped = append(skipped, coordinates)
+			continue
+		}
+		packaging := firstNonEmpty(resolve(dependency.Type), "jar")
+		classifier := resolve(dependency.Classifier)
+		purls = append(purls, buildPurl("maven", &groupId, artifactId, &version, map[string]*string{
+			"type":       &packaging,
+			"classifier": &classifier,
+		}))
+	}
+	return purls, skipped, nil
+}
+
+type packageLock struct {
+	Packages     map[string]packageLockEntry `json:"packages"`
+	Dependencies map[string]packageLockEntry `json:"dependencies"`
+}
+
+type packageLockEntry struct {
+	Name         string                      `json:"name"`
+	Version      string                      `json:"version"`
+	Link         bool                        `json:"link"`
+	Dependencies map[string]packageLockEntry `json:"dependencies"`
+}
+
+func parsePackageLockJson(content string) ([]string, error) {
+	var lock packageLock
+	if err := json.Unmarshal([]byte(content), &lock); err != nil {
+		return nil, fmt.Errorf("invalid package-lock.json: %w", err)
+	}
+
+	var purls []string
+	npmPurl := func(name string, version string) string {
+		var namespace *string
+		if scope, unscoped, ok := strings.Cut(name, "/"); ok && strings.HasPrefix(scope, "@") {
+			namespace, name = &scope, unscoped
+		}
+		return buildPurl("npm", namespace, name, &version, nil)
+	}
+
+	// Lockfile version 2 and later list all packages by their path below node_modules
+	if lock.Packages != nil {
+		for path, entry := range lock.Packages {
+			index := strings.LastIndex(path, "node_modules/")
+			if index < 0 || entry.Link || len(entry.Version) == 0 {
+				continue
+			}
+			purls = append(purls, npmPurl(firstNonEmpty(entry.Name, path[index+len("node_modules/"):]), entry.Version))
+		}
+		return purls, nil
+	}
+
+	// Lockfile version 1 nests the dependencies of dependencies
+	var walk func(dependencies map[string]packageLockEntry)
+	walk = func(dependencies map[string]packageLockEntry) {
+		for name, entry := range dependencies {
+			if len(entry.Version) > 0 && !strings.HasPrefix(entry.Version, "file:") {
+				purls = append(purls, npmPurl(name, entry.Version))
+			}
+			walk(entry.Dependencies)
+		}
+	}
+	walk(lock.Dependencies)
+	return purls, nil
+}
+
+var requirementPattern = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)\s*(\[[^\]]*\])?\s*===?\s*([^\s;,#]+)\s*(;.*)?$`)
+
+func parseRequirementsTxt(content string) ([]string, []string) {
+	var purls, skipped []string
+	for _, line := range strings.Split(content, "\n") {
+		line, _, _ = strings.Cut(line, " #")
+		line = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(line), "\\"))
+		if len(line) == 0 || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "-") {
+			continue
+		}
+		match := requirementPattern.FindStringSubmatch(line)
+		if match == nil || strings.Contains(match[3], "*") {
+			skipped = append(skipped, line)
+			continue
+		}
+		purls = append(purls, buildPurl("pypi", nil, match[1], &match[3], nil))
+	}
+	return purls, skipped
+}
+
+// uniqueSorted sorts values and removes duplicates.
+func uniqueSorted(values []string) []string {
+	sort.Strings(values)
+	unique := values[:0]
+	for i, value := range values {
+		if i == 0 || value != values[i-1] {
+			unique = append(unique, value)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,320 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	sonatypeiq "github.com/sonatype-nexus-community/nexus-iq-api-client-go"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &manifestEvaluationDataSource{}
+	_ datasource.DataSourceWithConfigure = &manifestEvaluationDataSource{}
+)
+
+const (
+	// defaultManifestEvaluationTimeout is how long to wait for the results of a manifest evaluation
+	// when no timeout is configured.
+	defaultManifestEvaluationTimeout = 5 * time.Minute
+
+	// manifestEvaluationPollInterval is how often the results of a manifest evaluation are requested.
+	manifestEvaluationPollInterval = 2 * time.Second
+)
+
+// ManifestEvaluationDataSource is a helper function to simplify the provider implementation.
+func ManifestEvaluationDataSource() datasource.DataSource {
+	return &manifestEvaluationDataSource{}
+}
+
+// manifestEvaluationDataSource is the data source implementation.
+type manifestEvaluationDataSource struct {
+	baseDataSource
+}
+
+type manifestEvaluationDataSourceModel struct {
+	ID                  types.String                       `tfsdk:"id"`
+	ApplicationId       types.String                       `tfsdk:"application_id"`
+	Manifest            types.String                       `tfsdk:"manifest"`
+	ManifestFormat      types.String                       `tfsdk:"manifest_format"`
+	Timeout             types.String                       `tfsdk:"timeout"`
+	EvaluationDate      types.String                       `tfsdk:"evaluation_date"`
+	SkippedDependencies []types.String                     `tfsdk:"skipped_dependencies"`
+	MaxThreatLevel      types.Int64                        `tfsdk:"max_threat_level"`
+	Components          []manifestEvaluationComponentModel `tfsdk:"components"`
+}
+
+type manifestEvaluationComponentModel struct {
+	PackageUrl       types.String                       `tfsdk:"package_url"`
+	DisplayName      types.String                       `tfsdk:"display_name"`
+	MatchState       types.String                       `tfsdk:"match_state"`
+	MaxThreatLevel   types.Int64                        `tfsdk:"max_threat_level"`
+	PolicyViolations []manifestEvaluationViolationModel `tfsdk:"policy_violations"`
+}
+
+type manifestEvaluationViolationModel struct {
+	PolicyId    types.String `tfsdk:"policy_id"`
+	PolicyName  types.String `tfsdk:"policy_name"`
+	ThreatLevel types.Int64  `tfsdk:"threat_level"`
+}
+
+// Metadata returns the data source type name.
+func (d *manifestEvaluationDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_manifest_evaluation"
+}
+
+// Schema defines the schema for the data source.
+func (d *manifestEvaluationDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Use this data source to evaluate the dependencies in a dependency manifest against the policies of an Application, without a full scan and without creating a report. " +
+			"The dependencies are read from the manifest by the provider and evaluated as individual components: a pom.xml only yields its direct dependencies, and dependencies without a pinned version are skipped",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"application_id": schema.StringAttribute{
+				Description: "Internal ID or public ID of the Application whose policies to evaluate against",
+				Required:    true,
+			},
+			"manifest": schema.StringAttribute{
+				Description: "Contents of the dependency manifest, e.g. read with the file function",
+				Required:    true,
+			},
+			"manifest_format": schema.StringAttribute{
+				Description: fmt.Sprintf("Format of the manifest: %s, %s or %s", manifestPomXml, manifestPackageLockJson, manifestRequirementsTxt),
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(manifestPomXml, manifestPackageLockJson, manifestRequirementsTxt),
+				},
+			},
+			"timeout": schema.StringAttribute{
+				Description: "Maximum time to wait for the evaluation, as a duration such as \"30s\" or \"10m\" - defaults to 5m",
+				Optional:    true,
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			"evaluation_date": schema.StringAttribute{
+				Description: "Date and time (RFC 3339) of the evaluation",
+				Computed:    true,
+			},
+			"skipped_dependencies": schema.ListAttribute{
+				Description: "Dependencies in the manifest that were not evaluated, as their version is not pinned",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"max_threat_level": schema.Int64Attribute{
+				Description: "Highest threat level of the policy violations of all components - 0 when there are none",
+				Computed:    true,
+			},
+			"components": schema.ListNestedAttribute{
+				Description: "Evaluation results of the components in the manifest",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"package_url": schema.StringAttribute{
+							Description: "Package URL of the component",
+							Computed:    true,
+						},
+						"display_name": schema.StringAttribute{
+							Description: "Display name of the component",
+							Computed:    true,
+						},
+						"match_state": schema.StringAttribute{
+							Description: "How the component was matched by Sonatype IQ Server (exact, similar or unknown)",
+							Computed:    true,
+						},
+						"max_threat_level": schema.Int64Attribute{
+							Description: "Highest threat level of the policy violations of the component - 0 when there are none",
+							Computed:    true,
+						},
+						"policy_violations": schema.ListNestedAttribute{
+							Description: "Policy violations of the component",
+							Computed:    true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"policy_id": schema.StringAttribute{
+										Description: "Internal ID of the violated policy",
+										Computed:    true,
+									},
+									"policy_name": schema.StringAttribute{
+										Description: "Name of the violated policy",
+										Computed:    true,
+									},
+									"threat_level": schema.Int64Attribute{
+										Description: "Threat level of the violated policy",
+										Computed:    true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *manifestEvaluationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if deferUnknownOwnerRead(req, resp) {
+		return
+	}
+
+	var data manifestEvaluationDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = context.WithValue(
+		ctx,
+		sonatypeiq.ContextBasicAuth,
+		d.auth,
+	)
+
+	purls, skipped, err := parseManifest(data.ManifestFormat.ValueString(), data.Manifest.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("manifest"), "Invalid Manifest", err.Error())
+		return
+	}
+	data.SkippedDependencies = []types.String{}
+	for _, dependency := range skipped {
+		data.SkippedDependencies = append(data.SkippedDependencies, types.StringValue(dependency))
+	}
+	if len(skipped) > 0 {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("manifest"),
+			"Dependencies Skipped",
+			fmt.Sprintf("%d dependencies were not evaluated, as their version is not pinned in the manifest: %s", len(skipped), strings.Join(skipped, ", ")),
+		)
+	}
+
+	applicationId, diags := resolveApplicationId(ctx, d.client, data.ApplicationId.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(applicationId)
+	data.EvaluationDate = types.StringNull()
+	data.MaxThreatLevel = types.Int64Value(0)
+	data.Components = []manifestEvaluationComponentModel{}
+	if len(purls) == 0 {
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	components := make([]sonatypeiq.ApiComponentDTOV2, 0, len(purls))
+	for i := range purls {
+		components = append(components, sonatypeiq.ApiComponentDTOV2{PackageUrl: &purls[i]})
+	}
+	// Evaluating components is sent as a POST, but does not change IQ Server
+	ticket, api_response, err := d.client.EvaluationAPI.EvaluateComponents1(onlyReads(ctx), applicationId).
+		ApiComponentEvaluationRequestDTOV2(sonatypeiq.ApiComponentEvaluationRequestDTOV2{Components: components}).
+		Execute()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Evaluate Manifest",
+			describeApiError(api_response, err),
+		)
+		return
+	}
+
+	timeout := defaultManifestEvaluationTimeout
+	if !data.Timeout.IsNull() {
+		// The value has already been validated
+		timeout, _ = time.ParseDuration(data.Timeout.ValueString())
+	}
+	result, err := d.awaitEvaluation(ctx, applicationId, ticket.GetResultId(), timeout)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Evaluate Manifest", err.Error())
+		return
+	}
+
+	data.ID = types.StringValue(ticket.GetResultId())
+	if result.EvaluationDate != nil {
+		data.EvaluationDate = types.StringValue(result.EvaluationDate.UTC().Format(time.RFC3339))
+	}
+	for _, details := range result.Results {
+		component := details.GetComponent()
+		model := manifestEvaluationComponentModel{
+			PackageUrl:       types.StringPointerValue(component.PackageUrl),
+			DisplayName:      types.StringPointerValue(component.DisplayName),
+			MatchState:       types.StringPointerValue(details.MatchState),
+			MaxThreatLevel:   types.Int64Value(0),
+			PolicyViolations: []manifestEvaluationViolationModel{},
+		}
+		policyData := details.GetPolicyData()
+		for _, violation := range policyData.PolicyViolations {
+			threatLevel := int64(violation.GetThreatLevel())
+			model.PolicyViolations = append(model.PolicyViolations, manifestEvaluationViolationModel{
+				PolicyId:    types.StringPointerValue(violation.PolicyId),
+				PolicyName:  types.StringPointerValue(violation.PolicyName),
+				ThreatLevel: types.Int64Value(threatLevel),
+			})
+			if threatLevel > model.MaxThreatLevel.ValueInt64() {
+				model.MaxThreatLevel = types.Int64Value(threatLevel)
+			}
+		}
+		if model.MaxThreatLevel.ValueInt64() > data.MaxThreatLevel.ValueInt64() {
+			data.MaxThreatLevel = model.MaxThreatLevel
+		}
+		data.Components = append(data.Components, model)
+	}
+
+	// Set state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// awaitEvaluation polls for the results of a component evaluation, which IQ Server reports as not
+// found until the evaluation has completed.
+func (d *manifestEvaluationDataSource) awaitEvaluation(ctx context.Context, applicationId string, resultId string, timeout time.Duration) (*sonatypeiq.ApiComponentEvaluationResultDTOV2, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for {
+		result, api_response, err := d.client.EvaluationAPI.GetComponentEvaluation(ctx, applicationId, resultId).Execute()
+		switch {
+		case err == nil && result.GetIsError():
+			return nil, fmt.Errorf("Sonatype IQ Server could not evaluate the manifest: %s", result.GetErrorMessage())
+		case err == nil:
+			return result, nil
+		case !isNotFound(api_response):
+			return nil, fmt.Errorf("%s", describeApiError(api_response, err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("the evaluation did not complete within %s", timeout)
+		case <-time.After(manifestEvaluationPollInterval):
+		}
+	}
+}
@@ -0,0 +1,50 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccManifestEvaluationDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccSandbox(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `data "sonatypeiq_manifest_evaluation" "sandbox" {
+					application_id  = "sandbox-application"
+					manifest_format = "requirements.txt"
+					manifest        = <<-EOT
+						django==1.6
+						requests
+					EOT
+				}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.sonatypeiq_manifest_evaluation.sandbox", "id"),
+					resource.TestCheckResourceAttrSet("data.sonatypeiq_manifest_evaluation.sandbox", "evaluation_date"),
+					resource.TestCheckResourceAttr("data.sonatypeiq_manifest_evaluation.sandbox", "skipped_dependencies.#", "1"),
+					resource.TestCheckResourceAttr("data.sonatypeiq_manifest_evaluation.sandbox", "components.#", "1"),
+					resource.TestCheckResourceAttr("data.sonatypeiq_manifest_evaluation.sandbox", "components.0.package_url", "pkg:pypi/django@1.6"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,141 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseManifest(t *testing.T) {
+	for name, tc := range map[string]struct {
+		format          string
+		content         string
+		expectedPurls   []string
+		expectedSkipped []string
+		expectError     bool
+	}{
+		"pom.xml": {
+			format: manifestPomXml,
+			content: `<project xmlns="http://maven.apache.org/POM/4.0.0">
+  <groupId>com.example</groupId>
+  <version>1.2.0</version>
+  <properties>
+    <jackson.version>2.15.2</jackson.version>
+    <databind.version>${jackson.version}</databind.version>
+  </properties>
+  <dependencies>
+    <dependency>
+      <groupId>com.fasterxml.jackson.core</groupId>
+      <artifactId>jackson-databind</artifactId>
+      <version>${databind.version}</version>
+    </dependency>
+    <dependency>
+      <groupId>${project.groupId}</groupId>
+      <artifactId>example-api</artifactId>
+      <version>${project.version}</version>
+      <classifier>tests</classifier>
+    </dependency>
+    <dependency>
+      <groupId>org.slf4j</groupId>
+      <artifactId>slf4j-api</artifactId>
+    </dependency>
+    <dependency>
+      <groupId>junit</groupId>
+      <artifactId>junit</artifactId>
+      <version>[4.0,5.0)</version>
+    </dependency>
+  </dependencies>
+</project>`,
+			expectedPurls: []string{
+				"pkg:maven/com.example/example-api@1.2.0?classifier=tests&type=jar",
+				"pkg:maven/com.fasterxml.jackson.core/jackson-databind@2.15.2?type=jar",
+			},
+			expectedSkipped: []string{"org.slf4j:slf4j-api", "junit:junit"},
+		},
+		"package-lock.json v3": {
+			format: manifestPackageLockJson,
+			content: `{
+  "lockfileVersion": 3,
+  "packages": {
+    "": {"name": "example", "version": "1.0.0"},
+    "node_modules/lodash": {"version": "4.17.21"},
+    "node_modules/@babel/core": {"version": "7.22.5"},
+    "node_modules/@babel/core/node_modules/semver": {"version": "6.3.1"},
+    "node_modules/local": {"resolved": "../local", "link": true}
+  }
+}`,
+			expectedPurls: []string{
+				"pkg:npm/%40babel/core@7.22.5",
+				"pkg:npm/lodash@4.17.21",
+				"pkg:npm/semver@6.3.1",
+			},
+		},
+		"package-lock.json v1": {
+			format: manifestPackageLockJson,
+			content: `{
+  "lockfileVersion": 1,
+  "dependencies": {
+    "lodash": {"version": "4.17.21"},
+    "debug": {"version": "4.3.4", "dependencies": {"ms": {"version": "2.1.2"}}}
+  }
+}`,
+			expectedPurls: []string{
+				"pkg:npm/debug@4.3.4",
+				"pkg:npm/lodash@4.17.21",
+				"pkg:npm/ms@2.1.2",
+			},
+		},
+		"requirements.txt": {
+			format: manifestRequirementsTxt,
+			content: `# Pinned dependencies
+-r base.txt
+Django==4.2.3
+requests[socks]==2.31.0 ; python_version >= "3.8"  # HTTP
+typing_extensions==4.7.1 \
+    --hash=sha256:0123456789abcdef
+urllib3>=2.0
+numpy==1.*
+`,
+			expectedPurls: []string{
+				"pkg:pypi/django@4.2.3",
+				"pkg:pypi/requests@2.31.0",
+				"pkg:pypi/typing-extensions@4.7.1",
+			},
+			expectedSkipped: []string{"urllib3>=2.0", "numpy==1.*"},
+		},
+		"invalid pom.xml":           {format: manifestPomXml, content: `<project>`, expectError: true},
+		"invalid package-lock.json": {format: manifestPackageLockJson, content: `[`, expectError: true},
+		"unsupported format":        {format: "build.gradle", expectError: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			purls, skipped, err := parseManifest(tc.format, tc.content)
+			if (err != nil) != tc.expectError {
+				t.Fatalf("Expected error %t, got %v", tc.expectError, err)
+			}
+			if tc.expectError {
+				return
+			}
+			if !reflect.DeepEqual(purls, tc.expectedPurls) {
+				t.Errorf("Expected Package URLs %v, got %v", tc.expectedPurls, purls)
+			}
+			if !reflect.DeepEqual(skipped, tc.expectedSkipped) {
+				t.Errorf("Expected skipped dependencies %v, got %v", tc.expectedSkipped, skipped)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+
+	sonatypeiq "github.com/sonatype-nexus-community/nexus-iq-api-client-go"
+)
+
+// metricsTimePeriods are the granularities of the Success Metrics reporting API.
+var metricsTimePeriods = []string{"WEEK", "MONTH"}
+
+// metricsTimePeriodValidators validate the first and last time periods of a Success Metrics query,
+// which are a week start date (YYYY-MM-DD) or a month (YYYY-MM).
+var metricsTimePeriodValidators = []validator.String{
+	stringvalidator.RegexMatches(regexp.MustCompile(`^\d{4}-\d{2}(-\d{2})?$`), "must be a week start date (YYYY-MM-DD) or a month (YYYY-MM)"),
+}
+
+// applicationMetrics are the Success Metrics of a single Application as returned by the reporting API.
+type applicationMetrics struct {
+	ApplicationId       *string              `json:"applicationId,omitempty"`
+	ApplicationPublicId *string              `json:"applicationPublicId,omitempty"`
+	ApplicationName     *string              `json:"applicationName,omitempty"`
+	OrganizationId      *string              `json:"organizationId,omitempty"`
+	OrganizationName    *string              `json:"organizationName,omitempty"`
+	Aggregations        []metricsAggregation `json:"aggregations,omitempty"`
+}
+
+// metricsAggregation are the Success Metrics of an Application for a single time period. Violation
+// counts are keyed by policy type (SECURITY, LICENSE, QUALITY, OTHER) and then by threat level
+// (LOW, MODERATE, SEVERE, CRITICAL), mean times to resolution are in milliseconds.
+type metricsAggregation struct {
+	TimePeriodStart           string                      `json:"timePeriodStart"`
+	EvaluationCount           int64                       `json:"evaluationCount"`
+	MttrLowThreat             *int64                      `json:"mttrLowThreat,omitempty"`
+	MttrModerateThreat        *int64                      `json:"mttrModerateThreat,omitempty"`
+	MttrSevereThreat          *int64                      `json:"mttrSevereThreat,omitempty"`
+	MttrCriticalThreat        *int64                      `json:"mttrCriticalThreat,omitempty"`
+	DiscoveredCounts          map[string]map[string]int64 `json:"discoveredCounts,omitempty"`
+	FixedCounts               map[string]map[string]int64 `json:"fixedCounts,omitempty"`
+	WaivedCounts              map[string]map[string]int64 `json:"waivedCounts,omitempty"`
+	OpenCountsAtTimePeriodEnd map[string]map[string]int64 `json:"openCountsAtTimePeriodEnd,omitempty"`
+}
+
+// getMetrics queries the Success Metrics reporting API. The generated client does not decode the
+// response, so the body is decoded here. The query is sent as a POST, which only reads.
+func getMetrics(ctx context.Context, client *sonatypeiq.APIClient, query sonatypeiq.ApiMetricsReportingQueryDTOV2) ([]applicationMetrics, *http.Response, error) {
+	api_response, err := client.ReportsAPI.GetMetrics(onlyReads(ctx)).ApiMetricsReportingQueryDTOV2(query).Execute()
+	if err != nil {
+		return nil, api_response, err
+	}
+
+	metrics := []applicationMetrics{}
+	if err := json.NewDecoder(api_response.Body).Decode(&metrics); err != nil {
+		return nil, api_response, err
+	}
+	return metrics, api_response, nil
+}
+
+// sumViolationCounts sums violation counts over all policy types, for a single threat level or for
+// all threat levels when threatLevel is empty.
+func sumViolationCounts(counts map[string]map[string]int64, threatLevel string) int64 {
+	var sum int64
+	for _, threatLevels := range counts {
+		for level, count := range threatLevels {
+			if threatLevel == "" || level == threatLevel {
+				sum += count
+			}
+		}
+	}
+	return sum
+}
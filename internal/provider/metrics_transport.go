@@ -0,0 +1,215 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// apiMetrics counts the requests sent to IQ Server by a provider process, so that they can be
+// written to a metrics file in the Prometheus text format once Terraform is done with the provider.
+type apiMetrics struct {
+	mu       sync.Mutex
+	started  time.Time
+	requests map[requestMetricKey]int64
+	seconds  map[endpointMetricKey]float64
+	retries  map[endpointMetricKey]int64
+}
+
+type endpointMetricKey struct {
+	method   string
+	endpoint string
+}
+
+type requestMetricKey struct {
+	endpointMetricKey
+	code string
+}
+
+// metricsFiles holds the metrics of every metrics file configured in this provider process.
+// Provider configurations sharing a metrics file share its metrics.
+var (
+	metricsFilesMu sync.Mutex
+	metricsFiles   = map[string]*apiMetrics{}
+)
+
+// metricsFor returns the metrics written to the metrics file at path.
+func metricsFor(path string) *apiMetrics {
+	metricsFilesMu.Lock()
+	defer metricsFilesMu.Unlock()
+
+	metrics, ok := metricsFiles[path]
+	if !ok {
+		metrics = &apiMetrics{
+			started:  time.Now(),
+			requests: map[requestMetricKey]int64{},
+			seconds:  map[endpointMetricKey]float64{},
+			retries:  map[endpointMetricKey]int64{},
+		}
+		metricsFiles[path] = metrics
+	}
+	return metrics
+}
+
+// WriteMetrics writes the metrics of the requests sent to Sonatype IQ Server to the metrics files
+// the provider was configured with, if any. Terraform starts the provider separately for planning
+// and for applying changes, so it is called when the provider process shuts down.
+func WriteMetrics() error {
+	metricsFilesMu.Lock()
+	defer metricsFilesMu.Unlock()
+
+	var errs []string
+	for path, metrics := range metricsFiles {
+		if err := metrics.write(path); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %s", path, err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("unable to write metrics file %s", strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+// recordRequest counts a single attempt of a request.
+func (m *apiMetrics) recordRequest(req *http.Request, resp *http.Response, err error, duration time.Duration) {
+	key := endpointMetricKey{method: req.Method, endpoint: metricsEndpoint(req.URL.Path)}
+	code := "error"
+	if err == nil {
+		code = strconv.Itoa(resp.StatusCode)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests[requestMetricKey{endpointMetricKey: key, code: code}]++
+	m.seconds[key] += duration.Seconds()
+}
+
+// recordRetry counts a request being retried. It is safe to call on nil metrics.
+func (m *apiMetrics) recordRetry(req *http.Request) {
+	if m == nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.retries[endpointMetricKey{method: req.Method, endpoint: metricsEndpoint(req.URL.Path)}]++
+}
+
+// format renders the metrics in the Prometheus text format, sorted so that the output is stable.
+func (m *apiMetrics) format() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var b strings.Builder
+	labels := func(key endpointMetricKey) string {
+		return fmt.Sprintf("method=%q,endpoint=%q", key.method, key.endpoint)
+	}
+	series := func(name string, help string, kind string, lines []string) {
+		sort.Strings(lines)
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+		for _, line := range lines {
+			b.WriteString(name + line + "\n")
+		}
+	}
+
+	var lines []string
+	for key, count := range m.requests {
+		lines = append(lines, fmt.Sprintf("{%s,code=%q} %d", labels(key.endpointMetricKey), key.code, count))
+	}
+	series("sonatypeiq_provider_requests_total", "Requests sent to Sonatype IQ Server, counting every attempt of a retried request.", "counter", lines)
+
+	lines = nil
+	for key, seconds := range m.seconds {
+		lines = append(lines, fmt.Sprintf("{%s} %s", labels(key), strconv.FormatFloat(seconds, 'f', 3, 64)))
+	}
+	series("sonatypeiq_provider_request_duration_seconds_total", "Time spent waiting for Sonatype IQ Server to respond.", "counter", lines)
+
+	lines = nil
+	for key, count := range m.retries {
+		lines = append(lines, fmt.Sprintf("{%s} %d", labels(key), count))
+	}
+	series("sonatypeiq_provider_retries_total", "Requests retried because Sonatype IQ Server was unavailable or throttled them.", "counter", lines)
+
+	series("sonatypeiq_provider_wall_time_seconds", "Time from configuring the provider until it shut down.", "gauge",
+		[]string{" " + strconv.FormatFloat(time.Since(m.started).Seconds(), 'f', 3, 64)})
+	return b.String()
+}
+
+// write replaces the metrics file at path, through a temporary file so that a collector never
+// reads a partially written file.
+func (m *apiMetrics) write(path string) error {
+	file, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	_, err = file.WriteString(m.format())
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.Chmod(file.Name(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), path)
+}
+
+// metricsIdSegment matches path segments that identify a single object, such as internal IDs.
+var metricsIdSegment = regexp.MustCompile(`^([0-9a-fA-F]{16,}|[0-9a-fA-F-]{36}|[0-9]+)$`)
+
+// metricsEndpoint replaces the identifiers in a request path by {id}, so that requests for
+// different objects are counted against the same endpoint. User and group names are not
+// recognizable as such, so the segments following users, user and group are replaced as well.
+func metricsEndpoint(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if len(segment) == 0 {
+			continue
+		}
+		if i > 0 && (segments[i-1] == "users" || segments[i-1] == "user" || segments[i-1] == "group") {
+			segments[i] = "{id}"
+		} else if metricsIdSegment.MatchString(segment) {
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// metricsTransport counts every request sent to IQ Server along with the time it took to respond.
+type metricsTransport struct {
+	next    http.RoundTripper
+	metrics *apiMetrics
+}
+
+func (t *metricsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	t.metrics.recordRequest(req, resp, err, time.Since(start))
+	return resp, err
+}
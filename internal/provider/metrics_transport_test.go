@@ -0,0 +1,93 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestMetricsTransport(t *testing.T) {
+	var received atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		// The first request is throttled, and then retried
+		if received.Add(1) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	metricsFile := filepath.Join(t.TempDir(), "sonatypeiq.prom")
+	client, diags := newHttpClient(SonatypeIqProviderModel{
+		Url:          types.StringValue(server.URL),
+		MetricsFile:  types.StringValue(metricsFile),
+		RetryWaitMin: types.Int64Value(0),
+		RetryWaitMax: types.Int64Value(0),
+	})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	for _, applicationId := range []string{"4bb67dcfc86344e3a483832f8c496419", "8f3a2b1c9d4e4f5a6b7c8d9e0f1a2b3c"} {
+		resp, err := client.Get(server.URL + "/api/v2/applications/" + applicationId)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		resp.Body.Close()
+	}
+
+	if err := metricsFor(metricsFile).write(metricsFile); err != nil {
+		t.Fatalf("unable to write metrics file: %s", err)
+	}
+	content, err := os.ReadFile(metricsFile)
+	if err != nil {
+		t.Fatalf("unable to read metrics file: %s", err)
+	}
+	for _, expected := range []string{
+		`sonatypeiq_provider_requests_total{method="GET",endpoint="/api/v2/applications/{id}",code="200"} 2`,
+		`sonatypeiq_provider_requests_total{method="GET",endpoint="/api/v2/applications/{id}",code="429"} 1`,
+		`sonatypeiq_provider_retries_total{method="GET",endpoint="/api/v2/applications/{id}"} 1`,
+		`# TYPE sonatypeiq_provider_wall_time_seconds gauge`,
+	} {
+		if !strings.Contains(string(content), expected) {
+			t.Errorf("expected metrics file to contain %s, got:\n%s", expected, content)
+		}
+	}
+}
+
+func TestMetricsEndpoint(t *testing.T) {
+	for path, expected := range map[string]string{
+		"/api/v2/organizations":                                 "/api/v2/organizations",
+		"/api/v2/applications/4bb67dcfc86344e3a483832f8c496419": "/api/v2/applications/{id}",
+		"/api/v2/users/jane.doe":                                "/api/v2/users/{id}",
+		"/api/v2/roleMemberships/organization/ROOT_ORGANIZATION_ID/role/1cddabf7fdaa47d6833454af10e0a3ef/user/jane.doe": "/api/v2/roleMemberships/organization/ROOT_ORGANIZATION_ID/role/{id}/user/{id}",
+		"/api/v2/reports/applications/4bb67dcfc86344e3a483832f8c496419/history":                                         "/api/v2/reports/applications/{id}/history",
+	} {
+		if actual := metricsEndpoint(path); actual != expected {
+			t.Errorf("expected %s for %s, got %s", expected, path, actual)
+		}
+	}
+}
@@ -0,0 +1,348 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	sonatypeiq "github.com/sonatype-nexus-community/nexus-iq-api-client-go"
+)
+
+// mockIqServer is an in-memory stand-in for the IQ Server endpoints used by the Organization and
+// Application resources and by Export, so that requests and error handling can be tested without
+// IQ Server.
+type mockIqServer struct {
+	*httptest.Server
+
+	mu            sync.Mutex
+	nextId        int
+	organizations map[string]sonatypeiq.ApiOrganizationDTO
+	applications  map[string]sonatypeiq.ApiApplicationDTO
+	roles         []sonatypeiq.ApiRoleDTO
+
+	// roleMemberships and sourceControls are keyed by "ownerType/ownerId".
+	roleMemberships map[string][]sonatypeiq.ApiRoleMemberMappingDTO
+	sourceControls  map[string]sonatypeiq.ApiSourceControlDTO
+
+	// failures maps "METHOD /path" to the status IQ Server responds with instead.
+	failures map[string]int
+
+	// requests holds "METHOD /path body" for every request received.
+	requests []string
+}
+
+func newMockIqServer(t *testing.T) *mockIqServer {
+	m := &mockIqServer{
+		organizations: map[string]sonatypeiq.ApiOrganizationDTO{
+			"ROOT_ORGANIZATION_ID": {
+				Id:   sonatypeiq.PtrString("ROOT_ORGANIZATION_ID"),
+				Name: sonatypeiq.PtrString("Root Organization"),
+			},
+		},
+		applications:    map[string]sonatypeiq.ApiApplicationDTO{},
+		roleMemberships: map[string][]sonatypeiq.ApiRoleMemberMappingDTO{},
+		sourceControls:  map[string]sonatypeiq.ApiSourceControlDTO{},
+		failures:        map[string]int{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v2/organizations", m.getOrganizations)
+	mux.HandleFunc("POST /api/v2/organizations", m.addOrganization)
+	mux.HandleFunc("GET /api/v2/organizations/{id}", m.getOrganization)
+	mux.HandleFunc("DELETE /api/v2/organizations/{id}", m.deleteOrganization)
+	mux.HandleFunc("GET /api/v2/applications", m.getApplications)
+	mux.HandleFunc("POST /api/v2/applications", m.addApplication)
+	mux.HandleFunc("GET /api/v2/applications/{id}", m.getApplication)
+	mux.HandleFunc("PUT /api/v2/applications/{id}", m.updateApplication)
+	mux.HandleFunc("DELETE /api/v2/applications/{id}", m.deleteApplication)
+	mux.HandleFunc("GET /api/v2/applications/organization/{id}", m.getOrganizationApplications)
+	mux.HandleFunc("GET /api/v2/roles", m.getRoles)
+	mux.HandleFunc("GET /api/v2/roleMemberships/{ownerType}/{id}", m.getRoleMemberships)
+	mux.HandleFunc("PUT /api/v2/roleMemberships/{ownerType}/{id}/role/{roleId}/{memberType}/{memberName}", m.grantRoleMembership)
+	mux.HandleFunc("DELETE /api/v2/roleMemberships/{ownerType}/{id}/role/{roleId}/{memberType}/{memberName}", m.revokeRoleMembership)
+	mux.HandleFunc("GET /api/v2/sourceControl/{ownerType}/{id}", m.getSourceControl)
+
+	m.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		req.Body = io.NopCloser(bytes.NewReader(body))
+
+		m.mu.Lock()
+		m.requests = append(m.requests, fmt.Sprintf("%s %s %s", req.Method, req.URL.Path, body))
+		status, fail := m.failures[req.Method+" "+req.URL.Path]
+		m.mu.Unlock()
+
+		if fail {
+			http.Error(w, http.StatusText(status), status)
+			return
+		}
+		mux.ServeHTTP(w, req)
+	}))
+	t.Cleanup(m.Close)
+
+	return m
+}
+
+// fail makes IQ Server respond to a request with the given status.
+func (m *mockIqServer) fail(method string, path string, status int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.failures[method+" "+path] = status
+}
+
+// received returns whether a request with the given method and path, and a body containing the
+// given text, was received.
+func (m *mockIqServer) received(method string, path string, body string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, request := range m.requests {
+		if strings.HasPrefix(request, method+" "+path+" ") && strings.Contains(request[len(method+" "+path+" "):], body) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *mockIqServer) id(prefix string) string {
+	m.nextId++
+	return fmt.Sprintf("%s-%d", prefix, m.nextId)
+}
+
+func (m *mockIqServer) getOrganizations(w http.ResponseWriter, _ *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	list := sonatypeiq.ApiOrganizationListDTO{Organizations: []sonatypeiq.ApiOrganizationDTO{}}
+	for _, organization := range m.organizations {
+		list.Organizations = append(list.Organizations, organization)
+	}
+	writeJson(w, http.StatusOK, list)
+}
+
+func (m *mockIqServer) addOrganization(w http.ResponseWriter, req *http.Request) {
+	var organization sonatypeiq.ApiOrganizationDTO
+	if err := json.NewDecoder(req.Body).Decode(&organization); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, existing := range m.organizations {
+		if existing.GetName() == organization.GetName() {
+			http.Error(w, "Organization name already exists", http.StatusConflict)
+			return
+		}
+	}
+	if _, ok := m.organizations[organization.GetParentOrganizationId()]; !ok {
+		http.Error(w, "Parent Organization not found", http.StatusNotFound)
+		return
+	}
+	organization.Id = sonatypeiq.PtrString(m.id("organization"))
+	m.organizations[organization.GetId()] = organization
+	writeJson(w, http.StatusOK, organization)
+}
+
+func (m *mockIqServer) getOrganization(w http.ResponseWriter, req *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	organization, ok := m.organizations[req.PathValue("id")]
+	if !ok {
+		http.NotFound(w, req)
+		return
+	}
+	writeJson(w, http.StatusOK, organization)
+}
+
+func (m *mockIqServer) deleteOrganization(w http.ResponseWriter, req *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.organizations[req.PathValue("id")]; !ok {
+		http.NotFound(w, req)
+		return
+	}
+	delete(m.organizations, req.PathValue("id"))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (m *mockIqServer) addApplication(w http.ResponseWriter, req *http.Request) {
+	var application sonatypeiq.ApiApplicationDTO
+	if err := json.NewDecoder(req.Body).Decode(&application); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, existing := range m.applications {
+		if existing.GetPublicId() == application.GetPublicId() {
+			http.Error(w, "Application public ID already exists", http.StatusConflict)
+			return
+		}
+	}
+	if _, ok := m.organizations[application.GetOrganizationId()]; !ok {
+		http.Error(w, "Organization not found", http.StatusNotFound)
+		return
+	}
+	application.Id = sonatypeiq.PtrString(m.id("application"))
+	m.applications[application.GetId()] = application
+	writeJson(w, http.StatusOK, application)
+}
+
+func (m *mockIqServer) getApplication(w http.ResponseWriter, req *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	application, ok := m.applications[req.PathValue("id")]
+	if !ok {
+		http.NotFound(w, req)
+		return
+	}
+	writeJson(w, http.StatusOK, application)
+}
+
+func (m *mockIqServer) updateApplication(w http.ResponseWriter, req *http.Request) {
+	var application sonatypeiq.ApiApplicationDTO
+	if err := json.NewDecoder(req.Body).Decode(&application); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.applications[req.PathValue("id")]; !ok {
+		http.NotFound(w, req)
+		return
+	}
+	application.Id = sonatypeiq.PtrString(req.PathValue("id"))
+	m.applications[application.GetId()] = application
+	writeJson(w, http.StatusOK, application)
+}
+
+func (m *mockIqServer) deleteApplication(w http.ResponseWriter, req *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.applications[req.PathValue("id")]; !ok {
+		http.NotFound(w, req)
+		return
+	}
+	delete(m.applications, req.PathValue("id"))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (m *mockIqServer) getOrganizationApplications(w http.ResponseWriter, req *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	list := sonatypeiq.ApiApplicationListDTO{Applications: []sonatypeiq.ApiApplicationDTO{}}
+	for _, application := range m.applications {
+		if application.GetOrganizationId() == req.PathValue("id") {
+			list.Applications = append(list.Applications, application)
+		}
+	}
+	writeJson(w, http.StatusOK, list)
+}
+
+func (m *mockIqServer) getApplications(w http.ResponseWriter, _ *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	list := sonatypeiq.ApiApplicationListDTO{Applications: []sonatypeiq.ApiApplicationDTO{}}
+	for _, application := range m.applications {
+		list.Applications = append(list.Applications, application)
+	}
+	writeJson(w, http.StatusOK, list)
+}
+
+func (m *mockIqServer) getRoles(w http.ResponseWriter, _ *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	writeJson(w, http.StatusOK, sonatypeiq.ApiRoleListDTO{Roles: m.roles})
+}
+
+func (m *mockIqServer) getRoleMemberships(w http.ResponseWriter, req *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	memberMappings := m.roleMemberships[req.PathValue("ownerType")+"/"+req.PathValue("id")]
+	if memberMappings == nil {
+		memberMappings = []sonatypeiq.ApiRoleMemberMappingDTO{}
+	}
+	writeJson(w, http.StatusOK, sonatypeiq.ApiRoleMemberMappingListDTO{MemberMappings: memberMappings})
+}
+
+func (m *mockIqServer) grantRoleMembership(w http.ResponseWriter, req *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := req.PathValue("ownerType") + "/" + req.PathValue("id")
+	member := sonatypeiq.ApiMemberDTO{
+		OwnerType:       sonatypeiq.PtrString(strings.ToUpper(req.PathValue("ownerType"))),
+		OwnerId:         sonatypeiq.PtrString(req.PathValue("id")),
+		Type:            sonatypeiq.PtrString(strings.ToUpper(req.PathValue("memberType"))),
+		UserOrGroupName: sonatypeiq.PtrString(req.PathValue("memberName")),
+	}
+	for i, memberMapping := range m.roleMemberships[key] {
+		if memberMapping.GetRoleId() == req.PathValue("roleId") {
+			m.roleMemberships[key][i].Members = append(memberMapping.Members, member)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+	m.roleMemberships[key] = append(m.roleMemberships[key], sonatypeiq.ApiRoleMemberMappingDTO{
+		RoleId:  sonatypeiq.PtrString(req.PathValue("roleId")),
+		Members: []sonatypeiq.ApiMemberDTO{member},
+	})
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (m *mockIqServer) revokeRoleMembership(w http.ResponseWriter, req *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := req.PathValue("ownerType") + "/" + req.PathValue("id")
+	for i, memberMapping := range m.roleMemberships[key] {
+		if memberMapping.GetRoleId() != req.PathValue("roleId") {
+			continue
+		}
+		for j, member := range memberMapping.Members {
+			if strings.EqualFold(member.GetType(), req.PathValue("memberType")) && member.GetUserOrGroupName() == req.PathValue("memberName") {
+				m.roleMemberships[key][i].Members = append(memberMapping.Members[:j], memberMapping.Members[j+1:]...)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+	}
+	http.NotFound(w, req)
+}
+
+func (m *mockIqServer) getSourceControl(w http.ResponseWriter, req *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	sourceControl, ok := m.sourceControls[req.PathValue("ownerType")+"/"+req.PathValue("id")]
+	if !ok {
+		http.NotFound(w, req)
+		return
+	}
+	writeJson(w, http.StatusOK, sourceControl)
+}
+
+func writeJson(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
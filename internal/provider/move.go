@@ -0,0 +1,91 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// communityProviderAddresses are the source addresses of other Sonatype IQ providers whose
+// resources can be moved to the resource of this provider with the same type name, using a moved
+// block, without destroying and recreating them.
+var communityProviderAddresses = []string{
+	"registry.terraform.io/sonatype-nexus-community/sonatypeiq",
+}
+
+// moveResourceState returns a state mover for resources of type typeName. It moves resources of
+// the same type that were created by one of the communityProviderAddresses, as well as resources
+// of a legacy type that was renamed to typeName (see legacyResourceTypes). Attributes this provider
+// does not know are dropped and attributes only this provider knows are null until the next Read,
+// unless fixup, which may be nil, sets them. Moves from other providers or resource types are left
+// to other movers.
+func moveResourceState(typeName string, fixup func(state map[string]interface{})) resource.StateMover {
+	return resource.StateMover{
+		StateMover: func(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+			community := req.SourceTypeName == typeName && slices.Contains(communityProviderAddresses, req.SourceProviderAddress)
+			if !community && legacyResourceTypes[req.SourceTypeName] != typeName {
+				return
+			}
+			if req.SourceRawState == nil {
+				resp.Diagnostics.AddError("Unable to move resource state", "No source state to move")
+				return
+			}
+
+			var state map[string]interface{}
+			if err := json.Unmarshal(req.SourceRawState.JSON, &state); err != nil {
+				resp.Diagnostics.AddError("Unable to move resource state", err.Error())
+				return
+			}
+			if fixup != nil {
+				fixup(state)
+			}
+			moved, err := json.Marshal(state)
+			if err != nil {
+				resp.Diagnostics.AddError("Unable to move resource state", err.Error())
+				return
+			}
+
+			targetType := resp.TargetState.Schema.Type().TerraformType(ctx)
+			value, err := (&tfprotov6.RawState{JSON: moved}).UnmarshalWithOpts(targetType, tfprotov6.UnmarshalOpts{
+				ValueFromJSONOpts: tftypes.ValueFromJSONOpts{
+					IgnoreUndefinedAttributes: true,
+				},
+			})
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Unable to move resource state",
+					"The state of "+req.SourceTypeName+" from "+req.SourceProviderAddress+" does not match the schema of this provider: "+err.Error(),
+				)
+				return
+			}
+			resp.TargetState.Raw = value
+		},
+	}
+}
+
+// setDefault sets an attribute of a raw state to its default value when it is not set.
+func setDefault(state map[string]interface{}, attribute string, value interface{}) {
+	if state[attribute] == nil {
+		state[attribute] = value
+	}
+}
@@ -0,0 +1,91 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestMoveResourceState(t *testing.T) {
+	ctx := context.Background()
+	r := NewApplicationResource()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	mover := r.(resource.ResourceWithMoveState).MoveState(ctx)[0]
+
+	move := func(providerAddress string, typeName string) *resource.MoveStateResponse {
+		resp := &resource.MoveStateResponse{
+			TargetState: tfsdk.State{
+				Schema: schemaResp.Schema,
+				Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+			},
+		}
+		mover.StateMover(ctx, resource.MoveStateRequest{
+			SourceProviderAddress: providerAddress,
+			SourceTypeName:        typeName,
+			SourceRawState: &tfprotov6.RawState{
+				JSON: []byte(`{"id":"4bb67dcfc86344e3a483832f8c496419","name":"Sandbox Application","public_id":"sandbox-application","organization_id":"ROOT_ORGANIZATION_ID","unknown_attribute":"dropped"}`),
+			},
+		}, resp)
+		return resp
+	}
+
+	resp := move("registry.terraform.io/sonatype-nexus-community/sonatypeiq", "sonatypeiq_application")
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	var publicId types.String
+	var deletionProtection types.Bool
+	resp.Diagnostics.Append(resp.TargetState.GetAttribute(ctx, path.Root("public_id"), &publicId)...)
+	resp.Diagnostics.Append(resp.TargetState.GetAttribute(ctx, path.Root("deletion_protection"), &deletionProtection)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	if publicId.ValueString() != "sandbox-application" {
+		t.Errorf("expected public_id to be moved, got: %s", publicId)
+	}
+	if deletionProtection.IsNull() || deletionProtection.ValueBool() {
+		t.Errorf("expected deletion_protection to default to false, got: %s", deletionProtection)
+	}
+
+	// Resources of a legacy type are moved from any provider address
+	legacyResourceTypes["sonatypeiq_app"] = "sonatypeiq_application"
+	defer delete(legacyResourceTypes, "sonatypeiq_app")
+	resp = move("registry.terraform.io/example/sonatypeiq", "sonatypeiq_app")
+	if resp.Diagnostics.HasError() || resp.TargetState.Raw.IsNull() {
+		t.Errorf("expected the legacy type to be moved, got: %v", resp.Diagnostics)
+	}
+
+	// Moves from other providers and resource types are left to other movers
+	for _, source := range [][2]string{
+		{"registry.terraform.io/hashicorp/random", "sonatypeiq_application"},
+		{"registry.terraform.io/sonatype-nexus-community/sonatypeiq", "sonatypeiq_organization"},
+	} {
+		resp := move(source[0], source[1])
+		if resp.Diagnostics.HasError() || !resp.TargetState.Raw.IsNull() {
+			t.Errorf("expected move of %s from %s to be skipped", source[1], source[0])
+		}
+	}
+}
@@ -0,0 +1,137 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	sonatypeiq "github.com/sonatype-nexus-community/nexus-iq-api-client-go"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &organizationApplicationsDataSource{}
+	_ datasource.DataSourceWithConfigure = &organizationApplicationsDataSource{}
+)
+
+// OrganizationApplicationsDataSource is a helper function to simplify the provider implementation.
+func OrganizationApplicationsDataSource() datasource.DataSource {
+	return &organizationApplicationsDataSource{}
+}
+
+// organizationApplicationsDataSource is the data source implementation.
+type organizationApplicationsDataSource struct {
+	baseDataSource
+}
+
+type organizationApplicationsDataSourceModel struct {
+	ID             types.String       `tfsdk:"id"`
+	OrganizationId types.String       `tfsdk:"organization_id"`
+	Recursive      types.Bool         `tfsdk:"recursive"`
+	Applications   []applicationModel `tfsdk:"applications"`
+}
+
+// Metadata returns the data source type name.
+func (d *organizationApplicationsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_organization_applications"
+}
+
+// Schema defines the schema for the data source.
+func (d *organizationApplicationsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Use this data source to get the Applications that belong to an Organization",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"organization_id": schema.StringAttribute{
+				Description: "Internal ID of the Organization - use 'ROOT_ORGANIZATION_ID' for the Root Organization",
+				Required:    true,
+			},
+			"recursive": schema.BoolAttribute{
+				Description: "Whether to include the Applications of all child Organizations - defaults to false",
+				Optional:    true,
+			},
+			"applications": schema.ListNestedAttribute{
+				Description:  "Applications that belong to the Organization",
+				Computed:     true,
+				NestedObject: applicationNestedObject(),
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *organizationApplicationsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if deferUnknownOwnerRead(req, resp) {
+		return
+	}
+
+	var data organizationApplicationsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = context.WithValue(
+		ctx,
+		sonatypeiq.ContextBasicAuth,
+		d.auth,
+	)
+
+	organizationIds := []string{data.OrganizationId.ValueString()}
+	if data.Recursive.ValueBool() {
+		// Walk the hierarchy breadth first, so Applications are listed by depth
+		var err error
+		organizationIds, err = d.organizationHierarchy().descendantIds(ctx, data.OrganizationId.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to Read IQ Organizations", err.Error())
+			return
+		}
+	}
+
+	data.Applications = []applicationModel{}
+	for _, organizationId := range organizationIds {
+		applications, api_response, err := d.client.ApplicationsAPI.GetApplicationsByOrganizationId(ctx, organizationId).Execute()
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read IQ Applications for Organization "+organizationId,
+				describeApiError(api_response, err),
+			)
+			return
+		}
+
+		for _, application := range applications.Applications {
+			data.Applications = append(data.Applications, applicationModelFromDTO(application))
+		}
+	}
+
+	data.ID = data.OrganizationId
+
+	// Set state
+	diags := resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -122,7 +122,7 @@ func (d *organizationDataSource) Read(ctx context.Context, req datasource.ReadRe
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Unable to Read IQ Organization by ID",
-				err.Error(),
+				describeApiError(r, err),
 			)
 			return
 		}
@@ -140,7 +140,7 @@ func (d *organizationDataSource) Read(ctx context.Context, req datasource.ReadRe
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Unable to Read IQ Organization by ID",
-				err.Error(),
+				describeApiError(r, err),
 			)
 			return
 		}
@@ -0,0 +1,136 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	sonatypeiq "github.com/sonatype-nexus-community/nexus-iq-api-client-go"
+)
+
+// organizationHierarchy resolves Organizations, their parents and their children from a single
+// list of all Organizations, which is read from IQ Server on first use. Use one hierarchy per
+// operation, so that an operation walks the hierarchy without a request per Organization, while
+// changes made in between operations are still seen.
+type organizationHierarchy struct {
+	client *sonatypeiq.APIClient
+
+	organizations []sonatypeiq.ApiOrganizationDTO
+	byId          map[string]sonatypeiq.ApiOrganizationDTO
+	children      map[string][]sonatypeiq.ApiOrganizationDTO
+}
+
+func newOrganizationHierarchy(client *sonatypeiq.APIClient) *organizationHierarchy {
+	return &organizationHierarchy{client: client}
+}
+
+// organizationHierarchy returns the Organization hierarchy for a single operation of the resource.
+func (r *baseResource) organizationHierarchy() *organizationHierarchy {
+	return newOrganizationHierarchy(r.client)
+}
+
+// organizationHierarchy returns the Organization hierarchy for a single read of the data source.
+func (d *baseDataSource) organizationHierarchy() *organizationHierarchy {
+	return newOrganizationHierarchy(d.client)
+}
+
+func (h *organizationHierarchy) load(ctx context.Context) error {
+	if h.byId != nil {
+		return nil
+	}
+
+	organizations, apiResponse, err := h.client.OrganizationsAPI.GetOrganizations(ctx).Execute()
+	if err != nil {
+		return errors.New(apiErrorDetail("Could not list the Organizations", apiResponse, err))
+	}
+
+	h.organizations = organizations.Organizations
+	h.byId = map[string]sonatypeiq.ApiOrganizationDTO{}
+	for _, organization := range h.organizations {
+		h.byId[organization.GetId()] = organization
+	}
+	h.children = childOrganizations(h.organizations)
+	return nil
+}
+
+// all returns all Organizations, in the order IQ Server lists them.
+func (h *organizationHierarchy) all(ctx context.Context) ([]sonatypeiq.ApiOrganizationDTO, error) {
+	if err := h.load(ctx); err != nil {
+		return nil, err
+	}
+	return h.organizations, nil
+}
+
+// get returns the Organization with the given internal ID.
+func (h *organizationHierarchy) get(ctx context.Context, organizationId string) (sonatypeiq.ApiOrganizationDTO, error) {
+	if err := h.load(ctx); err != nil {
+		return sonatypeiq.ApiOrganizationDTO{}, err
+	}
+	organization, ok := h.byId[organizationId]
+	if !ok {
+		return organization, fmt.Errorf("Organization %s does not exist", organizationId)
+	}
+	return organization, nil
+}
+
+// parentId returns the internal ID of the parent of an Organization, or nil for the Root Organization.
+func (h *organizationHierarchy) parentId(ctx context.Context, organizationId string) (*string, error) {
+	organization, err := h.get(ctx, organizationId)
+	if err != nil {
+		return nil, err
+	}
+	return organization.ParentOrganizationId, nil
+}
+
+// childMap maps the internal ID of each Organization to its child Organizations.
+func (h *organizationHierarchy) childMap(ctx context.Context) (map[string][]sonatypeiq.ApiOrganizationDTO, error) {
+	if err := h.load(ctx); err != nil {
+		return nil, err
+	}
+	return h.children, nil
+}
+
+// descendantIds returns the internal IDs of an Organization and all Organizations below it,
+// breadth first, so that Organizations are listed by depth.
+func (h *organizationHierarchy) descendantIds(ctx context.Context, organizationId string) ([]string, error) {
+	if err := h.load(ctx); err != nil {
+		return nil, err
+	}
+	organizationIds := []string{organizationId}
+	for i := 0; i < len(organizationIds); i++ {
+		for _, child := range h.children[organizationIds[i]] {
+			organizationIds = append(organizationIds, child.GetId())
+		}
+	}
+	return organizationIds, nil
+}
+
+// depth returns the number of ancestors of an Organization.
+func (h *organizationHierarchy) depth(ctx context.Context, organizationId string) (int, error) {
+	if err := h.load(ctx); err != nil {
+		return 0, err
+	}
+	depth := 0
+	organization := h.byId[organizationId]
+	for organization.ParentOrganizationId != nil && depth < len(h.organizations) {
+		depth++
+		organization = h.byId[*organization.ParentOrganizationId]
+	}
+	return depth, nil
+}
@@ -0,0 +1,79 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	sonatypeiq "github.com/sonatype-nexus-community/nexus-iq-api-client-go"
+)
+
+func TestOrganizationHierarchy(t *testing.T) {
+	server := newMockIqServer(t)
+	for _, organization := range []sonatypeiq.ApiOrganizationDTO{
+		{Id: sonatypeiq.PtrString("a"), Name: sonatypeiq.PtrString("A"), ParentOrganizationId: sonatypeiq.PtrString("ROOT_ORGANIZATION_ID")},
+		{Id: sonatypeiq.PtrString("b"), Name: sonatypeiq.PtrString("B"), ParentOrganizationId: sonatypeiq.PtrString("ROOT_ORGANIZATION_ID")},
+		{Id: sonatypeiq.PtrString("a1"), Name: sonatypeiq.PtrString("A1"), ParentOrganizationId: sonatypeiq.PtrString("a")},
+		{Id: sonatypeiq.PtrString("a1x"), Name: sonatypeiq.PtrString("A1X"), ParentOrganizationId: sonatypeiq.PtrString("a1")},
+	} {
+		server.organizations[organization.GetId()] = organization
+	}
+
+	configuration := sonatypeiq.NewConfiguration()
+	configuration.Servers = []sonatypeiq.ServerConfiguration{{URL: server.URL}}
+	hierarchy := newOrganizationHierarchy(sonatypeiq.NewAPIClient(configuration))
+	ctx := context.Background()
+
+	descendants, err := hierarchy.descendantIds(ctx, "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(descendants, ",") != "a,a1,a1x" {
+		t.Errorf("Descendants of a are %v, expected [a a1 a1x]", descendants)
+	}
+
+	parentId, err := hierarchy.parentId(ctx, "a1x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parentId == nil || *parentId != "a1" {
+		t.Errorf("Parent of a1x is %v, expected a1", parentId)
+	}
+	if parentId, err := hierarchy.parentId(ctx, "ROOT_ORGANIZATION_ID"); err != nil || parentId != nil {
+		t.Errorf("Parent of the Root Organization is %v (%v), expected none", parentId, err)
+	}
+	if _, err := hierarchy.parentId(ctx, "unknown"); err == nil {
+		t.Error("Expected an error for an unknown Organization")
+	}
+
+	depth, err := hierarchy.depth(ctx, "a1x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if depth != 3 {
+		t.Errorf("Depth of a1x is %d, expected 3", depth)
+	}
+
+	// The hierarchy is read from IQ Server once
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	if len(server.requests) != 1 {
+		t.Errorf("Expected a single request for the Organizations, got %q", server.requests)
+	}
+}
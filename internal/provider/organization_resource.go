@@ -19,11 +19,15 @@ package provider
 import (
 	"context"
 	"fmt"
-	"io"
+	"net/http"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
@@ -35,7 +39,8 @@ type organizationModelResouce struct {
 	Name                  types.String `tfsdk:"name"`
 	ParentOrganiziationId types.String `tfsdk:"parent_organization_id"`
 	// Tags                  types.List   `tfsdk:"tags"`
-	LastUpdated types.String `tfsdk:"last_updated"`
+	ForceDestroy types.Bool   `tfsdk:"force_destroy"`
+	LastUpdated  types.String `tfsdk:"last_updated"`
 }
 
 // organizationResource is the resource implementation.
@@ -94,6 +99,9 @@ func (r *organizationResource) Schema(_ context.Context, _ resource.SchemaReques
 				Description: "Internal ID of the Parent Organization if this Organization has a Parent Organization",
 				Computed:    true,
 				Optional:    true,
+				Validators: []validator.String{
+					organizationIdValidator,
+				},
 			},
 			// "tags": schema.ListNestedAttribute{
 			// 	Optional: true,
@@ -101,6 +109,12 @@ func (r *organizationResource) Schema(_ context.Context, _ resource.SchemaReques
 			// 		Attributes: tagSchemaObjectAttributes,
 			// 	},
 			// },
+			"force_destroy": schema.BoolAttribute{
+				Description: "Whether destroying the Organization also deletes all of its Applications and child Organizations - otherwise destroying an Organization that is not empty fails. Defaults to false. Applications with retain_on_destroy destroyed in the same run stop the destroy. Applications managed in another configuration or workspace are deleted even if they enable deletion_protection or retain_on_destroy there",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
 			"last_updated": schema.StringAttribute{
 				Computed: true,
 			},
@@ -155,10 +169,9 @@ func (r *organizationResource) Create(ctx context.Context, req resource.CreateRe
 
 	// Call API
 	if err != nil {
-		error_body, _ := io.ReadAll(api_response.Body)
 		resp.Diagnostics.AddError(
 			"Error creating Organization",
-			"Could not create Organization, unexpected error: "+api_response.Status+": "+string(error_body),
+			apiErrorDetail("Could not create Organization", api_response, err),
 		)
 		return
 	}
@@ -178,6 +191,9 @@ func (r *organizationResource) Create(ctx context.Context, req resource.CreateRe
 	// }
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
+	// IQ Server may not return the new entity straight away
+	resp.Diagnostics.Append(markCreated(ctx, resp.Private)...)
+
 	// Finally, set the state
 	tflog.Debug(ctx, "Storing certificate request info into the state")
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
@@ -200,13 +216,23 @@ func (r *organizationResource) Read(ctx context.Context, req resource.ReadReques
 	)
 
 	// Get refreshed Organization from IQ
-	organization, _, err := r.client.OrganizationsAPI.GetOrganization(ctx, state.ID.ValueString()).Execute()
+	var organization *sonatypeiq.ApiOrganizationDTO
+	var api_response *http.Response
+	var err error
+	resp.Diagnostics.Append(readConsistently(ctx, req.Private, resp.Private, func(ctx context.Context) bool {
+		organization, api_response, err = r.client.OrganizationsAPI.GetOrganization(ctx, state.ID.ValueString()).Execute()
+		return isNotFound(api_response)
+	})...)
 
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Reading IQ Organization",
-			"Could not read Organization with ID "+state.ID.ValueString()+": "+err.Error(),
-		)
+		if isNotFound(api_response) {
+			resp.State.RemoveResource(ctx)
+		} else {
+			resp.Diagnostics.AddError(
+				"Error Reading IQ Organization",
+				apiErrorDetail("Could not read Organization with ID "+state.ID.ValueString(), api_response, err),
+			)
+		}
 		return
 	}
 
@@ -244,7 +270,17 @@ func (r *organizationResource) Read(ctx context.Context, req resource.ReadReques
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *organizationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// No Update API
+	// No Update API, only settings of the provider itself can change
+	var plan organizationModelResouce
+	var state organizationModelResouce
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.ForceDestroy = plan.ForceDestroy
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -262,13 +298,150 @@ func (r *organizationResource) Delete(ctx context.Context, req resource.DeleteRe
 		r.auth,
 	)
 
+	children, err := r.organizationHierarchy().childMap(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting Organization", err.Error())
+		return
+	}
+
+	if !state.ForceDestroy.ValueBool() {
+		applications, api_response, err := r.client.ApplicationsAPI.GetApplicationsByOrganizationId(ctx, state.ID.ValueString()).Execute()
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error deleting Organization",
+				apiErrorDetail("Could not list the Applications of Organization "+state.ID.ValueString(), api_response, err),
+			)
+			return
+		}
+		if len(applications.Applications) > 0 || len(children[state.ID.ValueString()]) > 0 {
+			resp.Diagnostics.AddError(
+				"Organization is not empty",
+				fmt.Sprintf("Organization %s still contains %d Application(s) and %d child Organization(s). Delete these first, "+
+					"or set force_destroy to true and apply to delete them together with the Organization.",
+					state.Name.ValueString(), len(applications.Applications), len(children[state.ID.ValueString()])),
+			)
+			return
+		}
+	} else {
+		resp.Diagnostics.Append(r.checkRetained(ctx, state.ID.ValueString(), children)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.Append(r.deleteContents(ctx, state.ID.ValueString(), children)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	api_response, err := r.client.OrganizationsAPI.DeleteOrganization(ctx, state.ID.ValueString()).Execute()
 	if err != nil {
-		error_body, _ := io.ReadAll(api_response.Body)
 		resp.Diagnostics.AddError(
 			"Error deleting Organization",
-			"Could not delete Organization, unexpected error: "+api_response.Status+": "+string(error_body),
+			apiErrorDetail("Could not delete Organization", api_response, err),
 		)
 		return
 	}
 }
+
+// MoveState moves the resource from the Sonatype IQ community provider.
+func (r *organizationResource) MoveState(_ context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		moveResourceState("sonatypeiq_organization", func(state map[string]interface{}) {
+			setDefault(state, "force_destroy", false)
+		}),
+	}
+}
+
+// ImportState imports the resource by its internal ID.
+func (r *organizationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("force_destroy"), false)...)
+	resp.Diagnostics.Append(markCreated(ctx, resp.Private)...)
+}
+
+// childOrganizations maps the internal ID of each Organization to its child Organizations.
+func childOrganizations(organizations []sonatypeiq.ApiOrganizationDTO) map[string][]sonatypeiq.ApiOrganizationDTO {
+	children := map[string][]sonatypeiq.ApiOrganizationDTO{}
+	for _, organization := range organizations {
+		if organization.ParentOrganizationId != nil {
+			children[*organization.ParentOrganizationId] = append(children[*organization.ParentOrganizationId], organization)
+		}
+	}
+	return children
+}
+
+// checkRetained refuses to delete an Organization whose Applications, or those of its child
+// Organizations, include an Application that retain_on_destroy kept in IQ Server in this run.
+// This is checked before anything is deleted.
+func (r *organizationResource) checkRetained(ctx context.Context, organizationId string, children map[string][]sonatypeiq.ApiOrganizationDTO) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for _, child := range children[organizationId] {
+		diags.Append(r.checkRetained(ctx, child.GetId(), children)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	applications, api_response, err := r.client.ApplicationsAPI.GetApplicationsByOrganizationId(ctx, organizationId).Execute()
+	if err != nil {
+		diags.AddError(
+			"Error deleting Organization",
+			apiErrorDetail("Could not list the Applications of Organization "+organizationId, api_response, err),
+		)
+		return diags
+	}
+	for _, application := range applications.Applications {
+		if r.retained.contains(application.GetId()) {
+			diags.AddError(
+				"Organization contains a retained Application",
+				fmt.Sprintf("Application %s was kept in IQ Server because of its retain_on_destroy, so force_destroy must not delete it together with its Organization. "+
+					"Move the Application to another Organization, or set force_destroy to false.", application.GetPublicId()),
+			)
+		}
+	}
+	return diags
+}
+
+// deleteContents deletes all Applications and child Organizations of an Organization, deepest first.
+func (r *organizationResource) deleteContents(ctx context.Context, organizationId string, children map[string][]sonatypeiq.ApiOrganizationDTO) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for _, child := range children[organizationId] {
+		diags.Append(r.deleteContents(ctx, child.GetId(), children)...)
+		if diags.HasError() {
+			return diags
+		}
+
+		tflog.Info(ctx, "Deleting child Organization", map[string]interface{}{"organization": child.GetName()})
+		api_response, err := r.client.OrganizationsAPI.DeleteOrganization(ctx, child.GetId()).Execute()
+		if err != nil && !isNotFound(api_response) {
+			diags.AddError(
+				"Error deleting Organization",
+				apiErrorDetail("Could not delete child Organization "+child.GetName(), api_response, err),
+			)
+			return diags
+		}
+	}
+
+	applications, api_response, err := r.client.ApplicationsAPI.GetApplicationsByOrganizationId(ctx, organizationId).Execute()
+	if err != nil {
+		diags.AddError(
+			"Error deleting Organization",
+			apiErrorDetail("Could not list the Applications of Organization "+organizationId, api_response, err),
+		)
+		return diags
+	}
+	for _, application := range applications.Applications {
+		tflog.Info(ctx, "Deleting Application", map[string]interface{}{"application": application.GetPublicId()})
+		api_response, err := r.client.ApplicationsAPI.DeleteApplication(ctx, application.GetId()).Execute()
+		if err != nil && !isNotFound(api_response) {
+			diags.AddError(
+				"Error deleting Organization",
+				apiErrorDetail("Could not delete Application "+application.GetPublicId(), api_response, err),
+			)
+			return diags
+		}
+	}
+	return diags
+}
@@ -17,16 +17,21 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	sonatypeiq "github.com/sonatype-nexus-community/nexus-iq-api-client-go"
 )
 
 func TestAccOrganizationResource(t *testing.T) {
 
-	orgName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	orgName := testAccRandomName()
 
 	resource.Test(t, resource.TestCase{
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
@@ -39,9 +44,17 @@ func TestAccOrganizationResource(t *testing.T) {
 					resource.TestCheckResourceAttrSet("sonatypeiq_organization.org", "id"),
 					resource.TestCheckResourceAttr("sonatypeiq_organization.org", "name", orgName),
 					resource.TestCheckResourceAttr("sonatypeiq_organization.org", "parent_organization_id", "ROOT_ORGANIZATION_ID"),
+					resource.TestCheckResourceAttr("sonatypeiq_organization.org", "force_destroy", "false"),
 					resource.TestCheckResourceAttrSet("sonatypeiq_organization.org", "last_updated"),
 				),
 			},
+			// ImportState testing
+			{
+				ResourceName:            "sonatypeiq_organization.org",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
 			// // Update
 			// {
 			// 	Config: testAccSystemConfigResource(iqUrl, false),
@@ -69,3 +82,74 @@ resource "sonatypeiq_organization" "org" {
   parent_organization_id = data.sonatypeiq_organization.root.id
 }`, orgName)
 }
+
+func TestOrganizationResourceForceDestroyRetainedApplication(t *testing.T) {
+	const (
+		organizationId = "a3f4e5d6c7b8a9f0e1d2c3b4a5f6e7d8"
+		applicationId  = "c5b6a7f8e9d0c1b2a3f4e5d6c7b8a9f0"
+	)
+
+	for _, retained := range []bool{false, true} {
+		ctx := context.Background()
+		m := newMockIqServer(t)
+		m.organizations[organizationId] = sonatypeiq.ApiOrganizationDTO{
+			Id:                   sonatypeiq.PtrString(organizationId),
+			Name:                 sonatypeiq.PtrString("Sandbox Organization"),
+			ParentOrganizationId: sonatypeiq.PtrString("ROOT_ORGANIZATION_ID"),
+		}
+		m.applications[applicationId] = sonatypeiq.ApiApplicationDTO{
+			Id:             sonatypeiq.PtrString(applicationId),
+			PublicId:       sonatypeiq.PtrString("sandbox-application"),
+			Name:           sonatypeiq.PtrString("Sandbox Application"),
+			OrganizationId: sonatypeiq.PtrString(organizationId),
+		}
+		server, schemas := testProviderServer(t, m)
+
+		// Terraform destroys the Application before the Organization it belongs to
+		s := schemas["sonatypeiq_application"]
+		destroyed, err := server.ApplyResourceChange(ctx, &tfprotov6.ApplyResourceChangeRequest{
+			TypeName: "sonatypeiq_application",
+			PriorState: testDynamicValue(t, s, map[string]tftypes.Value{
+				"id":                  tftypes.NewValue(tftypes.String, applicationId),
+				"public_id":           tftypes.NewValue(tftypes.String, "sandbox-application"),
+				"organization_id":     tftypes.NewValue(tftypes.String, organizationId),
+				"deletion_protection": tftypes.NewValue(tftypes.Bool, false),
+				"retain_on_destroy":   tftypes.NewValue(tftypes.Bool, retained),
+			}, false),
+			PlannedState: testDynamicValue(t, s, nil, true),
+			Config:       testDynamicValue(t, s, nil, true),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkDiagnostics(t, "destroy of the Application", destroyed.Diagnostics, "")
+
+		s = schemas["sonatypeiq_organization"]
+		destroyed, err = server.ApplyResourceChange(ctx, &tfprotov6.ApplyResourceChangeRequest{
+			TypeName: "sonatypeiq_organization",
+			PriorState: testDynamicValue(t, s, map[string]tftypes.Value{
+				"id":                     tftypes.NewValue(tftypes.String, organizationId),
+				"name":                   tftypes.NewValue(tftypes.String, "Sandbox Organization"),
+				"parent_organization_id": tftypes.NewValue(tftypes.String, "ROOT_ORGANIZATION_ID"),
+				"force_destroy":          tftypes.NewValue(tftypes.Bool, true),
+			}, false),
+			PlannedState: testDynamicValue(t, s, nil, true),
+			Config:       testDynamicValue(t, s, nil, true),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if retained {
+			checkDiagnostics(t, "destroy of the Organization", destroyed.Diagnostics, "retain_on_destroy")
+			if m.received(http.MethodDelete, "/api/v2/applications/"+applicationId, "") || m.received(http.MethodDelete, "/api/v2/organizations/"+organizationId, "") {
+				t.Error("Expected force_destroy not to delete the retained Application or its Organization")
+			}
+		} else {
+			checkDiagnostics(t, "destroy of the Organization", destroyed.Diagnostics, "")
+			if !m.received(http.MethodDelete, "/api/v2/organizations/"+organizationId, "") {
+				t.Error("Expected the Organization to be deleted")
+			}
+		}
+	}
+}
@@ -19,13 +19,16 @@ package provider
 import (
 	"context"
 	"fmt"
-	"io"
 	"net/http"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	sonatypeiq "github.com/sonatype-nexus-community/nexus-iq-api-client-go"
@@ -37,11 +40,12 @@ type organizationRoleMembershipResource struct {
 }
 
 type organizationRoleMembershipModelResource struct {
-	ID             types.String `tfsdk:"id"`
-	RoleId         types.String `tfsdk:"role_id"`
-	OrganizationId types.String `tfsdk:"organization_id"`
-	UserName       types.String `tfsdk:"user_name"`
-	GroupName      types.String `tfsdk:"group_name"`
+	ID                   types.String `tfsdk:"id"`
+	RoleId               types.String `tfsdk:"role_id"`
+	OrganizationId       types.String `tfsdk:"organization_id"`
+	UserName             types.String `tfsdk:"user_name"`
+	GroupName            types.String `tfsdk:"group_name"`
+	IgnoreMemberNameCase types.Bool   `tfsdk:"ignore_member_name_case"`
 }
 
 // NewOrganizationRoleMembershipResource is a helper function to simplify the provider implementation.
@@ -57,26 +61,62 @@ func (r *organizationRoleMembershipResource) Metadata(_ context.Context, req res
 // Schema defines the schema for the resource.
 func (r *organizationRoleMembershipResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
 			},
 			"role_id": schema.StringAttribute{
 				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					roleIdValidator,
+				},
 			},
 			"organization_id": schema.StringAttribute{
 				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					organizationIdValidator,
+				},
 			},
 			"user_name": schema.StringAttribute{
 				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"group_name": schema.StringAttribute{
 				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"ignore_member_name_case": schema.BoolAttribute{
+				Description: "Whether user_name and group_name are matched case-insensitively, for realms such as LDAP and SAML that may return names with a different casing. Defaults to false",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
 			},
 		},
 	}
 }
 
+// ValidateConfig checks that the Role exists when validate_roles is enabled on the provider.
+func (r *organizationRoleMembershipResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var roleId types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("role_id"), &roleId)...)
+	if resp.Diagnostics.HasError() || roleId.IsNull() || roleId.IsUnknown() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.checkRoles(ctx, map[string]path.Path{roleId.ValueString(): path.Root("role_id")})...)
+}
+
 func (r *organizationRoleMembershipResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
 	return []resource.ConfigValidator{
 		resourcevalidator.ExactlyOneOf(
@@ -120,17 +160,19 @@ func (r *organizationRoleMembershipResource) Create(ctx context.Context, req res
 
 	// Call API
 	if err != nil {
-		error_body, _ := io.ReadAll(apiResponse.Body)
 		resp.Diagnostics.AddError(
 			"Error creating organization role membership",
-			"Could not create organization role membership, unexpected error: "+apiResponse.Status+": "+string(error_body),
+			apiErrorDetail("Could not create organization role membership", apiResponse, err),
 		)
 		return
 	}
 
 	// Map response body to schema and populate Computed attribute values.
 	// Because the organization role membership does not have an ID of its own, we create a synthetic one based on the provided attributes.
-	data.ID = types.StringValue(fmt.Sprintf("%s_%s_%s_%s", data.OrganizationId.ValueString(), data.RoleId.ValueString(), memberType, memberName))
+	data.ID = types.StringValue(formatEscapedId(data.OrganizationId.ValueString(), data.RoleId.ValueString(), memberType, memberName))
+
+	// IQ Server may not return the new entity straight away
+	resp.Diagnostics.Append(markCreated(ctx, resp.Private)...)
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, data)
@@ -157,23 +199,6 @@ func (r *organizationRoleMembershipResource) Read(ctx context.Context, req resou
 		r.auth,
 	)
 
-	// Get refreshed organization role membership from IQ
-	apiRequest := r.client.RoleMembershipsAPI.GetRoleMembershipsApplicationOrOrganization(ctx, "organization", data.OrganizationId.ValueString())
-	roleMemberships, apiResponse, err := r.client.RoleMembershipsAPI.GetRoleMembershipsApplicationOrOrganizationExecute(apiRequest)
-
-	// Check if we received a list of role mappings.
-	if err != nil {
-		if apiResponse.StatusCode == http.StatusNotFound {
-			resp.State.RemoveResource(ctx)
-		} else {
-			resp.Diagnostics.AddError(
-				"Error Reading IQ organization role membership",
-				"Could not read organization role membership with ID "+data.ID.ValueString()+": "+err.Error(),
-			)
-		}
-		return
-	}
-
 	// Determine the member type, which can be any of group or user.
 	// The resource validator makes sure that exactly one of these is configured.
 	var memberType, memberName string
@@ -185,16 +210,40 @@ func (r *organizationRoleMembershipResource) Read(ctx context.Context, req resou
 		memberName = data.UserName.ValueString()
 	}
 
-	// Find our organization role membership mapping
+	// Get refreshed organization role membership from IQ and find our organization role membership mapping
 	var organizationRoleMembership *sonatypeiq.ApiMemberDTO
-	for _, roleMembership := range roleMemberships.MemberMappings {
-		if *roleMembership.RoleId == data.RoleId.ValueString() {
-			for _, member := range roleMembership.Members {
-				if *member.Type == memberType && *member.UserOrGroupName == memberName && *member.OwnerType == "ORGANIZATION" && *member.OwnerId == data.OrganizationId.ValueString() {
-					organizationRoleMembership = &member
+	var apiResponse *http.Response
+	var err error
+	resp.Diagnostics.Append(readConsistently(ctx, req.Private, resp.Private, func(ctx context.Context) bool {
+		var roleMemberships *sonatypeiq.ApiRoleMemberMappingListDTO
+		roleMemberships, apiResponse, err = r.client.RoleMembershipsAPI.GetRoleMembershipsApplicationOrOrganization(ctx, "organization", data.OrganizationId.ValueString()).Execute()
+		if err != nil {
+			return isNotFound(apiResponse)
+		}
+
+		for _, roleMembership := range roleMemberships.MemberMappings {
+			if *roleMembership.RoleId == data.RoleId.ValueString() {
+				for _, member := range roleMembership.Members {
+					if *member.Type == memberType && memberNameMatches(member.GetUserOrGroupName(), memberName, data.IgnoreMemberNameCase.ValueBool()) && *member.OwnerType == "ORGANIZATION" && *member.OwnerId == data.OrganizationId.ValueString() {
+						organizationRoleMembership = &member
+					}
 				}
 			}
 		}
+		return organizationRoleMembership == nil
+	})...)
+
+	// Check if we received a list of role mappings.
+	if err != nil {
+		if isNotFound(apiResponse) {
+			resp.State.RemoveResource(ctx)
+		} else {
+			resp.Diagnostics.AddError(
+				"Error Reading IQ organization role membership",
+				apiErrorDetail("Could not read organization role membership with ID "+data.ID.ValueString(), apiResponse, err),
+			)
+		}
+		return
 	}
 
 	if organizationRoleMembership == nil {
@@ -209,55 +258,18 @@ func (r *organizationRoleMembershipResource) Read(ctx context.Context, req resou
 	}
 }
 
-// // Update updates the resource and sets the updated Terraform state on success.
-//
-//	func (r *organizationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-//		var plan organizationModelResource
-//		var state organizationModelResource
-//		resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
-//		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
-//		if resp.Diagnostics.HasError() {
-//			return
-//		}
-//
-//		// Make Update API Call
-//		ctx = context.WithValue(
-//			ctx,
-//			sonatypeiq.ContextBasicAuth,
-//			r.auth,
-//		)
-//		app_update_request := r.client.ApplicationsAPI.UpdateApplication(ctx, state.ID.ValueString())
-//		app_update_request = app_update_request.ApiApplicationDTO(sonatypeiq.ApiApplicationDTO{
-//			Name:            plan.Name.ValueStringPointer(),
-//			PublicId:        plan.PublicId.ValueStringPointer(),
-//			OrganizationId:  plan.OrganizationId.ValueStringPointer(),
-//			ContactUserName: plan.ContactUserName.ValueStringPointer(),
-//		})
-//
-//		organization, api_response, err := app_update_request.Execute()
-//
-//		// Call API
-//		if err != nil {
-//			error_body, _ := io.ReadAll(api_response.Body)
-//			resp.Diagnostics.AddError(
-//				"Error updating Application",
-//				"Could not update Application, unexpected error: "+api_response.Status+": "+string(error_body),
-//			)
-//			return
-//		}
-//
-//		// Map response body to schema and populate Computed attribute values
-//		plan.ID = types.StringValue(*organization.Id)
-//		plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
-//
-//		// Set state to fully populated data
-//		resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
-//		if resp.Diagnostics.HasError() {
-//			return
-//		}
-//
-// }
-//
+// Update updates the resource and sets the updated Terraform state on success. All attributes
+// that identify the organization role membership require replacement, so only options are updated.
+func (r *organizationRoleMembershipResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan organizationRoleMembershipModelResource
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *organizationRoleMembershipResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var data organizationRoleMembershipModelResource
@@ -287,11 +299,58 @@ func (r *organizationRoleMembershipResource) Delete(ctx context.Context, req res
 	apiRequest := r.client.RoleMembershipsAPI.RevokeRoleMembershipApplicationOrOrganization(ctx, "organization", data.OrganizationId.ValueString(), data.RoleId.ValueString(), memberType, memberName)
 	apiResponse, err := r.client.RoleMembershipsAPI.RevokeRoleMembershipApplicationOrOrganizationExecute(apiRequest)
 	if err != nil {
-		error_body, _ := io.ReadAll(apiResponse.Body)
 		resp.Diagnostics.AddError(
 			"Error deleting organization role membership",
-			"Could not delete organization role membership, unexpected error: "+apiResponse.Status+": "+string(error_body),
+			apiErrorDetail("Could not delete organization role membership", apiResponse, err),
 		)
 		return
 	}
 }
+
+// UpgradeState upgrades IDs created by earlier versions of the provider.
+func (r *organizationRoleMembershipResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: upgradeRoleMembershipIdV0("organization_id"),
+	}
+}
+
+// MoveState moves the resource from the Sonatype IQ community provider.
+func (r *organizationRoleMembershipResource) MoveState(_ context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		moveResourceState("sonatypeiq_organization_role_membership", func(state map[string]interface{}) {
+			upgradeRoleMembershipState(state, "organization_id")
+		}),
+	}
+}
+
+// ImportState imports the organization role membership by its ID of the form
+// <organization_id>/<role_id>/<user|group>/<user_name|group_name>, with each part URL-escaped. IDs of
+// the form <organization_id>_<role_id>_<user|group>_<user_name|group_name> are still accepted.
+func (r *organizationRoleMembershipResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts, err := parseEscapedId(req.ID, "organization_id", "role_id", "member_type", "member_name")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid organization role membership ID", err.Error())
+		return
+	}
+
+	var memberAttribute string
+	switch parts[2] {
+	case "user":
+		memberAttribute = "user_name"
+	case "group":
+		memberAttribute = "group_name"
+	default:
+		resp.Diagnostics.AddError(
+			"Invalid organization role membership ID",
+			fmt.Sprintf("The member type of ID %s must be either user or group, got: %s", req.ID, parts[2]),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), formatEscapedId(parts...))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("organization_id"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("role_id"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(memberAttribute), parts[3])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("ignore_member_name_case"), false)...)
+	resp.Diagnostics.Append(markCreated(ctx, resp.Private)...)
+}
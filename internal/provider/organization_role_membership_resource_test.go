@@ -18,19 +18,64 @@ package provider
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 func TestAccOrganizationRoleMembershipResource(t *testing.T) {
 
 	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccSandbox(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			// Create and Read testing
 			{
-				Config: fmt.Sprintf(providerConfig + `
+				Config: testAccOrganizationRoleMembershipResourceConfig(""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// Verify application role membership
+					resource.TestCheckResourceAttrSet("sonatypeiq_organization_role_membership.test", "id"),
+					resource.TestCheckResourceAttr("sonatypeiq_organization_role_membership.test", "user_name", "example2"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "sonatypeiq_organization_role_membership.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// ImportState testing with an ID created by earlier versions of the provider
+			{
+				ResourceName: "sonatypeiq_organization_role_membership.test",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					attributes := s.RootModule().Resources["sonatypeiq_organization_role_membership.test"].Primary.Attributes
+					return strings.Join([]string{attributes["organization_id"], attributes["role_id"], "user", attributes["user_name"]}, "_"), nil
+				},
+				ImportStateVerify: true,
+			},
+			// Update testing, options do not replace the membership
+			{
+				Config: testAccOrganizationRoleMembershipResourceConfig("ignore_member_name_case = true"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("sonatypeiq_organization_role_membership.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("sonatypeiq_organization_role_membership.test", "ignore_member_name_case", "true"),
+				),
+			},
+		},
+	})
+}
+
+func testAccOrganizationRoleMembershipResourceConfig(options string) string {
+	return fmt.Sprintf(providerConfig+`
         data "sonatypeiq_organization" "sandbox" {
           name = "Sandbox Organization"
         }
@@ -48,17 +93,36 @@ func TestAccOrganizationRoleMembershipResource(t *testing.T) {
         }
 
         resource "sonatypeiq_organization_role_membership" "test" {
-          role_id        = data.sonatypeiq_role.developer.id
+          role_id         = data.sonatypeiq_role.developer.id
           organization_id = data.sonatypeiq_organization.sandbox.id
-          user_name      = sonatypeiq_user.user.username
+          user_name       = sonatypeiq_user.user.username
+          %s
         }
+        `, options)
+}
 
-        `),
-				Check: resource.ComposeAggregateTestCheckFunc(
-					// Verify application role membership
-					resource.TestCheckResourceAttrSet("sonatypeiq_organization_role_membership.test", "id"),
-					resource.TestCheckResourceAttr("sonatypeiq_organization_role_membership.test", "user_name", "example2"),
-				),
+func TestAccOrganizationRoleMembershipResourceValidateRoles(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Unknown Roles are reported while planning
+			{
+				Config: `
+        provider "sonatypeiq" {
+          username       = ""
+          password       = ""
+          url            = ""
+          validate_roles = true
+        }
+
+        resource "sonatypeiq_organization_role_membership" "test" {
+          role_id         = "00000000000000000000000000000000"
+          organization_id = "ROOT_ORGANIZATION_ID"
+          user_name       = "example"
+        }
+        `,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("No Role with name or ID '00000000000000000000000000000000' exists"),
 			},
 		},
 	})
@@ -47,6 +47,8 @@ type organizationsDataSource struct {
 type organizationsDataSourceModel struct {
 	ID            types.String        `tfsdk:"id"`
 	Organizations []organizationModel `tfsdk:"organizations"`
+	MaxResults    types.Int64         `tfsdk:"max_results"`
+	TotalCount    types.Int64         `tfsdk:"total_count"`
 }
 
 type organizationModel struct {
@@ -76,6 +78,8 @@ func (d *organizationsDataSource) Schema(_ context.Context, _ datasource.SchemaR
 			"id": schema.StringAttribute{
 				Computed: true,
 			},
+			"max_results": maxResultsAttribute("Organizations"),
+			"total_count": totalCountAttribute("Organizations"),
 			"organizations": schema.ListNestedAttribute{
 				Description: "List of Organizations",
 				Computed:    true,
@@ -127,6 +131,10 @@ func (d *organizationsDataSource) Schema(_ context.Context, _ datasource.SchemaR
 // Read refreshes the Terraform state with the latest data.
 func (d *organizationsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var state organizationsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	ctx = context.WithValue(
 		ctx,
@@ -134,18 +142,19 @@ func (d *organizationsDataSource) Read(ctx context.Context, req datasource.ReadR
 		d.auth,
 	)
 
-	orgList, _, err := d.client.OrganizationsAPI.GetOrganizations(ctx).Execute()
+	orgList, api_response, err := d.client.OrganizationsAPI.GetOrganizations(ctx).Execute()
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read IQ Organizations",
-			err.Error(),
+			describeApiError(api_response, err),
 		)
 		return
 	}
 
 	tflog.Debug(ctx, fmt.Sprintf("Iterating %d Organizations", len(orgList.Organizations)))
 
-	for _, organization := range orgList.Organizations {
+	state.TotalCount = types.Int64Value(int64(len(orgList.Organizations)))
+	for _, organization := range limitResults(orgList.Organizations, state.MaxResults, "Organizations", &resp.Diagnostics) {
 		var parentOrgId = types.StringNull()
 		if organization.ParentOrganizationId != nil {
 			tflog.Debug(ctx, fmt.Sprintf("Parent Org Id is %s", *organization.ParentOrganizationId))
@@ -0,0 +1,177 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	sonatypeiq "github.com/sonatype-nexus-community/nexus-iq-api-client-go"
+)
+
+// policyWaiverRequestResource is the resource implementation.
+type policyWaiverRequestResource struct {
+	baseResource
+}
+
+type policyWaiverRequestModelResource struct {
+	ID                  types.String `tfsdk:"id"`
+	PolicyViolationId   types.String `tfsdk:"policy_violation_id"`
+	Comment             types.String `tfsdk:"comment"`
+	PolicyViolationLink types.String `tfsdk:"policy_violation_link"`
+	AddWaiverLink       types.String `tfsdk:"add_waiver_link"`
+}
+
+// NewPolicyWaiverRequestResource is a helper function to simplify the provider implementation.
+func NewPolicyWaiverRequestResource() resource.Resource {
+	return &policyWaiverRequestResource{}
+}
+
+// Metadata returns the resource type name.
+func (r *policyWaiverRequestResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_policy_waiver_request"
+}
+
+// Schema defines the schema for the resource.
+func (r *policyWaiverRequestResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Use this resource to request a waiver for a Policy Violation. IQ Server notifies the users that are allowed to waive the Policy Violation. " +
+			"A waiver request cannot be withdrawn, so destroying this resource only removes it from the Terraform state",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "ID of the waiver request, equal to the Policy Violation ID",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"policy_violation_id": schema.StringAttribute{
+				Description: "Internal ID of the Policy Violation to request a waiver for",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"comment": schema.StringAttribute{
+				Description: "Reason for the waiver request, included in the notification to the approvers",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"policy_violation_link": schema.StringAttribute{
+				Description: "Link to the Policy Violation included in the notification to the approvers",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"add_waiver_link": schema.StringAttribute{
+				Description: "Link the approvers can follow to add the waiver, included in the notification",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+// Create requests the waiver and sets the initial Terraform state.
+func (r *policyWaiverRequestResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan policyWaiverRequestModelResource
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = context.WithValue(
+		ctx,
+		sonatypeiq.ContextBasicAuth,
+		r.auth,
+	)
+
+	request := sonatypeiq.ApiRequestPolicyWaiverDTO{
+		Comment:             plan.Comment.ValueStringPointer(),
+		PolicyViolationLink: plan.PolicyViolationLink.ValueStringPointer(),
+		AddWaiverLink:       plan.AddWaiverLink.ValueStringPointer(),
+	}
+	apiResponse, err := r.client.PolicyWaiversAPI.RequestPolicyWaiver(ctx, plan.PolicyViolationId.ValueString()).ApiRequestPolicyWaiverDTO(request).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error requesting Policy Waiver",
+			apiErrorDetail("Could not request a waiver for Policy Violation "+plan.PolicyViolationId.ValueString(), apiResponse, err),
+		)
+		return
+	}
+
+	plan.ID = plan.PolicyViolationId
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data. IQ Server does not expose waiver
+// requests once they are sent, so only the Policy Violation itself is checked.
+func (r *policyWaiverRequestResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state policyWaiverRequestModelResource
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = context.WithValue(
+		ctx,
+		sonatypeiq.ContextBasicAuth,
+		r.auth,
+	)
+
+	_, apiResponse, err := r.client.PolicyViolationsAPI.GetApplicableWaivers(ctx, state.PolicyViolationId.ValueString()).Execute()
+	if err != nil {
+		if isNotFound(apiResponse) {
+			resp.State.RemoveResource(ctx)
+		} else {
+			resp.Diagnostics.AddError(
+				"Error Reading IQ Policy Waiver Request",
+				apiErrorDetail("Could not read Policy Violation "+state.PolicyViolationId.ValueString(), apiResponse, err),
+			)
+		}
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update is never called, as changing any attribute requests a new waiver.
+func (r *policyWaiverRequestResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan policyWaiverRequestModelResource
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete removes the waiver request from the Terraform state, as IQ Server does not support
+// withdrawing it.
+func (r *policyWaiverRequestResource) Delete(context.Context, resource.DeleteRequest, *resource.DeleteResponse) {
+}
@@ -0,0 +1,383 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	sonatypeiq "github.com/sonatype-nexus-community/nexus-iq-api-client-go"
+)
+
+// policyWaiverMatcherStrategies are the strategies IQ Server uses to match Policy Violations
+// against a Policy Waiver:
+//   - DEFAULT: the component of the Policy Violation, or its hash for unknown components
+//   - EXACT_COMPONENT: the exact component (package URL including version) of the Policy Violation
+//   - ALL_VERSIONS: every version of the component of the Policy Violation
+//   - ALL_COMPONENTS: every component that violates the Policy
+var policyWaiverMatcherStrategies = []string{"DEFAULT", "EXACT_COMPONENT", "ALL_VERSIONS", "ALL_COMPONENTS"}
+
+// policyWaiverResource is the resource implementation.
+type policyWaiverResource struct {
+	baseResource
+}
+
+type policyWaiverModelResource struct {
+	ID                   types.String `tfsdk:"id"`
+	OrganizationId       types.String `tfsdk:"organization_id"`
+	ApplicationId        types.String `tfsdk:"application_id"`
+	PolicyViolationId    types.String `tfsdk:"policy_violation_id"`
+	MatcherStrategy      types.String `tfsdk:"matcher_strategy"`
+	Comment              types.String `tfsdk:"comment"`
+	ExpiryTime           types.String `tfsdk:"expiry_time"`
+	PolicyId             types.String `tfsdk:"policy_id"`
+	PolicyName           types.String `tfsdk:"policy_name"`
+	AssociatedPackageUrl types.String `tfsdk:"associated_package_url"`
+}
+
+// NewPolicyWaiverResource is a helper function to simplify the provider implementation.
+func NewPolicyWaiverResource() resource.Resource {
+	return &policyWaiverResource{}
+}
+
+// Metadata returns the resource type name.
+func (r *policyWaiverResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_policy_waiver"
+}
+
+// Schema defines the schema for the resource.
+func (r *policyWaiverResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Use this resource to waive a Policy Violation, and optionally other violations of the same Policy, for an Organization or Application",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Internal ID of the Policy Waiver",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"organization_id": schema.StringAttribute{
+				Description: "Internal ID of the Organization the Policy Waiver applies to - use 'ROOT_ORGANIZATION_ID' for the Root Organization",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					organizationIdValidator,
+				},
+			},
+			"application_id": schema.StringAttribute{
+				Description: "Internal ID or public ID of the Application the Policy Waiver applies to",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"policy_violation_id": schema.StringAttribute{
+				Description: "Internal ID of the Policy Violation to waive",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"matcher_strategy": schema.StringAttribute{
+				Description: "Which violations of the Policy are waived, one of " + strings.Join(policyWaiverMatcherStrategies, ", ") + ". " +
+					"DEFAULT waives the component of the Policy Violation (matched by hash when it is unknown), EXACT_COMPONENT the exact component version, " +
+					"ALL_VERSIONS every version of the component and ALL_COMPONENTS every component. Defaults to DEFAULT",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("DEFAULT"),
+				Validators: []validator.String{
+					stringvalidator.OneOf(policyWaiverMatcherStrategies...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"comment": schema.StringAttribute{
+				Description: "Reason for the Policy Waiver",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"expiry_time": schema.StringAttribute{
+				Description: "Time the Policy Waiver expires (RFC3339) - the Policy Waiver does not expire when not set",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"policy_id": schema.StringAttribute{
+				Description: "Internal ID of the waived Policy",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"policy_name": schema.StringAttribute{
+				Description: "Name of the waived Policy",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"associated_package_url": schema.StringAttribute{
+				Description: "Package URL the Policy Waiver matches - null when it applies to all components",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *policyWaiverResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("organization_id"),
+			path.MatchRoot("application_id"),
+		),
+	}
+}
+
+// ValidateConfig checks that the expiry time is a valid timestamp.
+func (r *policyWaiverResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config policyWaiverModelResource
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() || config.ExpiryTime.IsNull() || config.ExpiryTime.IsUnknown() {
+		return
+	}
+
+	if _, err := time.Parse(time.RFC3339, config.ExpiryTime.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("expiry_time"),
+			"Invalid Expiry Time",
+			fmt.Sprintf("expiry_time must be an RFC3339 timestamp, e.g. 2030-01-01T00:00:00Z: %s", err),
+		)
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *policyWaiverResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan policyWaiverModelResource
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = context.WithValue(
+		ctx,
+		sonatypeiq.ContextBasicAuth,
+		r.auth,
+	)
+
+	ownerType, ownerId, diags := r.resolveOwner(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	options := sonatypeiq.ApiWaiverOptionsDTO{
+		Comment:         plan.Comment.ValueStringPointer(),
+		MatcherStrategy: plan.MatcherStrategy.ValueStringPointer(),
+	}
+	if plan.MatcherStrategy.ValueString() == "ALL_COMPONENTS" {
+		applyToAllComponents := true
+		options.ApplyToAllComponents = &applyToAllComponents
+	}
+	if !plan.ExpiryTime.IsNull() {
+		expiryTime, _ := time.Parse(time.RFC3339, plan.ExpiryTime.ValueString())
+		options.ExpiryTime = &expiryTime
+	}
+
+	apiResponse, err := r.client.PolicyWaiversAPI.AddPolicyWaiverByPolicyViolationId(ctx, ownerType, ownerId, plan.PolicyViolationId.ValueString()).ApiWaiverOptionsDTO(options).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating Policy Waiver",
+			apiErrorDetail("Could not waive Policy Violation "+plan.PolicyViolationId.ValueString(), apiResponse, err),
+		)
+		return
+	}
+
+	// IQ Server does not return the new Policy Waiver, so look up the most recent one of the owner
+	// for the Policy Violation.
+	waivers, apiResponse, err := r.client.PolicyWaiversAPI.GetPolicyWaivers(ctx, ownerType, ownerId).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating Policy Waiver",
+			apiErrorDetail(fmt.Sprintf("Could not read Policy Waivers of %s %s", ownerType, ownerId), apiResponse, err),
+		)
+		return
+	}
+	var waiver *sonatypeiq.ApiPolicyWaiverDTO
+	for i, candidate := range waivers {
+		if candidate.GetPolicyViolationId() != plan.PolicyViolationId.ValueString() || candidate.GetMatcherStrategy() != plan.MatcherStrategy.ValueString() {
+			continue
+		}
+		if waiver == nil || candidate.GetCreateTime().After(waiver.GetCreateTime()) {
+			waiver = &waivers[i]
+		}
+	}
+	if waiver == nil {
+		resp.Diagnostics.AddError(
+			"Error creating Policy Waiver",
+			fmt.Sprintf("Policy Violation %s was waived, but the Policy Waiver could not be found for %s %s", plan.PolicyViolationId.ValueString(), ownerType, ownerId),
+		)
+		return
+	}
+
+	plan.ID = types.StringPointerValue(waiver.PolicyWaiverId)
+	plan.updateComputed(waiver)
+
+	// IQ Server may not return the new entity straight away
+	resp.Diagnostics.Append(markCreated(ctx, resp.Private)...)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *policyWaiverResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state policyWaiverModelResource
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = context.WithValue(
+		ctx,
+		sonatypeiq.ContextBasicAuth,
+		r.auth,
+	)
+
+	ownerType, ownerId, diags := r.resolveOwner(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var waiver *sonatypeiq.ApiPolicyWaiverDTO
+	var apiResponse *http.Response
+	var err error
+	resp.Diagnostics.Append(readConsistently(ctx, req.Private, resp.Private, func(ctx context.Context) bool {
+		waiver, apiResponse, err = r.client.PolicyWaiversAPI.GetPolicyWaiver(ctx, ownerType, ownerId, state.ID.ValueString()).Execute()
+		return err != nil && isNotFound(apiResponse)
+	})...)
+	if err != nil {
+		if isNotFound(apiResponse) {
+			resp.State.RemoveResource(ctx)
+		} else {
+			resp.Diagnostics.AddError(
+				"Error Reading IQ Policy Waiver",
+				apiErrorDetail("Could not read Policy Waiver with ID "+state.ID.ValueString(), apiResponse, err),
+			)
+		}
+		return
+	}
+
+	if waiver.MatcherStrategy != nil {
+		state.MatcherStrategy = types.StringPointerValue(waiver.MatcherStrategy)
+	}
+	state.Comment = types.StringPointerValue(waiver.Comment)
+	if waiver.Comment != nil && len(*waiver.Comment) == 0 {
+		state.Comment = types.StringNull()
+	}
+	// Keep the configured representation of the expiry time when it denotes the same instant.
+	configuredExpiryTime, parseErr := time.Parse(time.RFC3339, state.ExpiryTime.ValueString())
+	if waiver.ExpiryTime == nil {
+		state.ExpiryTime = types.StringNull()
+	} else if parseErr != nil || !configuredExpiryTime.Equal(*waiver.ExpiryTime) {
+		state.ExpiryTime = types.StringValue(waiver.ExpiryTime.Format(time.RFC3339))
+	}
+	state.updateComputed(waiver)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update is never called, as changing any attribute replaces the Policy Waiver.
+func (r *policyWaiverResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan policyWaiverModelResource
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *policyWaiverResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state policyWaiverModelResource
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = context.WithValue(
+		ctx,
+		sonatypeiq.ContextBasicAuth,
+		r.auth,
+	)
+
+	ownerType, ownerId, diags := r.resolveOwner(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiResponse, err := r.client.PolicyWaiversAPI.DeletePolicyWaiver(ctx, ownerType, ownerId, state.ID.ValueString()).Execute()
+	if err != nil && !isNotFound(apiResponse) {
+		resp.Diagnostics.AddError(
+			"Error deleting Policy Waiver",
+			apiErrorDetail("Could not delete Policy Waiver with ID "+state.ID.ValueString(), apiResponse, err),
+		)
+	}
+}
+
+// resolveOwner returns the type and internal ID of the Organization or Application the Policy
+// Waiver belongs to. The config validator makes sure that exactly one of these is configured.
+func (r *policyWaiverResource) resolveOwner(ctx context.Context, m *policyWaiverModelResource) (string, string, diag.Diagnostics) {
+	if m.ApplicationId.IsNull() {
+		return "organization", m.OrganizationId.ValueString(), nil
+	}
+	applicationId, diags := resolveApplicationId(ctx, r.client, m.ApplicationId.ValueString())
+	return "application", applicationId, diags
+}
+
+// updateComputed sets the read-only attributes of the model from the Policy Waiver.
+func (m *policyWaiverModelResource) updateComputed(waiver *sonatypeiq.ApiPolicyWaiverDTO) {
+	m.PolicyId = types.StringPointerValue(waiver.PolicyId)
+	m.PolicyName = types.StringPointerValue(waiver.PolicyName)
+	m.AssociatedPackageUrl = types.StringPointerValue(waiver.AssociatedPackageUrl)
+}
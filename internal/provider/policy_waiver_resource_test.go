@@ -0,0 +1,60 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccPolicyWaiverResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Only known matcher strategies are accepted
+			{
+				Config:      testAccPolicyWaiverResourceConfig("ALL_PURLS", "2030-01-01T00:00:00Z"),
+				ExpectError: regexp.MustCompile("Invalid Attribute Value Match"),
+			},
+			// The expiry time must be a timestamp
+			{
+				Config:      testAccPolicyWaiverResourceConfig("ALL_VERSIONS", "2030-01-01"),
+				ExpectError: regexp.MustCompile("expiry_time must be an RFC3339 timestamp"),
+			},
+			// Waiving a Policy Violation that does not exist
+			{
+				Config:      testAccPolicyWaiverResourceConfig("ALL_COMPONENTS", "2030-01-01T00:00:00Z"),
+				ExpectError: regexp.MustCompile("Could not waive Policy Violation"),
+			},
+		},
+	})
+}
+
+func testAccPolicyWaiverResourceConfig(matcherStrategy string, expiryTime string) string {
+	return fmt.Sprintf(providerConfig+`
+resource "sonatypeiq_policy_waiver" "test" {
+  organization_id     = "ROOT_ORGANIZATION_ID"
+  policy_violation_id = "does-not-exist"
+  matcher_strategy    = %q
+  comment             = "Accepted risk"
+  expiry_time         = %q
+}
+`, matcherStrategy, expiryTime)
+}
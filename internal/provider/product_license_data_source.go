@@ -0,0 +1,182 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	sonatypeiq "github.com/sonatype-nexus-community/nexus-iq-api-client-go"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &productLicenseDataSource{}
+	_ datasource.DataSourceWithConfigure = &productLicenseDataSource{}
+)
+
+// ProductLicenseDataSource is a helper function to simplify the provider implementation.
+func ProductLicenseDataSource() datasource.DataSource {
+	return &productLicenseDataSource{}
+}
+
+// productLicenseDataSource is the data source implementation.
+type productLicenseDataSource struct {
+	baseDataSource
+}
+
+type productLicenseDataSourceModel struct {
+	ID                 types.String `tfsdk:"id"`
+	ExpiryDate         types.String `tfsdk:"expiry_date"`
+	DaysRemaining      types.Int64  `tfsdk:"days_remaining"`
+	Valid              types.Bool   `tfsdk:"valid"`
+	LicensedUsers      types.Int64  `tfsdk:"licensed_users"`
+	Products           types.Set    `tfsdk:"products"`
+	LifecycleEnabled   types.Bool   `tfsdk:"lifecycle_enabled"`
+	FirewallEnabled    types.Bool   `tfsdk:"firewall_enabled"`
+	SbomManagerEnabled types.Bool   `tfsdk:"sbom_manager_enabled"`
+}
+
+// productLicenseDTO is the product license of IQ Server.
+type productLicenseDTO struct {
+	ExpiryTimestamp *int64   `json:"expiryTimestamp,omitempty"`
+	LicensedUsers   *int64   `json:"licensedUsers,omitempty"`
+	Products        []string `json:"products,omitempty"`
+}
+
+// licensed reports whether any of the licensed products matches one of the given names, ignoring
+// case, as IQ Server reports products by their (former) names, e.g. "clm" or "Lifecycle".
+func (l productLicenseDTO) licensed(names ...string) bool {
+	for _, product := range l.Products {
+		for _, name := range names {
+			if strings.Contains(strings.ToLower(product), name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Metadata returns the data source type name.
+func (d *productLicenseDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_product_license"
+}
+
+// Schema defines the schema for the data source.
+func (d *productLicenseDataSource) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Use this data source to get the product license installed on Sonatype IQ Server, e.g. to only manage Firewall resources when Firewall is licensed",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"expiry_date": schema.StringAttribute{
+				Description: "Date and time (RFC 3339) the license expires - null when the license does not expire",
+				Computed:    true,
+			},
+			"days_remaining": schema.Int64Attribute{
+				Description: "Number of days until the license expires, negative once it has expired - null when the license does not expire",
+				Computed:    true,
+			},
+			"valid": schema.BoolAttribute{
+				Description: "Whether the license has not expired",
+				Computed:    true,
+			},
+			"licensed_users": schema.Int64Attribute{
+				Description: "Number of users the license is for - null when not limited",
+				Computed:    true,
+			},
+			"products": schema.SetAttribute{
+				Description: "Products the license enables, as reported by Sonatype IQ Server",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"lifecycle_enabled": schema.BoolAttribute{
+				Description: "Whether Sonatype Lifecycle is licensed",
+				Computed:    true,
+			},
+			"firewall_enabled": schema.BoolAttribute{
+				Description: "Whether Sonatype Repository Firewall is licensed",
+				Computed:    true,
+			},
+			"sbom_manager_enabled": schema.BoolAttribute{
+				Description: "Whether Sonatype SBOM Manager is licensed",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *productLicenseDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data productLicenseDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = context.WithValue(
+		ctx,
+		sonatypeiq.ContextBasicAuth,
+		d.auth,
+	)
+
+	var license productLicenseDTO
+	if apiResponse, err := callIqApi(ctx, d.client, http.MethodGet, "/rest/product/license", nil, &license); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read IQ Product License",
+			describeApiError(apiResponse, err),
+		)
+		return
+	}
+
+	data.ID = types.StringValue("product_license")
+	data.ExpiryDate = types.StringNull()
+	data.DaysRemaining = types.Int64Null()
+	data.Valid = types.BoolValue(true)
+	if license.ExpiryTimestamp != nil {
+		expiry := time.UnixMilli(*license.ExpiryTimestamp)
+		remaining := time.Until(expiry)
+		data.ExpiryDate = types.StringValue(expiry.UTC().Format(time.RFC3339))
+		data.DaysRemaining = types.Int64Value(int64(remaining / (24 * time.Hour)))
+		data.Valid = types.BoolValue(remaining > 0)
+	}
+	data.LicensedUsers = types.Int64PointerValue(license.LicensedUsers)
+
+	products := license.Products
+	if products == nil {
+		products = []string{}
+	}
+	var diags diag.Diagnostics
+	data.Products, diags = types.SetValueFrom(ctx, types.StringType, products)
+	resp.Diagnostics.Append(diags...)
+	data.LifecycleEnabled = types.BoolValue(license.licensed("lifecycle", "clm"))
+	data.FirewallEnabled = types.BoolValue(license.licensed("firewall"))
+	data.SbomManagerEnabled = types.BoolValue(license.licensed("sbom"))
+
+	// Set state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
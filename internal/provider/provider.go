@@ -18,21 +18,31 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"net/url"
 	"os"
+	"regexp"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	sonatypeiq "github.com/sonatype-nexus-community/nexus-iq-api-client-go"
 )
 
 // Ensure SonatypeIqProvider satisfies various provider interfaces.
-var _ provider.Provider = &SonatypeIqProvider{}
+var (
+	_ provider.Provider              = &SonatypeIqProvider{}
+	_ provider.ProviderWithFunctions = &SonatypeIqProvider{}
+)
 
 // SonatypeIqProvider defines the provider implementation.
 type SonatypeIqProvider struct {
@@ -44,9 +54,50 @@ type SonatypeIqProvider struct {
 
 // SonatypeIqProviderModel describes the provider data model.
 type SonatypeIqProviderModel struct {
-	Url      types.String `tfsdk:"url"`
-	Username types.String `tfsdk:"username"`
-	Password types.String `tfsdk:"password"`
+	Url          types.String `tfsdk:"url"`
+	FailoverUrls types.List   `tfsdk:"failover_urls"`
+	Username     types.String `tfsdk:"username"`
+	Password     types.String `tfsdk:"password"`
+	UserCode     types.String `tfsdk:"user_code"`
+	PassCode     types.String `tfsdk:"pass_code"`
+
+	AuthMode   types.String `tfsdk:"auth_mode"`
+	Token      types.String `tfsdk:"token"`
+	AuthHeader types.String `tfsdk:"auth_header"`
+
+	CredentialsFile types.String `tfsdk:"credentials_file"`
+	Profile         types.String `tfsdk:"profile"`
+
+	CaCertFile         types.String `tfsdk:"ca_cert_file"`
+	CaCertPem          types.String `tfsdk:"ca_cert_pem"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+	ClientCert         types.String `tfsdk:"client_cert"`
+	ClientKey          types.String `tfsdk:"client_key"`
+	ProxyUrl           types.String `tfsdk:"proxy_url"`
+
+	MaxRetries   types.Int64 `tfsdk:"max_retries"`
+	RetryWaitMin types.Int64 `tfsdk:"retry_wait_min"`
+	RetryWaitMax types.Int64 `tfsdk:"retry_wait_max"`
+
+	RequestTimeout types.Int64 `tfsdk:"request_timeout"`
+
+	MaxConcurrentRequests types.Int64   `tfsdk:"max_concurrent_requests"`
+	RequestsPerSecond     types.Float64 `tfsdk:"requests_per_second"`
+
+	AdditionalHeaders types.Map `tfsdk:"additional_headers"`
+
+	SkipConnectivityCheck types.Bool   `tfsdk:"skip_connectivity_check"`
+	MinimumServerVersion  types.String `tfsdk:"minimum_server_version"`
+
+	DefaultOrganizationId types.String `tfsdk:"default_organization_id"`
+
+	CacheLookups types.Bool `tfsdk:"cache_lookups"`
+
+	ValidateRoles types.Bool `tfsdk:"validate_roles"`
+
+	ReadOnly     types.Bool   `tfsdk:"read_only"`
+	AuditLogFile types.String `tfsdk:"audit_log_file"`
+	MetricsFile  types.String `tfsdk:"metrics_file"`
 }
 
 func (p *SonatypeIqProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -58,17 +109,186 @@ func (p *SonatypeIqProvider) Schema(ctx context.Context, req provider.SchemaRequ
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"url": schema.StringAttribute{
-				MarkdownDescription: "Sonatype IQ Server URL",
-				Required:            true,
+				MarkdownDescription: "Sonatype IQ Server URL - may also be set using the `SONATYPEIQ_URL` environment variable",
+				Optional:            true,
+			},
+			"failover_urls": schema.ListAttribute{
+				MarkdownDescription: "URLs of further Sonatype IQ Server instances, e.g. a standby behind another load balancer, to fail over to in order when the instance at `url` cannot be connected to. Requests keep going to the instance failed over to",
+				ElementType:         types.StringType,
+				Optional:            true,
 			},
 			"username": schema.StringAttribute{
-				MarkdownDescription: "Administrator Username for Sonatype IQ Server",
-				Required:            true,
+				MarkdownDescription: "Administrator Username for Sonatype IQ Server - may also be set using the `SONATYPEIQ_USERNAME` environment variable",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("user_code")),
+				},
 			},
 			"password": schema.StringAttribute{
-				MarkdownDescription: "Password for your Administrator user for Sonatype IQ Server",
-				Required:            true,
+				MarkdownDescription: "Password for your Administrator user for Sonatype IQ Server - may also be set using the `SONATYPEIQ_PASSWORD` environment variable",
+				Optional:            true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("pass_code")),
+				},
+			},
+			"user_code": schema.StringAttribute{
+				MarkdownDescription: "User Code of a Sonatype IQ Server User Token - use instead of `username`. May also be set using the `SONATYPEIQ_USER_CODE` environment variable",
+				Optional:            true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("pass_code")),
+				},
+			},
+			"pass_code": schema.StringAttribute{
+				MarkdownDescription: "Pass Code of a Sonatype IQ Server User Token - use instead of `password`. May also be set using the `SONATYPEIQ_PASS_CODE` environment variable",
+				Optional:            true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("user_code")),
+				},
+			},
+			"auth_mode": schema.StringAttribute{
+				MarkdownDescription: "How requests are authenticated: `basic` authentication with a username and password or User Token, a `bearer` token, or a token in a custom `header`, e.g. when Sonatype IQ Server is behind an authenticating proxy. May also be set using the `SONATYPEIQ_AUTH_MODE` environment variable. Defaults to `basic`",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(authModeBasic, authModeBearer, authModeHeader),
+				},
+			},
+			"token": schema.StringAttribute{
+				MarkdownDescription: "Token to authenticate with when `auth_mode` is `bearer` or `header` - may also be set using the `SONATYPEIQ_TOKEN` environment variable",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"auth_header": schema.StringAttribute{
+				MarkdownDescription: "Name of the HTTP header, e.g. `X-Auth-Token`, that the `token` is sent in when `auth_mode` is `header`",
+				Optional:            true,
+			},
+			"credentials_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a JSON file of named profiles, each holding any of `url`, `username`, `password`, `user_code`, `pass_code`, `auth_mode`, `token` and `auth_header`. Profile values are used for attributes that are neither configured nor set in the environment. May also be set using the `SONATYPEIQ_CREDENTIALS_FILE` environment variable. Defaults to `~/.sonatypeiq/credentials.json`, which is only read when it exists",
+				Optional:            true,
+			},
+			"profile": schema.StringAttribute{
+				MarkdownDescription: "Name of the profile in the `credentials_file` to use - may also be set using the `SONATYPEIQ_PROFILE` environment variable. Defaults to `default`",
+				Optional:            true,
+			},
+			"ca_cert_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a PEM encoded CA Certificate (bundle) to trust in addition to the system trust store",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("ca_cert_pem")),
+				},
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded CA Certificate (bundle) to trust in addition to the system trust store",
+				Optional:            true,
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				MarkdownDescription: "Skip verification of the TLS certificate presented by Sonatype IQ Server. **Only** use this in lab environments",
+				Optional:            true,
+			},
+			"client_cert": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded Client Certificate to present when Sonatype IQ Server is behind a mutual TLS gateway",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_key")),
+				},
+			},
+			"client_key": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded private key for the `client_cert`",
+				Optional:            true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_cert")),
+				},
+			},
+			"proxy_url": schema.StringAttribute{
+				MarkdownDescription: "URL of the HTTP(S) proxy to reach Sonatype IQ Server through. When not set, the `HTTPS_PROXY`, `HTTP_PROXY` and `NO_PROXY` environment variables are honored",
+				Optional:            true,
+			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of times a request is retried when IQ Server is unavailable, throttles requests (HTTP 429) or the connection is reset. Requests that change IQ Server are only retried when they cannot have been processed, i.e. when the connection could not be established or IQ Server responded with a 429 or 503. Defaults to `3`, set to `0` to disable retries",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"retry_wait_min": schema.Int64Attribute{
+				MarkdownDescription: "Minimum time in seconds to wait before retrying a request. Defaults to `1`",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"retry_wait_max": schema.Int64Attribute{
+				MarkdownDescription: "Maximum time in seconds to wait before retrying a request. Defaults to `30`",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"request_timeout": schema.Int64Attribute{
+				MarkdownDescription: "Time in seconds a single request to Sonatype IQ Server may take, including reading the response. Defaults to `300` - report and evaluation endpoints can be slow for large Applications",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of requests sent to Sonatype IQ Server concurrently. Defaults to `0` (unlimited) - use this to protect smaller IQ Server instances when refreshing many resources",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"requests_per_second": schema.Float64Attribute{
+				MarkdownDescription: "Maximum number of requests sent to Sonatype IQ Server per second. Defaults to `0` (unlimited)",
+				Optional:            true,
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0),
+				},
+			},
+			"additional_headers": schema.MapAttribute{
+				MarkdownDescription: "Additional HTTP headers sent with every request to Sonatype IQ Server, e.g. to route or authorize requests through an API gateway",
+				Optional:            true,
 				Sensitive:           true,
+				ElementType:         types.StringType,
+			},
+			"default_organization_id": schema.StringAttribute{
+				MarkdownDescription: "Internal ID of the Organization that `sonatypeiq_application` and `sonatypeiq_source_control` resources belong to when they do not configure an `organization_id` (or an `application_id` for Source Control) - may also be set using the `SONATYPEIQ_DEFAULT_ORGANIZATION_ID` environment variable",
+				Optional:            true,
+			},
+			"cache_lookups": schema.BoolAttribute{
+				MarkdownDescription: "Share the responses of identical lookups, such as many `sonatypeiq_organization` data sources or the role memberships of an owner with many role membership resources, within a single Terraform operation. Any change made to Sonatype IQ Server clears the cache; cleared responses that Sonatype IQ Server supplied an `ETag` or `Last-Modified` header for are revalidated with a conditional request rather than transferred again. Identical lookups sent at the same time share a single request even when this is disabled. Defaults to `true`",
+				Optional:            true,
+			},
+			"validate_roles": schema.BoolAttribute{
+				MarkdownDescription: "Check while planning that the Roles configured on role membership resources exist on Sonatype IQ Server, so that typos are reported before anything is applied. Defaults to `false`",
+				Optional:            true,
+			},
+			"read_only": schema.BoolAttribute{
+				MarkdownDescription: "Refuse to send any request that could change Sonatype IQ Server, so that e.g. drift detection can safely target a production server: reading and planning work as usual, while applying a create, update or delete fails before anything is changed. Data sources keep working, including those that query Sonatype IQ Server with a POST, such as `sonatypeiq_success_metrics` and `sonatypeiq_manifest_evaluation`. Defaults to `false`",
+				Optional:            true,
+			},
+			"audit_log_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a file that every request that changes Sonatype IQ Server is appended to as a line of JSON, with the time, the user or kind of token the request was sent as, the method and URL, the response status and the SHA-256 digest of the payload. Payloads themselves are not recorded",
+				Optional:            true,
+			},
+			"metrics_file": schema.StringAttribute{
+				MarkdownDescription: "Path to write metrics of the requests sent to Sonatype IQ Server to in the Prometheus text format when Terraform is done with the provider, e.g. for the textfile collector of the node exporter: the number of requests and the time spent per endpoint, the number of retries and the total wall time. Terraform starts the provider separately for planning and for applying changes, and the file holds the metrics of the last of them",
+				Optional:            true,
+			},
+			"skip_connectivity_check": schema.BoolAttribute{
+				MarkdownDescription: "Skip verifying that Sonatype IQ Server can be reached with the configured credentials when the provider is configured. Defaults to `false`",
+				Optional:            true,
+			},
+			"minimum_server_version": schema.StringAttribute{
+				MarkdownDescription: "Minimum Sonatype IQ Server version (e.g. `1.170.0`) the configuration requires - the provider fails to configure against older servers",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^\d+(\.\d+)*(-\d+)?$`), "must be a version such as 1.170.0"),
+					stringvalidator.ConflictsWith(path.MatchRoot("skip_connectivity_check")),
+				},
 			},
 		},
 	}
@@ -85,21 +305,73 @@ func (p *SonatypeIqProvider) Configure(ctx context.Context, req provider.Configu
 		return
 	}
 
-	iqUrl := os.Getenv("IQ_SERVER_URL")
-	username := os.Getenv("IQ_SERVER_USERNAME")
-	password := os.Getenv("IQ_SERVER_PASSWORD")
+	// The provider configuration may depend on resources that have not been created yet, e.g.
+	// when IQ Server itself is deployed in the same run. Let Terraform defer everything that
+	// depends on this provider until the configuration is known.
+	if !req.Config.Raw.IsFullyKnown() && req.ClientCapabilities.DeferralAllowed {
+		resp.Deferred = &provider.Deferred{
+			Reason: provider.DeferredReasonProviderConfigUnknown,
+		}
+		return
+	}
+
+	profile, diags := loadCredentialsProfile(config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Environment variables act as fallbacks for any attribute not set in the configuration, and
+	// the profile of the credentials file for any attribute not set in the environment either
+	iqUrl := firstNonEmpty(envDefault("SONATYPEIQ_URL", "IQ_SERVER_URL"), profile.Url)
+	username := firstNonEmpty(envDefault("SONATYPEIQ_USERNAME", "IQ_SERVER_USERNAME"), profile.Username)
+	password := firstNonEmpty(envDefault("SONATYPEIQ_PASSWORD", "IQ_SERVER_PASSWORD"), profile.Password)
+	userCode := firstNonEmpty(envDefault("SONATYPEIQ_USER_CODE"), profile.UserCode)
+	passCode := firstNonEmpty(envDefault("SONATYPEIQ_PASS_CODE"), profile.PassCode)
+	authMode := firstNonEmpty(envDefault("SONATYPEIQ_AUTH_MODE"), profile.AuthMode)
+	token := firstNonEmpty(envDefault("SONATYPEIQ_TOKEN"), profile.Token)
+	authHeader := profile.AuthHeader
+	defaultOrganizationId := envDefault("SONATYPEIQ_DEFAULT_ORGANIZATION_ID")
 
 	if !config.Url.IsNull() && len(config.Url.ValueString()) > 0 {
-		print("setting URL")
 		iqUrl = config.Url.ValueString()
 	}
 
 	if !config.Username.IsNull() && len(config.Username.ValueString()) > 0 {
 		username = config.Username.ValueString()
+		userCode, passCode = "", ""
 	}
 
 	if !config.Password.IsNull() && len(config.Password.ValueString()) > 0 {
 		password = config.Password.ValueString()
+		userCode, passCode = "", ""
+	}
+
+	if !config.UserCode.IsNull() && len(config.UserCode.ValueString()) > 0 {
+		userCode = config.UserCode.ValueString()
+	}
+
+	if !config.PassCode.IsNull() && len(config.PassCode.ValueString()) > 0 {
+		passCode = config.PassCode.ValueString()
+	}
+
+	if !config.AuthMode.IsNull() && len(config.AuthMode.ValueString()) > 0 {
+		authMode = config.AuthMode.ValueString()
+	}
+	if len(authMode) == 0 {
+		authMode = authModeBasic
+	}
+
+	if !config.Token.IsNull() && len(config.Token.ValueString()) > 0 {
+		token = config.Token.ValueString()
+	}
+
+	if !config.AuthHeader.IsNull() && len(config.AuthHeader.ValueString()) > 0 {
+		authHeader = config.AuthHeader.ValueString()
+	}
+
+	if !config.DefaultOrganizationId.IsNull() && len(config.DefaultOrganizationId.ValueString()) > 0 {
+		defaultOrganizationId = config.DefaultOrganizationId.ValueString()
 	}
 
 	// Validate Provider Configuration
@@ -111,7 +383,13 @@ func (p *SonatypeIqProvider) Configure(ctx context.Context, req provider.Configu
 		)
 	}
 
-	if _, e := url.ParseRequestURI(iqUrl); e != nil {
+	if config.Url.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("url"),
+			"Unknown Sonatype IQ Server URL",
+			"The Sonatype IQ Server URL must be known when configuring the provider - upgrade to a version of Terraform that supports deferred actions to configure it from resources created in the same run",
+		)
+	} else if _, e := url.ParseRequestURI(iqUrl); e != nil {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("url"),
 			"Invalid Sonatype IQ Server URL",
@@ -135,13 +413,93 @@ func (p *SonatypeIqProvider) Configure(ctx context.Context, req provider.Configu
 		)
 	}
 
+	if config.UserCode.IsUnknown() || config.PassCode.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("user_code"),
+			"User Token not supplied",
+			"The User Code and Pass Code of the User Token must be known when configuring the provider",
+		)
+	}
+
+	// User Tokens take precedence over Username and Password
+	auth := sonatypeiq.BasicAuth{UserName: username, Password: password}
+	if len(userCode) > 0 || len(passCode) > 0 {
+		if len(userCode) == 0 || len(passCode) == 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("user_code"),
+				"Incomplete User Token",
+				"Both the User Code and the Pass Code of the User Token are required",
+			)
+		}
+		auth = sonatypeiq.BasicAuth{UserName: userCode, Password: passCode}
+	}
+
+	switch authMode {
+	case authModeBasic:
+		if len(auth.UserName) == 0 || len(auth.Password) == 0 {
+			resp.Diagnostics.AddError(
+				"Credentials not supplied",
+				"Either a Username and Password or a User Code and Pass Code for your Sonatype IQ Server are required",
+			)
+		}
+	case authModeBearer, authModeHeader:
+		if len(token) == 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("token"),
+				"Token not supplied",
+				fmt.Sprintf("A token is required when auth_mode is %s", authMode),
+			)
+		}
+		if authMode == authModeHeader && len(authHeader) == 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("auth_header"),
+				"Header not supplied",
+				"The name of the header to send the token in is required when auth_mode is header",
+			)
+		}
+	default:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("auth_mode"),
+			"Invalid Authentication Mode",
+			fmt.Sprintf("Unsupported auth_mode '%s' - use one of %s, %s or %s", authMode, authModeBasic, authModeBearer, authModeHeader),
+		)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// The HTTP client is built from the configuration, to which the environment may have contributed
+	config.Url = types.StringValue(iqUrl)
+	config.Password = types.StringValue(password)
+	config.PassCode = types.StringValue(passCode)
+	config.AuthMode = types.StringValue(authMode)
+	config.Token = types.StringValue(token)
+	config.AuthHeader = types.StringValue(authHeader)
+	config.Username = types.StringValue(username)
+	config.UserCode = types.StringValue(userCode)
+	ctx = withMaskedSecrets(ctx, providerSecrets(config))
+	httpClient, diags := newHttpClient(config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	additionalHeaders := make(map[string]string)
+	if !config.AdditionalHeaders.IsNull() {
+		resp.Diagnostics.Append(config.AdditionalHeaders.ElementsAs(ctx, &additionalHeaders, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	// Example client configuration for data sources and resources
 	configuration := sonatypeiq.NewConfiguration()
-	configuration.UserAgent = "sonatypeiq-terraform/" + p.version
+	configuration.HTTPClient = httpClient
+	configuration.UserAgent = fmt.Sprintf("terraform-provider-sonatypeiq/%s Terraform/%s", p.version, req.TerraformVersion)
+	for header, value := range additionalHeaders {
+		configuration.AddDefaultHeader(header, value)
+	}
 	configuration.Servers = []sonatypeiq.ServerConfiguration{
 		{
 			URL:         iqUrl,
@@ -150,40 +508,105 @@ func (p *SonatypeIqProvider) Configure(ctx context.Context, req provider.Configu
 	}
 
 	client := sonatypeiq.NewAPIClient(configuration)
+
+	if !config.SkipConnectivityCheck.ValueBool() {
+		resp.Diagnostics.Append(checkServer(ctx, client, auth, config.MinimumServerVersion.ValueString())...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	identity := ""
+	if authMode == authModeBasic && len(userCode) == 0 && len(passCode) == 0 {
+		identity = username
+	}
 	resp.DataSourceData = SonatypeDataSourceData{
-		client: client,
-		auth:   sonatypeiq.BasicAuth{UserName: username, Password: password},
+		client:   client,
+		auth:     auth,
+		authMode: authMode,
+		username: identity,
 	}
 	resp.ResourceData = SonatypeDataSourceData{
-		client: client,
-		auth:   sonatypeiq.BasicAuth{UserName: username, Password: password},
+		client:                client,
+		auth:                  auth,
+		defaultOrganizationId: defaultOrganizationId,
+		validateRoles:         config.ValidateRoles.ValueBool(),
+		retained:              newRetainedApplications(),
 	}
 }
 
 func (p *SonatypeIqProvider) Resources(ctx context.Context) []func() resource.Resource {
-	return []func() resource.Resource{
+	resources := []func() resource.Resource{
 		NewApplicationResource,
+		NewAutoPolicyWaiverResource,
 		NewConfigMailResource,
 		NewConfigProxyServerResource,
+		NewLicenseOverrideResource,
 		NewOrganizationResource,
+		NewPolicyWaiverResource,
+		NewPolicyWaiverRequestResource,
+		NewRootOrganizationResource,
+		NewSourceControlResource,
 		NewSystemConfigResource,
 		NewUserResource,
 		NewApplicationRoleMembershipResource,
 		NewOrganizationRoleMembershipResource,
+		NewRoleMembershipsResource,
 	}
+	return append(resources, legacyResources(ctx, "sonatypeiq", resources)...)
 }
 
 func (p *SonatypeIqProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
+		ApplicableWaiversDataSource,
+		AuditLogDataSource,
+		AuthorizationDataSource,
+		AttributionReportDataSource,
 		ApplicationCategoriesDataSource,
 		ApplicationDataSource,
 		ApplicationsDataSource,
+		ApplicationReportPdfDataSource,
+		ComponentLabelsDataSource,
 		ConfigSamlDataSource,
+		CurrentUserDataSource,
+		DependencyTreeDataSource,
+		HealthDataSource,
+		InnerSourceComponentsDataSource,
+		LicenseObligationsDataSource,
+		LicenseThreatGroupsDataSource,
+		ManifestEvaluationDataSource,
 		OrganizationDataSource,
+		OrganizationApplicationsDataSource,
 		OrganizationsDataSource,
+		ProductLicenseDataSource,
+		ReportHistoryDataSource,
+		ScmProvidersDataSource,
+		SourceControlDataSource,
+		SourceControlMetricsDataSource,
+		EffectiveConfigurationDataSource,
+		StagesDataSource,
+		SuccessMetricsDataSource,
 		SystemConfigDataSource,
+		ViolationTrendsDataSource,
 		RoleDataSource,
+		RootOrganizationDataSource,
+	}
+}
+
+func (p *SonatypeIqProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewBuildPurlFunction,
+		NewCvssToThreatLevelFunction,
+	}
+}
+
+// envDefault returns the value of the first of the given environment variables that is set.
+func envDefault(names ...string) string {
+	for _, name := range names {
+		if value := os.Getenv(name); len(value) > 0 {
+			return value
+		}
 	}
+	return ""
 }
 
 func New(version string) func() provider.Provider {
@@ -17,6 +17,10 @@
 package provider
 
 import (
+	"context"
+	"regexp"
+	"testing"
+
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 )
@@ -46,3 +50,48 @@ var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServe
 // 	// about the appropriate environment variables being set are common to see in a pre-check
 // 	// function.
 // }
+
+// secretAttributeName matches the names of attributes that hold secrets.
+var secretAttributeName = regexp.MustCompile(`(^|_)(password|pass_code|token|secret|private_key|client_key)$`)
+
+// TestProviderSchemaSensitiveAttributes makes sure that secrets are never shown in plans or outputs.
+func TestProviderSchemaSensitiveAttributes(t *testing.T) {
+	server, err := testAccProtoV6ProviderFactories["sonatypeiq"]()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := server.GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range resp.Diagnostics {
+		t.Errorf("%s: %s", d.Summary, d.Detail)
+	}
+
+	schemas := map[string]*tfprotov6.Schema{"provider": resp.Provider}
+	for name, s := range resp.ResourceSchemas {
+		schemas[name] = s
+	}
+	for name, s := range resp.DataSourceSchemas {
+		schemas["data."+name] = s
+	}
+
+	for name, s := range schemas {
+		checkSensitiveAttributes(t, name, s.Block)
+	}
+}
+
+func checkSensitiveAttributes(t *testing.T, prefix string, block *tfprotov6.SchemaBlock) {
+	for _, attribute := range block.Attributes {
+		if secretAttributeName.MatchString(attribute.Name) && !attribute.Sensitive {
+			t.Errorf("%s.%s holds a secret but is not marked Sensitive", prefix, attribute.Name)
+		}
+		if attribute.NestedType != nil {
+			checkSensitiveAttributes(t, prefix+"."+attribute.Name, &tfprotov6.SchemaBlock{Attributes: attribute.NestedType.Attributes})
+		}
+	}
+	for _, nested := range block.BlockTypes {
+		checkSensitiveAttributes(t, prefix+"."+nested.TypeName, nested.Block)
+	}
+}
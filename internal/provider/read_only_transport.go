@@ -0,0 +1,80 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+)
+
+// readOnlyTransport refuses to send requests that could change IQ Server, which is every request
+// other than GET, HEAD and OPTIONS unless it is marked as only reading.
+type readOnlyTransport struct {
+	next http.RoundTripper
+}
+
+// onlyReadsKey is the context key marking requests that only read, despite their method.
+type onlyReadsKey struct{}
+
+// onlyReads returns a context for requests that do not change IQ Server, although they are sent
+// as a POST because of the query they carry. Only POST requests to queryEndpoints are treated as
+// reading, so that the mark cannot exempt any other request.
+func onlyReads(ctx context.Context) context.Context {
+	return context.WithValue(ctx, onlyReadsKey{}, true)
+}
+
+// queryEndpoints are the endpoints that only read, although they are sent as a POST: evaluating
+// components, querying metrics and generating an attribution report.
+var queryEndpoints = []*regexp.Regexp{
+	regexp.MustCompile(`/api/v2/evaluation/applications/[^/]+$`),
+	regexp.MustCompile(`/api/v2/reports/metrics$`),
+	regexp.MustCompile(`/api/v2/licenseLegalMetadata/application/[^/]+/stage/[^/]+/report/templateId/[^/]+$`),
+}
+
+// markedOnlyReads reports whether the request is a POST to one of the queryEndpoints that is
+// marked by onlyReads. DELETE, PUT and PATCH requests never are, whatever their context.
+func markedOnlyReads(req *http.Request) bool {
+	if req.Method != http.MethodPost {
+		return false
+	}
+	if reads, _ := req.Context().Value(onlyReadsKey{}).(bool); !reads {
+		return false
+	}
+	for _, endpoint := range queryEndpoints {
+		if endpoint.MatchString(req.URL.Path) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *readOnlyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return t.next.RoundTrip(req)
+	}
+	if markedOnlyReads(req) {
+		return t.next.RoundTrip(req)
+	}
+
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	return nil, fmt.Errorf("the provider is configured to be read_only, so %s %s was not sent to Sonatype IQ Server", req.Method, req.URL.Path)
+}
@@ -0,0 +1,113 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestReadOnlyTransport(t *testing.T) {
+	var received atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		received.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, diags := newHttpClient(SonatypeIqProviderModel{
+		Url:      types.StringValue(server.URL),
+		ReadOnly: types.BoolValue(true),
+	})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	resp, err := client.Get(server.URL + "/api/v2/organizations")
+	if err != nil {
+		t.Fatalf("expected GET to be sent, got: %s", err)
+	}
+	resp.Body.Close()
+
+	_, err = client.Post(server.URL+"/api/v2/organizations", "application/json", strings.NewReader(`{"name":"Sandbox Organization"}`))
+	if err == nil || !strings.Contains(err.Error(), "read_only") {
+		t.Errorf("expected POST to be refused, got: %v", err)
+	}
+	if received.Load() != 1 {
+		t.Errorf("expected only the GET to reach IQ Server, got %d requests", received.Load())
+	}
+}
+
+func TestReadOnlyTransportOnlyReads(t *testing.T) {
+	var received atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		received.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, diags := newHttpClient(SonatypeIqProviderModel{
+		Url:      types.StringValue(server.URL),
+		ReadOnly: types.BoolValue(true),
+	})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	// Success Metrics are queried with a POST that only reads
+	req, err := http.NewRequestWithContext(onlyReads(context.Background()), http.MethodPost, server.URL+"/api/v2/reports/metrics", strings.NewReader(`{"timePeriod":"MONTH"}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("expected POST that only reads to be sent, got: %s", err)
+	}
+	resp.Body.Close()
+
+	if received.Load() != 1 {
+		t.Errorf("expected the POST to reach IQ Server, got %d requests", received.Load())
+	}
+
+	// The mark does not exempt requests that change IQ Server
+	for _, marked := range []struct {
+		method string
+		path   string
+	}{
+		{http.MethodDelete, "/api/v2/labels/organization/ROOT_ORGANIZATION_ID/00000000000000000000000000000000"},
+		{http.MethodPut, "/api/v2/reports/metrics"},
+		{http.MethodPatch, "/api/v2/reports/metrics"},
+		{http.MethodPost, "/api/v2/organizations"},
+	} {
+		req, err := http.NewRequestWithContext(onlyReads(context.Background()), marked.method, server.URL+marked.path, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if _, err := client.Do(req); err == nil || !strings.Contains(err.Error(), "read_only") {
+			t.Errorf("expected marked %s %s to be refused, got: %v", marked.method, marked.path, err)
+		}
+	}
+	if received.Load() != 1 {
+		t.Errorf("expected only the POST that reads to reach IQ Server, got %d requests", received.Load())
+	}
+}
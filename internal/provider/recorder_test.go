@@ -0,0 +1,331 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// recordEnv makes recorded tests run against IQ Server and record their cassette.
+const recordEnv = "SONATYPEIQ_RECORD"
+
+// cassette holds the interactions with IQ Server recorded by a test.
+type cassette struct {
+	Interactions []interaction `json:"interactions"`
+}
+
+// interaction is a single request sent to IQ Server and its response. Secrets are redacted from
+// both bodies, so that cassettes can be committed.
+type interaction struct {
+	Method       string `json:"method"`
+	Uri          string `json:"uri"`
+	RequestBody  string `json:"request_body,omitempty"`
+	StatusCode   int    `json:"status_code"`
+	ContentType  string `json:"content_type,omitempty"`
+	ResponseBody string `json:"response_body,omitempty"`
+}
+
+// recorder records the interactions with IQ Server into a cassette, or replays them from the
+// cassette without contacting IQ Server.
+type recorder struct {
+	mu        sync.Mutex
+	recording bool
+	cassette  cassette
+	replayed  []bool
+}
+
+// roundTripperFunc adapts a function to http.RoundTripper.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func newRecorder(path string, recording bool) (*recorder, error) {
+	r := &recorder{recording: recording}
+	if recording {
+		return r, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &r.cassette); err != nil {
+		return nil, fmt.Errorf("invalid cassette %s: %w", path, err)
+	}
+	r.replayed = make([]bool, len(r.cassette.Interactions))
+	return r, nil
+}
+
+// wrap is used as wrapTransport, so that every HTTP client built by the provider goes through
+// the recorder.
+func (r *recorder) wrap(next http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return r.roundTrip(next, req)
+	})
+}
+
+func (r *recorder) roundTrip(next http.RoundTripper, req *http.Request) (*http.Response, error) {
+	var requestBody []byte
+	if req.Body != nil {
+		payload, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		requestBody = payload
+		req.Body = io.NopCloser(bytes.NewReader(payload))
+	}
+
+	request := interaction{
+		Method:      req.Method,
+		Uri:         req.URL.RequestURI(),
+		RequestBody: cassetteBody(requestBody),
+	}
+	if !r.recording {
+		return r.replay(req, request)
+	}
+
+	resp, err := next.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+	payload, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(payload))
+	if err != nil {
+		return resp, err
+	}
+
+	request.StatusCode = resp.StatusCode
+	request.ContentType = resp.Header.Get("Content-Type")
+	request.ResponseBody = cassetteBody(payload)
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, request)
+	r.mu.Unlock()
+
+	return resp, nil
+}
+
+// replay returns the response of the first interaction not replayed yet that matches the request.
+// Terraform refreshes independent resources concurrently, so interactions are not replayed in order.
+func (r *recorder) replay(req *http.Request, request interaction) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, recorded := range r.cassette.Interactions {
+		if r.replayed[i] || recorded.Method != request.Method || recorded.Uri != request.Uri || recorded.RequestBody != request.RequestBody {
+			continue
+		}
+		r.replayed[i] = true
+
+		header := http.Header{}
+		if recorded.ContentType != "" {
+			header.Set("Content-Type", recorded.ContentType)
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+			StatusCode:    recorded.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(strings.NewReader(recorded.ResponseBody)),
+			ContentLength: int64(len(recorded.ResponseBody)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("no recorded interaction left for %s %s %s", request.Method, request.Uri, request.RequestBody)
+}
+
+// unreplayed returns the interactions of the cassette that were not replayed.
+func (r *recorder) unreplayed() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var unreplayed []string
+	for i, recorded := range r.cassette.Interactions {
+		if !r.replayed[i] {
+			unreplayed = append(unreplayed, recorded.Method+" "+recorded.Uri)
+		}
+	}
+	return unreplayed
+}
+
+// cassetteBody redacts the secrets from a JSON body. Bodies without secrets are kept as is.
+func cassetteBody(payload []byte) string {
+	if redacted := redactBody(payload); strings.Contains(redacted, `"***"`) {
+		return redacted
+	}
+	return string(payload)
+}
+
+func (r *recorder) save(path string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// recordedTest runs an acceptance test with its interactions with IQ Server kept in the cassette
+// testdata/cassettes/<test name>.json. With SONATYPEIQ_RECORD set, the test runs against IQ Server
+// and records the cassette. Otherwise the cassette is replayed, so that the test runs without
+// TF_ACC and without IQ Server. Tests without a cassette run as regular acceptance tests.
+//
+// Requests must match the cassette, so recorded tests cannot use random names.
+func recordedTest(t *testing.T, testCase resource.TestCase) {
+	path := filepath.Join("testdata", "cassettes", t.Name()+".json")
+	recording := os.Getenv(recordEnv) != ""
+
+	if !recording {
+		if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+			resource.Test(t, testCase)
+			return
+		}
+	}
+
+	rec, err := newRecorder(path, recording)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wrapTransport = rec.wrap
+	defer func() { wrapTransport = nil }()
+
+	if recording {
+		resource.Test(t, testCase)
+		if !t.Failed() {
+			if err := rec.save(path); err != nil {
+				t.Fatalf("Unable to save cassette %s: %s", path, err)
+			}
+		}
+		return
+	}
+
+	// Requests never leave the recorder, so any IQ Server and credentials will do
+	t.Setenv("SONATYPEIQ_URL", "http://iq.invalid")
+	t.Setenv("SONATYPEIQ_USERNAME", "admin")
+	t.Setenv("SONATYPEIQ_PASSWORD", "admin")
+
+	resource.UnitTest(t, testCase)
+	if unreplayed := rec.unreplayed(); len(unreplayed) > 0 && !t.Failed() {
+		t.Errorf("Recorded interactions were not replayed, re-record %s: %s", path, strings.Join(unreplayed, ", "))
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if req.Method == http.MethodPost {
+			w.WriteHeader(http.StatusCreated)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"method": req.Method, "uri": req.URL.RequestURI()})
+	}))
+	defer server.Close()
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	rec, err := newRecorder(path, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &http.Client{Transport: rec.wrap(http.DefaultTransport)}
+	recorded := recorderExchange(t, client, server.URL)
+	if err := rec.save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "s3cr3t") {
+		t.Errorf("Cassette contains a secret: %s", data)
+	}
+
+	rec, err = newRecorder(path, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client = &http.Client{Transport: rec.wrap(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		t.Errorf("Replayed request %s %s was sent", req.Method, req.URL)
+		return nil, errors.New("unexpected request")
+	}))}
+	replayed := recorderExchange(t, client, "http://iq.invalid")
+
+	if strings.Join(replayed, "\n") != strings.Join(recorded, "\n") {
+		t.Errorf("Replayed responses %q, expected %q", replayed, recorded)
+	}
+	if unreplayed := rec.unreplayed(); len(unreplayed) > 0 {
+		t.Errorf("Interactions were not replayed: %v", unreplayed)
+	}
+
+	if _, err := client.Get("http://iq.invalid/api/v2/applications"); err == nil {
+		t.Error("Expected an error for a request that was replayed already")
+	}
+}
+
+func recorderExchange(t *testing.T, client *http.Client, url string) []string {
+	var responses []string
+	for _, req := range []struct {
+		method string
+		uri    string
+		body   string
+	}{
+		{http.MethodGet, "/api/v2/applications", ""},
+		{http.MethodGet, "/api/v2/applications?publicId=app", ""},
+		{http.MethodPost, "/api/v2/users", `{"username":"user","password":"s3cr3t"}`},
+	} {
+		request, err := http.NewRequest(req.method, url+req.uri, strings.NewReader(req.body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := client.Do(request)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		responses = append(responses, fmt.Sprintf("%d %s %s", resp.StatusCode, resp.Header.Get("Content-Type"), body))
+	}
+	return responses
+}
@@ -0,0 +1,265 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	sonatypeiq "github.com/sonatype-nexus-community/nexus-iq-api-client-go"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &reportHistoryDataSource{}
+	_ datasource.DataSourceWithConfigure = &reportHistoryDataSource{}
+)
+
+// ReportHistoryDataSource is a helper function to simplify the provider implementation.
+func ReportHistoryDataSource() datasource.DataSource {
+	return &reportHistoryDataSource{}
+}
+
+// reportHistoryDataSource is the data source implementation.
+type reportHistoryDataSource struct {
+	baseDataSource
+}
+
+type reportHistoryDataSourceModel struct {
+	ID                   types.String         `tfsdk:"id"`
+	ApplicationId        types.String         `tfsdk:"application_id"`
+	StageId              types.String         `tfsdk:"stage_id"`
+	MaxResults           types.Int64          `tfsdk:"max_results"`
+	LatestEvaluationDate types.String         `tfsdk:"latest_evaluation_date"`
+	Reports              []reportHistoryModel `tfsdk:"reports"`
+}
+
+type reportHistoryModel struct {
+	ScanId                            types.String `tfsdk:"scan_id"`
+	StageId                           types.String `tfsdk:"stage_id"`
+	EvaluationDate                    types.String `tfsdk:"evaluation_date"`
+	PolicyEvaluationId                types.String `tfsdk:"policy_evaluation_id"`
+	CommitHash                        types.String `tfsdk:"commit_hash"`
+	ScanTriggerType                   types.String `tfsdk:"scan_trigger_type"`
+	Reevaluation                      types.Bool   `tfsdk:"reevaluation"`
+	ForMonitoring                     types.Bool   `tfsdk:"for_monitoring"`
+	ReportHtmlUrl                     types.String `tfsdk:"report_html_url"`
+	ReportPdfUrl                      types.String `tfsdk:"report_pdf_url"`
+	ReportDataUrl                     types.String `tfsdk:"report_data_url"`
+	CriticalPolicyViolationCount      types.Int64  `tfsdk:"critical_policy_violation_count"`
+	SeverePolicyViolationCount        types.Int64  `tfsdk:"severe_policy_violation_count"`
+	ModeratePolicyViolationCount      types.Int64  `tfsdk:"moderate_policy_violation_count"`
+	GrandfatheredPolicyViolationCount types.Int64  `tfsdk:"grandfathered_policy_violation_count"`
+	AffectedComponentCount            types.Int64  `tfsdk:"affected_component_count"`
+	TotalComponentCount               types.Int64  `tfsdk:"total_component_count"`
+}
+
+// Metadata returns the data source type name.
+func (d *reportHistoryDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_report_history"
+}
+
+// Schema defines the schema for the data source.
+func (d *reportHistoryDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Use this data source to get the history of evaluation reports of an Application, latest first, e.g. to reference an earlier report or to check that an Application was evaluated recently",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"application_id": schema.StringAttribute{
+				Description: "Internal ID or public ID of the Application",
+				Required:    true,
+			},
+			"stage_id": schema.StringAttribute{
+				Description: "Only return the reports of evaluations in this Stage (e.g. build, stage-release, release, operate)",
+				Optional:    true,
+			},
+			"max_results": maxResultsAttribute("reports"),
+			"latest_evaluation_date": schema.StringAttribute{
+				Description: "Date and time (RFC 3339) of the latest evaluation - null when the Application has not been evaluated",
+				Computed:    true,
+			},
+			"reports": schema.ListNestedAttribute{
+				Description: "Evaluation reports, latest first",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"scan_id": schema.StringAttribute{
+							Description: "ID of the scan the report is for",
+							Computed:    true,
+						},
+						"stage_id": schema.StringAttribute{
+							Description: "Stage of the evaluation",
+							Computed:    true,
+						},
+						"evaluation_date": schema.StringAttribute{
+							Description: "Date and time (RFC 3339) of the evaluation",
+							Computed:    true,
+						},
+						"policy_evaluation_id": schema.StringAttribute{
+							Description: "ID of the policy evaluation",
+							Computed:    true,
+						},
+						"commit_hash": schema.StringAttribute{
+							Description: "Commit that was evaluated - null when not known",
+							Computed:    true,
+						},
+						"scan_trigger_type": schema.StringAttribute{
+							Description: "What triggered the evaluation (e.g. CLI, CI, SOURCE_CONTROL)",
+							Computed:    true,
+						},
+						"reevaluation": schema.BoolAttribute{
+							Description: "Whether the report is a re-evaluation of an earlier scan",
+							Computed:    true,
+						},
+						"for_monitoring": schema.BoolAttribute{
+							Description: "Whether the evaluation is used for continuous monitoring",
+							Computed:    true,
+						},
+						"report_html_url": schema.StringAttribute{
+							Description: "Relative URL of the report in the IQ Server UI",
+							Computed:    true,
+						},
+						"report_pdf_url": schema.StringAttribute{
+							Description: "Relative URL of the PDF report",
+							Computed:    true,
+						},
+						"report_data_url": schema.StringAttribute{
+							Description: "Relative URL of the report data",
+							Computed:    true,
+						},
+						"critical_policy_violation_count": schema.Int64Attribute{
+							Description: "Number of critical policy violations",
+							Computed:    true,
+						},
+						"severe_policy_violation_count": schema.Int64Attribute{
+							Description: "Number of severe policy violations",
+							Computed:    true,
+						},
+						"moderate_policy_violation_count": schema.Int64Attribute{
+							Description: "Number of moderate policy violations",
+							Computed:    true,
+						},
+						"grandfathered_policy_violation_count": schema.Int64Attribute{
+							Description: "Number of legacy (grandfathered) policy violations",
+							Computed:    true,
+						},
+						"affected_component_count": schema.Int64Attribute{
+							Description: "Number of components with policy violations",
+							Computed:    true,
+						},
+						"total_component_count": schema.Int64Attribute{
+							Description: "Number of components evaluated",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *reportHistoryDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if deferUnknownOwnerRead(req, resp) {
+		return
+	}
+
+	var data reportHistoryDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = context.WithValue(
+		ctx,
+		sonatypeiq.ContextBasicAuth,
+		d.auth,
+	)
+
+	applicationId, diags := resolveApplicationId(ctx, d.client, data.ApplicationId.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiRequest := d.client.ReportsAPI.GetReportHistoryForApplication(ctx, applicationId)
+	if !data.StageId.IsNull() {
+		apiRequest = apiRequest.Stage(data.StageId.ValueString())
+	}
+	if !data.MaxResults.IsNull() {
+		apiRequest = apiRequest.Limit(int32(data.MaxResults.ValueInt64()))
+	}
+	history, api_response, err := apiRequest.Execute()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read IQ report history",
+			describeApiError(api_response, err),
+		)
+		return
+	}
+
+	reports := history.Reports
+	sort.SliceStable(reports, func(i, j int) bool {
+		return reports[i].GetEvaluationDate().After(reports[j].GetEvaluationDate())
+	})
+
+	data.ID = types.StringValue(applicationId)
+	data.LatestEvaluationDate = types.StringNull()
+	data.Reports = []reportHistoryModel{}
+	for _, report := range reports {
+		model := reportHistoryModel{
+			ScanId:             types.StringPointerValue(report.ScanId),
+			StageId:            types.StringPointerValue(report.Stage),
+			EvaluationDate:     types.StringNull(),
+			PolicyEvaluationId: types.StringPointerValue(report.PolicyEvaluationId),
+			CommitHash:         types.StringPointerValue(report.CommitHash),
+			ScanTriggerType:    types.StringPointerValue(report.ScanTriggerType),
+			Reevaluation:       types.BoolValue(report.GetIsReevaluation()),
+			ForMonitoring:      types.BoolValue(report.GetIsForMonitoring()),
+			ReportHtmlUrl:      types.StringPointerValue(report.ReportHtmlUrl),
+			ReportPdfUrl:       types.StringPointerValue(report.ReportPdfUrl),
+			ReportDataUrl:      types.StringPointerValue(report.ReportDataUrl),
+		}
+		if report.EvaluationDate != nil {
+			model.EvaluationDate = types.StringValue(report.EvaluationDate.UTC().Format(time.RFC3339))
+		}
+		result := report.GetPolicyEvaluationResult()
+		model.CriticalPolicyViolationCount = int32PointerValue(result.CriticalPolicyViolationCount)
+		model.SeverePolicyViolationCount = int32PointerValue(result.SeverePolicyViolationCount)
+		model.ModeratePolicyViolationCount = int32PointerValue(result.ModeratePolicyViolationCount)
+		model.GrandfatheredPolicyViolationCount = int32PointerValue(result.GrandfatheredPolicyViolationCount)
+		model.AffectedComponentCount = int32PointerValue(result.AffectedComponentCount)
+		model.TotalComponentCount = int32PointerValue(result.TotalComponentCount)
+		data.Reports = append(data.Reports, model)
+	}
+	if len(data.Reports) > 0 {
+		data.LatestEvaluationDate = data.Reports[0].EvaluationDate
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
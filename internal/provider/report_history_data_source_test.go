@@ -0,0 +1,48 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccReportHistoryDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccSandbox(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `data "sonatypeiq_report_history" "sandbox" {
+					application_id = "sandbox-application"
+					stage_id       = "build"
+					max_results    = 2
+				}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.sonatypeiq_report_history.sandbox", "id"),
+					resource.TestCheckResourceAttrSet("data.sonatypeiq_report_history.sandbox", "latest_evaluation_date"),
+					resource.TestCheckResourceAttr("data.sonatypeiq_report_history.sandbox", "reports.0.stage_id", "build"),
+					resource.TestCheckResourceAttrPair("data.sonatypeiq_report_history.sandbox", "reports.0.evaluation_date", "data.sonatypeiq_report_history.sandbox", "latest_evaluation_date"),
+					resource.TestCheckResourceAttrSet("data.sonatypeiq_report_history.sandbox", "reports.0.scan_id"),
+					resource.TestCheckResourceAttrSet("data.sonatypeiq_report_history.sandbox", "reports.0.total_component_count"),
+				),
+			},
+		},
+	})
+}
@@ -20,21 +20,33 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 
 	sonatypeiq "github.com/sonatype-nexus-community/nexus-iq-api-client-go"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource              = &baseResource{}
-	_ resource.ResourceWithConfigure = &baseResource{}
+	_ resource.Resource               = &baseResource{}
+	_ resource.ResourceWithConfigure  = &baseResource{}
+	_ resource.ResourceWithModifyPlan = &baseResource{}
 )
 
+// ownerAttributes are the attributes that identify the Organization or Application that a
+// resource or data source belongs to.
+var ownerAttributes = []string{"organization_id", "application_id", "parent_organization_id", "owner_id"}
+
 // applicationResource is the resource implementation.
 type baseResource struct {
 	client *sonatypeiq.APIClient
 	auth   sonatypeiq.BasicAuth
+
+	defaultOrganizationId string
+	validateRoles         bool
+	retained              *retainedApplications
 }
 
 // Create implements resource.Resource.
@@ -85,4 +97,83 @@ func (r *baseResource) Configure(_ context.Context, req resource.ConfigureReques
 
 	r.client = config.client
 	r.auth = config.auth
+	r.defaultOrganizationId = config.defaultOrganizationId
+	r.validateRoles = config.validateRoles
+	r.retained = config.retained
+}
+
+// ModifyPlan implements resource.ResourceWithModifyPlan. Resources that modify their plan any
+// further call deferUnknownOwner themselves.
+func (r *baseResource) ModifyPlan(_ context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	deferUnknownOwner(req, resp)
+}
+
+// hasUnknownOwner reports whether any of the owner attributes of a configuration is unknown, which
+// is the case when the Organization or Application is created in the same run.
+func hasUnknownOwner(config tftypes.Value) bool {
+	var attributes map[string]tftypes.Value
+	if err := config.As(&attributes); err != nil {
+		return false
+	}
+	for _, name := range ownerAttributes {
+		if value, ok := attributes[name]; ok && !value.IsKnown() {
+			return true
+		}
+	}
+	return false
+}
+
+// deferUnknownOwner defers the change of a resource whose owner is not known yet, when Terraform
+// supports deferred actions. It reports whether the owner is unknown, in which case checks against
+// IQ Server that need the owner are skipped while planning.
+func deferUnknownOwner(req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) bool {
+	if !hasUnknownOwner(req.Config.Raw) {
+		return false
+	}
+	if req.ClientCapabilities.DeferralAllowed {
+		resp.Deferred = &resource.Deferred{
+			Reason: resource.DeferredReasonResourceConfigUnknown,
+		}
+	}
+	return true
+}
+
+// checkRoles reports an error on the attribute of each of the given Roles that is neither the ID
+// nor the name of a Role on IQ Server. Roles are only checked when
+// validate_roles is enabled on the provider, and once the provider is configured.
+func (r *baseResource) checkRoles(ctx context.Context, roles map[string]path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if !r.validateRoles || r.client == nil || len(roles) == 0 {
+		return diags
+	}
+
+	ctx = context.WithValue(
+		ctx,
+		sonatypeiq.ContextBasicAuth,
+		r.auth,
+	)
+
+	roleList, apiResponse, err := r.client.RolesAPI.GetRoles(ctx).Execute()
+	if err != nil {
+		diags.AddWarning("Unable to validate Roles", describeApiError(apiResponse, err))
+		return diags
+	}
+
+	for role, attribute := range roles {
+		found := false
+		for _, candidate := range roleList.Roles {
+			if candidate.GetId() == role || candidate.GetName() == role {
+				found = true
+				break
+			}
+		}
+		if !found {
+			diags.AddAttributeError(
+				attribute,
+				"Unknown Role",
+				fmt.Sprintf("No Role with name or ID '%s' exists", role),
+			)
+		}
+	}
+	return diags
 }
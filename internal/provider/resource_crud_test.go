@@ -0,0 +1,468 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	sonatypeiq "github.com/sonatype-nexus-community/nexus-iq-api-client-go"
+)
+
+// crudTestCase creates, reads, refreshes and deletes a resource against the mock IQ Server. The test stops
+// at the first step that is expected to fail.
+type crudTestCase struct {
+	name         string
+	resourceType string
+
+	// config holds the configured attributes, computed the attributes that are unknown in the plan.
+	config   map[string]tftypes.Value
+	computed []string
+
+	// path is the path of the entity in IQ Server, followed by its ID. The create request is sent
+	// to the path without trailing slash, with a body containing createBody.
+	path       string
+	createBody string
+
+	// setup runs before the create, afterCreate once the created entity has been read.
+	setup       func(m *mockIqServer)
+	afterCreate func(m *mockIqServer, id string)
+
+	createError string
+	readRemoves bool
+	deleteError string
+}
+
+func TestResourceCrud(t *testing.T) {
+	organization := map[string]tftypes.Value{
+		"name":                   tftypes.NewValue(tftypes.String, "Sandbox Organization"),
+		"parent_organization_id": tftypes.NewValue(tftypes.String, "ROOT_ORGANIZATION_ID"),
+		"force_destroy":          tftypes.NewValue(tftypes.Bool, false),
+	}
+	application := map[string]tftypes.Value{
+		"name":                      tftypes.NewValue(tftypes.String, "Sandbox Application"),
+		"public_id":                 tftypes.NewValue(tftypes.String, "sandbox-application"),
+		"organization_id":           tftypes.NewValue(tftypes.String, "ROOT_ORGANIZATION_ID"),
+		"strict_contact_validation": tftypes.NewValue(tftypes.Bool, false),
+		"deletion_protection":       tftypes.NewValue(tftypes.Bool, false),
+		"retain_on_destroy":         tftypes.NewValue(tftypes.Bool, false),
+	}
+	applicationComputed := []string{"id", "application_category_ids", "application_tags", "last_updated"}
+
+	for _, test := range []crudTestCase{
+		{
+			name:         "organization",
+			resourceType: "sonatypeiq_organization",
+			config:       organization,
+			computed:     []string{"id", "last_updated"},
+			path:         "/api/v2/organizations/",
+			createBody:   `"name":"Sandbox Organization","parentOrganizationId":"ROOT_ORGANIZATION_ID"`,
+		},
+		{
+			name:         "organization create conflict",
+			resourceType: "sonatypeiq_organization",
+			config:       organization,
+			computed:     []string{"id", "last_updated"},
+			path:         "/api/v2/organizations/",
+			setup: func(m *mockIqServer) {
+				m.organizations["existing"] = sonatypeiq.ApiOrganizationDTO{
+					Id:                   sonatypeiq.PtrString("existing"),
+					Name:                 sonatypeiq.PtrString("Sandbox Organization"),
+					ParentOrganizationId: sonatypeiq.PtrString("ROOT_ORGANIZATION_ID"),
+				}
+			},
+			createError: "Error creating Organization",
+		},
+		{
+			name:         "organization deleted outside of Terraform",
+			resourceType: "sonatypeiq_organization",
+			config:       organization,
+			computed:     []string{"id", "last_updated"},
+			path:         "/api/v2/organizations/",
+			afterCreate: func(m *mockIqServer, id string) {
+				delete(m.organizations, id)
+			},
+			readRemoves: true,
+		},
+		{
+			name:         "organization delete server error",
+			resourceType: "sonatypeiq_organization",
+			config:       organization,
+			computed:     []string{"id", "last_updated"},
+			path:         "/api/v2/organizations/",
+			afterCreate: func(m *mockIqServer, id string) {
+				m.fail(http.MethodDelete, "/api/v2/organizations/"+id, http.StatusInternalServerError)
+			},
+			deleteError: "Error deleting Organization",
+		},
+		{
+			name:         "application",
+			resourceType: "sonatypeiq_application",
+			config:       application,
+			computed:     applicationComputed,
+			path:         "/api/v2/applications/",
+			createBody:   `"name":"Sandbox Application","organizationId":"ROOT_ORGANIZATION_ID","publicId":"sandbox-application"`,
+		},
+		{
+			name:         "application create conflict",
+			resourceType: "sonatypeiq_application",
+			config:       application,
+			computed:     applicationComputed,
+			path:         "/api/v2/applications/",
+			setup: func(m *mockIqServer) {
+				m.applications["existing"] = sonatypeiq.ApiApplicationDTO{
+					Id:             sonatypeiq.PtrString("existing"),
+					PublicId:       sonatypeiq.PtrString("sandbox-application"),
+					Name:           sonatypeiq.PtrString("Sandbox Application"),
+					OrganizationId: sonatypeiq.PtrString("ROOT_ORGANIZATION_ID"),
+				}
+			},
+			createError: "Error creating Application",
+		},
+		{
+			name:         "application deleted outside of Terraform",
+			resourceType: "sonatypeiq_application",
+			config:       application,
+			computed:     applicationComputed,
+			path:         "/api/v2/applications/",
+			afterCreate: func(m *mockIqServer, id string) {
+				delete(m.applications, id)
+			},
+			readRemoves: true,
+		},
+		{
+			name:         "application delete server error",
+			resourceType: "sonatypeiq_application",
+			config:       application,
+			computed:     applicationComputed,
+			path:         "/api/v2/applications/",
+			afterCreate: func(m *mockIqServer, id string) {
+				m.fail(http.MethodDelete, "/api/v2/applications/"+id, http.StatusInternalServerError)
+			},
+			deleteError: "Error deleting Application",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			test.run(t)
+		})
+	}
+}
+
+func (test crudTestCase) run(t *testing.T) {
+	ctx := context.Background()
+	m := newMockIqServer(t)
+	if test.setup != nil {
+		test.setup(m)
+	}
+	server, schemas := testProviderServer(t, m)
+	s := schemas[test.resourceType]
+
+	planned := map[string]tftypes.Value{}
+	for name, value := range test.config {
+		planned[name] = value
+	}
+	for _, name := range test.computed {
+		planned[name] = tftypes.NewValue(s.ValueType().(tftypes.Object).AttributeTypes[name], tftypes.UnknownValue)
+	}
+
+	// Create
+	created, err := server.ApplyResourceChange(ctx, &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:     test.resourceType,
+		PriorState:   testDynamicValue(t, s, nil, true),
+		PlannedState: testDynamicValue(t, s, planned, false),
+		Config:       testDynamicValue(t, s, test.config, false),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !checkDiagnostics(t, "create", created.Diagnostics, test.createError) {
+		return
+	}
+	if !m.received(http.MethodPost, strings.TrimSuffix(test.path, "/"), test.createBody) {
+		t.Errorf("No POST %s request with %s was sent", strings.TrimSuffix(test.path, "/"), test.createBody)
+	}
+	state := testStateValue(t, s, created.NewState)
+	var attributes map[string]tftypes.Value
+	var id string
+	if err := state.As(&attributes); err != nil {
+		t.Fatal(err)
+	}
+	if err := attributes["id"].As(&id); err != nil || id == "" {
+		t.Fatalf("Created %s has no ID", test.resourceType)
+	}
+
+	// Read right after the create, which clears the mark that tolerates IQ Server not returning
+	// the entity yet
+	read, err := server.ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+		TypeName:     test.resourceType,
+		CurrentState: created.NewState,
+		Private:      created.Private,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !checkDiagnostics(t, "read", read.Diagnostics, "") {
+		return
+	}
+
+	if test.afterCreate != nil {
+		test.afterCreate(m, id)
+	}
+
+	// Refresh
+	read, err = server.ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+		TypeName:     test.resourceType,
+		CurrentState: read.NewState,
+		Private:      read.Private,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !checkDiagnostics(t, "refresh", read.Diagnostics, "") {
+		return
+	}
+	if removed := testStateValue(t, s, read.NewState).IsNull(); removed != test.readRemoves {
+		t.Fatalf("Refresh removed the resource: %t, expected %t", removed, test.readRemoves)
+	}
+	if test.readRemoves {
+		return
+	}
+
+	// Delete
+	deleted, err := server.ApplyResourceChange(ctx, &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:     test.resourceType,
+		PriorState:   read.NewState,
+		PlannedState: testDynamicValue(t, s, nil, true),
+		Config:       testDynamicValue(t, s, nil, true),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !checkDiagnostics(t, "delete", deleted.Diagnostics, test.deleteError) {
+		return
+	}
+	if !m.received(http.MethodDelete, test.path+id, "") {
+		t.Errorf("No DELETE %s%s request was sent", test.path, id)
+	}
+}
+
+// testProviderServer returns a provider server configured against the mock IQ Server, together
+// with the resource schemas.
+func testProviderServer(t *testing.T, m *mockIqServer) (tfprotov6.ProviderServer, map[string]*tfprotov6.Schema) {
+	return testProviderServerWithConfig(t, m, nil)
+}
+
+// testProviderServerWithConfig is testProviderServer with additional provider attributes.
+func testProviderServerWithConfig(t *testing.T, m *mockIqServer, attributes map[string]tftypes.Value) (tfprotov6.ProviderServer, map[string]*tfprotov6.Schema) {
+	ctx := context.Background()
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schemas, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := map[string]tftypes.Value{
+		"url":                     tftypes.NewValue(tftypes.String, m.URL),
+		"username":                tftypes.NewValue(tftypes.String, "admin"),
+		"password":                tftypes.NewValue(tftypes.String, "admin123"),
+		"skip_connectivity_check": tftypes.NewValue(tftypes.Bool, true),
+		"cache_lookups":           tftypes.NewValue(tftypes.Bool, false),
+		"max_retries":             tftypes.NewValue(tftypes.Number, 0),
+	}
+	for name, value := range attributes {
+		config[name] = value
+	}
+	configured, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{
+		TerraformVersion: "1.9.0",
+		Config:           testDynamicValue(t, schemas.Provider, config, false),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkDiagnostics(t, "configure", configured.Diagnostics, "")
+
+	return server, schemas.ResourceSchemas
+}
+
+// testDynamicValue builds a value of the schema from the given attributes, leaving all other
+// attributes null.
+func testDynamicValue(t *testing.T, s *tfprotov6.Schema, attributes map[string]tftypes.Value, null bool) *tfprotov6.DynamicValue {
+	objectType := s.ValueType().(tftypes.Object)
+	value := tftypes.NewValue(objectType, nil)
+	if !null {
+		values := map[string]tftypes.Value{}
+		for name, attributeType := range objectType.AttributeTypes {
+			values[name] = tftypes.NewValue(attributeType, nil)
+			if attribute, ok := attributes[name]; ok {
+				values[name] = attribute
+			}
+		}
+		value = tftypes.NewValue(objectType, values)
+	}
+
+	dynamicValue, err := tfprotov6.NewDynamicValue(objectType, value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &dynamicValue
+}
+
+func testStateValue(t *testing.T, s *tfprotov6.Schema, state *tfprotov6.DynamicValue) tftypes.Value {
+	value, err := state.Unmarshal(s.ValueType())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return value
+}
+
+// checkDiagnostics reports unexpected errors, and whether the step succeeded as expected so that
+// the test can continue.
+func checkDiagnostics(t *testing.T, step string, diagnostics []*tfprotov6.Diagnostic, expectedError string) bool {
+	var errors []string
+	for _, d := range diagnostics {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			errors = append(errors, d.Summary+": "+d.Detail)
+		}
+	}
+
+	if expectedError == "" {
+		for _, err := range errors {
+			t.Errorf("Unexpected error on %s: %s", step, err)
+		}
+		return len(errors) == 0
+	}
+
+	for _, err := range errors {
+		if strings.Contains(err, expectedError) {
+			return false
+		}
+	}
+	t.Errorf("Expected error %q on %s, got %v", expectedError, step, errors)
+	return false
+}
+
+func TestDeferUnknownOwner(t *testing.T) {
+	ctx := context.Background()
+	server, _ := testProviderServer(t, newMockIqServer(t))
+	schemas, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	unknown := tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+
+	for _, deferralAllowed := range []bool{true, false} {
+		// Resources with and without a plan modification of their own
+		for resourceType, attribute := range map[string]string{
+			"sonatypeiq_application":      "organization_id",
+			"sonatypeiq_source_control":   "application_id",
+			"sonatypeiq_role_memberships": "organization_id",
+		} {
+			s := schemas.ResourceSchemas[resourceType]
+			config := testDynamicValue(t, s, map[string]tftypes.Value{attribute: unknown}, false)
+			planned, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+				TypeName:           resourceType,
+				PriorState:         testDynamicValue(t, s, nil, true),
+				ProposedNewState:   config,
+				Config:             config,
+				ClientCapabilities: &tfprotov6.PlanResourceChangeClientCapabilities{DeferralAllowed: deferralAllowed},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkDiagnostics(t, "plan of "+resourceType, planned.Diagnostics, "")
+			if deferred := planned.Deferred != nil; deferred != deferralAllowed {
+				t.Errorf("Expected planning %s with an unknown %s to be deferred: %t, got %t", resourceType, attribute, deferralAllowed, deferred)
+			}
+		}
+
+		s := schemas.DataSourceSchemas["sonatypeiq_source_control"]
+		read, err := server.ReadDataSource(ctx, &tfprotov6.ReadDataSourceRequest{
+			TypeName:           "sonatypeiq_source_control",
+			Config:             testDynamicValue(t, s, map[string]tftypes.Value{"organization_id": unknown}, false),
+			ClientCapabilities: &tfprotov6.ReadDataSourceClientCapabilities{DeferralAllowed: deferralAllowed},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if deferralAllowed {
+			checkDiagnostics(t, "read of sonatypeiq_source_control", read.Diagnostics, "")
+			if read.Deferred == nil {
+				t.Error("Expected reading sonatypeiq_source_control with an unknown organization_id to be deferred")
+			}
+		} else {
+			checkDiagnostics(t, "read of sonatypeiq_source_control", read.Diagnostics, "Unknown Owner")
+		}
+	}
+}
+
+func TestDeferUnknownProviderConfiguration(t *testing.T) {
+	ctx := context.Background()
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
+		t.Fatal(err)
+	}
+	schemas, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Any attribute may depend on resources created in the same run, not only the connection
+	for _, attribute := range []string{"url", "insecure_skip_verify", "max_retries", "request_timeout", "cache_lookups"} {
+		attributeType := schemas.Provider.ValueType().(tftypes.Object).AttributeTypes[attribute]
+		configured, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{
+			TerraformVersion: "1.9.0",
+			Config: testDynamicValue(t, schemas.Provider, map[string]tftypes.Value{
+				"url":                     tftypes.NewValue(tftypes.String, "https://iq.example.com"),
+				"skip_connectivity_check": tftypes.NewValue(tftypes.Bool, true),
+				attribute:                 tftypes.NewValue(attributeType, tftypes.UnknownValue),
+			}, false),
+			ClientCapabilities: &tfprotov6.ConfigureProviderClientCapabilities{DeferralAllowed: true},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkDiagnostics(t, "configure", configured.Diagnostics, "")
+
+		// The framework defers every request once the provider deferred its configuration
+		s := schemas.ResourceSchemas["sonatypeiq_organization"]
+		config := testDynamicValue(t, s, map[string]tftypes.Value{
+			"name": tftypes.NewValue(tftypes.String, "Sandbox Organization"),
+		}, false)
+		planned, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+			TypeName:           "sonatypeiq_organization",
+			PriorState:         testDynamicValue(t, s, nil, true),
+			ProposedNewState:   config,
+			Config:             config,
+			ClientCapabilities: &tfprotov6.PlanResourceChangeClientCapabilities{DeferralAllowed: true},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if planned.Deferred == nil || planned.Deferred.Reason != tfprotov6.DeferredReasonProviderConfigUnknown {
+			t.Errorf("Expected configuring the provider with an unknown %s to be deferred, got %v", attribute, planned.Deferred)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+const (
+	defaultMaxRetries   = 3
+	defaultRetryWaitMin = 1 * time.Second
+	defaultRetryWaitMax = 30 * time.Second
+)
+
+// retryTransport retries requests that failed because IQ Server was (temporarily) unable to
+// serve them, waiting with jittered exponential backoff between attempts. Requests that may change
+// IQ Server are only retried when they cannot have been processed, so that e.g. a Policy Waiver is
+// not created twice when the connection drops after IQ Server committed it.
+type retryTransport struct {
+	next         http.RoundTripper
+	maxRetries   int
+	retryWaitMin time.Duration
+	retryWaitMax time.Duration
+
+	// metrics counts the retries - nil unless a metrics file is configured
+	metrics *apiMetrics
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	idempotent := req.Method == http.MethodGet || req.Method == http.MethodHead || markedOnlyReads(req)
+
+	attemptReq := req
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 {
+			// Requests are not modified, so every retry is sent as a clone with a fresh body
+			attemptReq = req.Clone(req.Context())
+			if req.Body != nil && req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				attemptReq.Body = body
+			}
+		}
+
+		resp, err := t.next.RoundTrip(attemptReq)
+
+		if attempt >= t.maxRetries || !shouldRetry(resp, err, idempotent) {
+			return resp, err
+		}
+		// Requests with a body that cannot be replayed are not retried
+		if req.Body != nil && req.GetBody == nil {
+			return resp, err
+		}
+
+		wait := t.backoff(attempt, resp)
+		if resp != nil {
+			// Drain the body so the connection can be reused
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(wait):
+		}
+		t.metrics.recordRetry(req)
+	}
+}
+
+// shouldRetry reports whether a request should be retried based on its outcome. Requests that are
+// not idempotent are only retried when IQ Server provably did not process them: the connection
+// could not be established, or IQ Server turned them away as unavailable or throttled.
+func shouldRetry(resp *http.Response, err error, idempotent bool) bool {
+	if err != nil {
+		var opErr *net.OpError
+		if (errors.As(err, &opErr) && (opErr.Op == "dial" || opErr.Op == "proxyconnect")) ||
+			errors.Is(err, syscall.ECONNREFUSED) {
+			return true
+		}
+		if !idempotent {
+			return false
+		}
+
+		var netErr net.Error
+		if errors.As(err, &netErr) && netErr.Timeout() {
+			return true
+		}
+		return errors.Is(err, syscall.ECONNRESET) ||
+			errors.Is(err, io.ErrUnexpectedEOF) ||
+			errors.Is(err, io.EOF)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests,
+		http.StatusServiceUnavailable:
+		return true
+	case http.StatusBadGateway,
+		http.StatusGatewayTimeout:
+		return idempotent
+	}
+	return false
+}
+
+// backoff returns the time to wait before the next attempt, honoring a Retry-After header
+// when IQ Server (or a proxy in front of it) supplies one.
+func (t *retryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+			return min(time.Duration(seconds)*time.Second, t.retryWaitMax)
+		}
+	}
+
+	wait := time.Duration(float64(t.retryWaitMin) * math.Pow(2, float64(attempt)))
+	if wait <= 0 || wait > t.retryWaitMax {
+		wait = t.retryWaitMax
+	}
+	// Full jitter between retryWaitMin and the exponential backoff
+	if wait > t.retryWaitMin {
+		wait = t.retryWaitMin + time.Duration(rand.Int63n(int64(wait-t.retryWaitMin)))
+	}
+	return wait
+}
@@ -0,0 +1,120 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// newRetryTestClient returns a client that retries without waiting against the handler, along with
+// the number of requests the handler received.
+func newRetryTestClient(t *testing.T, handler func(w http.ResponseWriter, attempt int32)) (*http.Client, string, *atomic.Int32) {
+	var received atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		handler(w, received.Add(1))
+	}))
+	t.Cleanup(server.Close)
+
+	client, diags := newHttpClient(SonatypeIqProviderModel{
+		Url:          types.StringValue(server.URL),
+		CacheLookups: types.BoolValue(false),
+		RetryWaitMin: types.Int64Value(0),
+		RetryWaitMax: types.Int64Value(0),
+	})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	return client, server.URL, &received
+}
+
+// badGatewayOnce responds with a 502 to the first attempt only.
+func badGatewayOnce(w http.ResponseWriter, attempt int32) {
+	if attempt == 1 {
+		w.WriteHeader(http.StatusBadGateway)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// dropConnectionOnce closes the connection without responding to the first attempt only, as
+// happens when the connection drops after IQ Server processed a request.
+func dropConnectionOnce(w http.ResponseWriter, attempt int32) {
+	if attempt == 1 {
+		conn, _, _ := w.(http.Hijacker).Hijack()
+		conn.Close()
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func TestRetryTransportRetriesReads(t *testing.T) {
+	for name, handler := range map[string]func(http.ResponseWriter, int32){
+		"bad gateway":        badGatewayOnce,
+		"dropped connection": dropConnectionOnce,
+	} {
+		client, url, received := newRetryTestClient(t, handler)
+		resp, err := client.Get(url + "/api/v2/organizations")
+		if err != nil {
+			t.Fatalf("%s: expected GET to be retried, got: %s", name, err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK || received.Load() != 2 {
+			t.Errorf("%s: expected GET to succeed on the second attempt, got %d after %d attempts", name, resp.StatusCode, received.Load())
+		}
+	}
+}
+
+func TestRetryTransportDoesNotRepeatChanges(t *testing.T) {
+	for name, handler := range map[string]func(http.ResponseWriter, int32){
+		"bad gateway":        badGatewayOnce,
+		"dropped connection": dropConnectionOnce,
+	} {
+		client, url, received := newRetryTestClient(t, handler)
+		resp, err := client.Post(url+"/api/v2/organizations", "application/json", strings.NewReader(`{"name":"Sandbox Organization"}`))
+		if err == nil {
+			resp.Body.Close()
+		}
+		if received.Load() != 1 {
+			t.Errorf("%s: expected POST not to be retried, got %d attempts", name, received.Load())
+		}
+	}
+}
+
+func TestRetryTransportRetriesUnprocessedChanges(t *testing.T) {
+	client, url, received := newRetryTestClient(t, func(w http.ResponseWriter, attempt int32) {
+		if attempt == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	resp, err := client.Post(url+"/api/v2/organizations", "application/json", strings.NewReader(`{"name":"Sandbox Organization"}`))
+	if err != nil {
+		t.Fatalf("expected POST to be retried, got: %s", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || received.Load() != 2 {
+		t.Errorf("expected POST to succeed on the second attempt, got %d after %d attempts", resp.StatusCode, received.Load())
+	}
+}
@@ -82,11 +82,11 @@ func (d *roleDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 		d.auth,
 	)
 
-	roleList, _, err := d.client.RolesAPI.GetRoles(ctx).Execute()
+	roleList, api_response, err := d.client.RolesAPI.GetRoles(ctx).Execute()
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read IQ Roles",
-			err.Error(),
+			describeApiError(api_response, err),
 		)
 		return
 	}
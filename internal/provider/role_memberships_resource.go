@@ -0,0 +1,605 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	sonatypeiq "github.com/sonatype-nexus-community/nexus-iq-api-client-go"
+)
+
+// roleMembershipsResource is the resource implementation.
+type roleMembershipsResource struct {
+	baseResource
+}
+
+type roleMembershipsModelResource struct {
+	ID             types.String                `tfsdk:"id"`
+	OrganizationId types.String                `tfsdk:"organization_id"`
+	ApplicationId  types.String                `tfsdk:"application_id"`
+	Roles          map[string]roleMembersModel `tfsdk:"roles"`
+
+	IgnoreMemberNameCase types.Bool `tfsdk:"ignore_member_name_case"`
+}
+
+type roleMembersModel struct {
+	Users  types.Set `tfsdk:"users"`
+	Groups types.Set `tfsdk:"groups"`
+}
+
+// roleMember is a single grant of a Role to a user or group.
+type roleMember struct {
+	roleId     string
+	memberType string
+	memberName string
+}
+
+// owner returns the type and internal ID of the Organization or Application the Role Memberships
+// belong to. The config validator makes sure that exactly one of these is configured.
+func (m *roleMembershipsModelResource) owner() (string, string) {
+	if !m.ApplicationId.IsNull() {
+		return "application", m.ApplicationId.ValueString()
+	}
+	return "organization", m.OrganizationId.ValueString()
+}
+
+// members flattens the Roles of the model into the individual grants, using roleIds to resolve
+// the Role names or IDs that are used as keys.
+func (m *roleMembershipsModelResource) members(ctx context.Context, roleIds map[string]string) (map[roleMember]bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	members := map[roleMember]bool{}
+	for role, roleMembers := range m.Roles {
+		for memberType, names := range map[string]types.Set{"user": roleMembers.Users, "group": roleMembers.Groups} {
+			var memberNames []string
+			diags.Append(names.ElementsAs(ctx, &memberNames, false)...)
+			for _, memberName := range memberNames {
+				members[roleMember{roleId: roleIds[role], memberType: memberType, memberName: memberName}] = true
+			}
+		}
+	}
+	return members, diags
+}
+
+// NewRoleMembershipsResource is a helper function to simplify the provider implementation.
+func NewRoleMembershipsResource() resource.Resource {
+	return &roleMembershipsResource{}
+}
+
+// Metadata returns the resource type name.
+func (r *roleMembershipsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_role_memberships"
+}
+
+// Schema defines the schema for the resource.
+func (r *roleMembershipsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	emptySet := types.SetValueMust(types.StringType, []attr.Value{})
+
+	resp.Schema = schema.Schema{
+		Description: "Manages all members of one or more Roles of an Organization or Application. Members of the listed Roles that are not configured are revoked, Roles that are not listed are left alone.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"organization_id": schema.StringAttribute{
+				Description: "Internal ID of the Organization - use 'ROOT_ORGANIZATION_ID' for the Root Organization",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					organizationIdValidator,
+				},
+			},
+			"application_id": schema.StringAttribute{
+				Description: "Internal ID or public ID of the Application",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"roles": schema.MapNestedAttribute{
+				Description: "Members per Role, keyed by Role name or ID",
+				Required:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"users": schema.SetAttribute{
+							Description: "Names of the users that are granted the Role",
+							Optional:    true,
+							Computed:    true,
+							ElementType: types.StringType,
+							Default:     setdefault.StaticValue(emptySet),
+						},
+						"groups": schema.SetAttribute{
+							Description: "Names of the groups that are granted the Role",
+							Optional:    true,
+							Computed:    true,
+							ElementType: types.StringType,
+							Default:     setdefault.StaticValue(emptySet),
+						},
+					},
+				},
+			},
+			"ignore_member_name_case": schema.BoolAttribute{
+				Description: "Whether user and group names are matched case-insensitively, for realms such as LDAP and SAML that may return names with a different casing. Defaults to false",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+// ValidateConfig checks that the Roles exist when validate_roles is enabled on the provider.
+func (r *roleMembershipsResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var roles types.Map
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("roles"), &roles)...)
+	if resp.Diagnostics.HasError() || roles.IsNull() || roles.IsUnknown() {
+		return
+	}
+
+	paths := map[string]path.Path{}
+	for role := range roles.Elements() {
+		paths[role] = path.Root("roles").AtMapKey(role)
+	}
+	resp.Diagnostics.Append(r.checkRoles(ctx, paths)...)
+}
+
+func (r *roleMembershipsResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("organization_id"),
+			path.MatchRoot("application_id"),
+		),
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *roleMembershipsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan roleMembershipsModelResource
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = context.WithValue(
+		ctx,
+		sonatypeiq.ContextBasicAuth,
+		r.auth,
+	)
+
+	roleIds, diags := r.resolveRoles(ctx, plan.Roles)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	members, diags := plan.members(ctx, roleIds)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ownerType, ownerId := plan.owner()
+	plan.ID = types.StringValue(formatEscapedId(ownerType, ownerId))
+
+	ownerType, ownerId, diags = r.resolveOwner(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The listed Roles may already have members that are not configured, these are revoked
+	current, diags := r.grantedMembers(ctx, ownerType, ownerId, roleIds, members, plan.IgnoreMemberNameCase.ValueBool())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(r.reconcile(ctx, ownerType, ownerId, current, members)...)
+
+	// IQ Server may not return the new entity straight away
+	resp.Diagnostics.Append(markCreated(ctx, resp.Private)...)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *roleMembershipsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state roleMembershipsModelResource
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = context.WithValue(
+		ctx,
+		sonatypeiq.ContextBasicAuth,
+		r.auth,
+	)
+
+	ownerType, ownerId, diags := r.resolveOwner(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var roleMemberships *sonatypeiq.ApiRoleMemberMappingListDTO
+	var apiResponse *http.Response
+	var err error
+	resp.Diagnostics.Append(readConsistently(ctx, req.Private, resp.Private, func(ctx context.Context) bool {
+		roleMemberships, apiResponse, err = r.client.RoleMembershipsAPI.GetRoleMembershipsApplicationOrOrganization(ctx, ownerType, ownerId).Execute()
+		return isNotFound(apiResponse)
+	})...)
+	if err != nil {
+		if isNotFound(apiResponse) {
+			resp.State.RemoveResource(ctx)
+		} else {
+			resp.Diagnostics.AddError(
+				"Error Reading IQ role memberships",
+				apiErrorDetail(fmt.Sprintf("Could not read role memberships of %s %s", ownerType, ownerId), apiResponse, err),
+			)
+		}
+		return
+	}
+
+	// Only members that are granted the Role on the owner itself are managed, inherited members are ignored
+	granted := map[string]map[string][]string{}
+	for _, roleMembership := range roleMemberships.MemberMappings {
+		for _, member := range roleMembership.Members {
+			if !strings.EqualFold(member.GetOwnerType(), ownerType) || member.GetOwnerId() != ownerId {
+				continue
+			}
+			if granted[roleMembership.GetRoleId()] == nil {
+				granted[roleMembership.GetRoleId()] = map[string][]string{}
+			}
+			memberType := strings.ToLower(member.GetType())
+			granted[roleMembership.GetRoleId()][memberType] = append(granted[roleMembership.GetRoleId()][memberType], member.GetUserOrGroupName())
+		}
+	}
+
+	// After import all Roles with members are managed, keyed by their ID
+	if state.Roles == nil {
+		state.Roles = map[string]roleMembersModel{}
+		for roleId := range granted {
+			state.Roles[roleId] = roleMembersModel{}
+		}
+	}
+
+	roleIds, diags := r.resolveRoles(ctx, state.Roles)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for role := range state.Roles {
+		users, diags := matchMemberNames(ctx, granted[roleIds[role]]["user"], state.Roles[role].Users, state.IgnoreMemberNameCase.ValueBool())
+		resp.Diagnostics.Append(diags...)
+		groups, diags := matchMemberNames(ctx, granted[roleIds[role]]["group"], state.Roles[role].Groups, state.IgnoreMemberNameCase.ValueBool())
+		resp.Diagnostics.Append(diags...)
+		state.Roles[role] = roleMembersModel{Users: users, Groups: groups}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *roleMembershipsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan roleMembershipsModelResource
+	var state roleMembershipsModelResource
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = context.WithValue(
+		ctx,
+		sonatypeiq.ContextBasicAuth,
+		r.auth,
+	)
+
+	roles := map[string]roleMembersModel{}
+	for role, members := range state.Roles {
+		roles[role] = members
+	}
+	for role, members := range plan.Roles {
+		roles[role] = members
+	}
+	roleIds, diags := r.resolveRoles(ctx, roles)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, diags := state.members(ctx, roleIds)
+	resp.Diagnostics.Append(diags...)
+	desired, diags := plan.members(ctx, roleIds)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ownerType, ownerId, diags := r.resolveOwner(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(r.reconcile(ctx, ownerType, ownerId, current, desired)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *roleMembershipsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state roleMembershipsModelResource
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = context.WithValue(
+		ctx,
+		sonatypeiq.ContextBasicAuth,
+		r.auth,
+	)
+
+	roleIds, diags := r.resolveRoles(ctx, state.Roles)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, diags := state.members(ctx, roleIds)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ownerType, ownerId, diags := r.resolveOwner(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(r.reconcile(ctx, ownerType, ownerId, current, nil)...)
+}
+
+// ImportState imports all Role Memberships of an owner by an ID of the form
+// <organization|application>/<id>, with each part URL-escaped.
+func (r *roleMembershipsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts, err := parseEscapedId(req.ID, "owner_type", "owner_id")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid role memberships ID", err.Error())
+		return
+	}
+
+	switch parts[0] {
+	case "organization":
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("organization_id"), parts[1])...)
+	case "application":
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("application_id"), parts[1])...)
+	default:
+		resp.Diagnostics.AddError(
+			"Invalid role memberships ID",
+			fmt.Sprintf("The owner type of ID %s must be either organization or application, got: %s", req.ID, parts[0]),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), formatEscapedId(parts...))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("ignore_member_name_case"), false)...)
+	resp.Diagnostics.Append(markCreated(ctx, resp.Private)...)
+}
+
+// resolveOwner returns the type and internal ID of the owner, resolving the public ID of an
+// Application to its internal ID.
+func (r *roleMembershipsResource) resolveOwner(ctx context.Context, m *roleMembershipsModelResource) (string, string, diag.Diagnostics) {
+	ownerType, ownerId := m.owner()
+	if ownerType != "application" {
+		return ownerType, ownerId, nil
+	}
+	ownerId, diags := resolveApplicationId(ctx, r.client, ownerId)
+	return ownerType, ownerId, diags
+}
+
+// resolveRoles maps the keys of roles, which are either Role IDs or names, to Role IDs.
+func (r *roleMembershipsResource) resolveRoles(ctx context.Context, roles map[string]roleMembersModel) (map[string]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if len(roles) == 0 {
+		return map[string]string{}, diags
+	}
+
+	roleList, apiResponse, err := r.client.RolesAPI.GetRoles(ctx).Execute()
+	if err != nil {
+		diags.AddError("Unable to Read IQ Roles", describeApiError(apiResponse, err))
+		return nil, diags
+	}
+
+	roleIds := map[string]string{}
+	for role := range roles {
+		for _, candidate := range roleList.Roles {
+			if candidate.GetId() == role {
+				roleIds[role] = role
+				break
+			}
+			if candidate.GetName() == role {
+				roleIds[role] = candidate.GetId()
+			}
+		}
+		if _, ok := roleIds[role]; !ok {
+			diags.AddAttributeError(
+				path.Root("roles").AtMapKey(role),
+				"Unknown Role",
+				fmt.Sprintf("No Role with name or ID '%s' exists", role),
+			)
+		}
+	}
+	return roleIds, diags
+}
+
+// grantedMembers returns the members that are granted one of the given Roles on the owner itself.
+// Members inherited from a parent Organization are left out, as they cannot be revoked on the
+// owner. Names that match a desired member when ignoreCase is set take its casing.
+func (r *roleMembershipsResource) grantedMembers(ctx context.Context, ownerType string, ownerId string, roleIds map[string]string, desired map[roleMember]bool, ignoreCase bool) (map[roleMember]bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	roleMemberships, apiResponse, err := r.client.RoleMembershipsAPI.GetRoleMembershipsApplicationOrOrganization(ctx, ownerType, ownerId).Execute()
+	if err != nil {
+		diags.AddError(
+			"Error Reading IQ role memberships",
+			apiErrorDetail(fmt.Sprintf("Could not read role memberships of %s %s", ownerType, ownerId), apiResponse, err),
+		)
+		return nil, diags
+	}
+
+	managed := map[string]bool{}
+	for _, roleId := range roleIds {
+		managed[roleId] = true
+	}
+
+	granted := map[roleMember]bool{}
+	for _, roleMembership := range roleMemberships.MemberMappings {
+		if !managed[roleMembership.GetRoleId()] {
+			continue
+		}
+		for _, member := range roleMembership.Members {
+			if !strings.EqualFold(member.GetOwnerType(), ownerType) || member.GetOwnerId() != ownerId {
+				continue
+			}
+			granted[matchRoleMember(roleMember{
+				roleId:     roleMembership.GetRoleId(),
+				memberType: strings.ToLower(member.GetType()),
+				memberName: member.GetUserOrGroupName(),
+			}, desired, ignoreCase)] = true
+		}
+	}
+	return granted, diags
+}
+
+// matchRoleMember returns the desired member that matches the granted member, or the granted
+// member itself when none matches.
+func matchRoleMember(granted roleMember, desired map[roleMember]bool, ignoreCase bool) roleMember {
+	for member := range desired {
+		if member.roleId == granted.roleId && member.memberType == granted.memberType && memberNameMatches(granted.memberName, member.memberName, ignoreCase) {
+			return member
+		}
+	}
+	return granted
+}
+
+// reconcile revokes the members that are current but no longer desired, and grants the members
+// that are desired but not yet current. All changes are attempted, even when some of them fail.
+func (r *roleMembershipsResource) reconcile(ctx context.Context, ownerType string, ownerId string, current map[roleMember]bool, desired map[roleMember]bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, member := range sortedRoleMembers(current) {
+		if desired[member] {
+			continue
+		}
+		apiRequest := r.client.RoleMembershipsAPI.RevokeRoleMembershipApplicationOrOrganization(ctx, ownerType, ownerId, member.roleId, member.memberType, member.memberName)
+		apiResponse, err := r.client.RoleMembershipsAPI.RevokeRoleMembershipApplicationOrOrganizationExecute(apiRequest)
+		if err != nil && !isNotFound(apiResponse) {
+			diags.AddError(
+				"Error revoking role membership",
+				apiErrorDetail(fmt.Sprintf("Could not revoke role %s from %s %s", member.roleId, member.memberType, member.memberName), apiResponse, err),
+			)
+		}
+	}
+	for _, member := range sortedRoleMembers(desired) {
+		if current[member] {
+			continue
+		}
+		apiRequest := r.client.RoleMembershipsAPI.GrantRoleMembershipApplicationOrOrganization(ctx, ownerType, ownerId, member.roleId, member.memberType, member.memberName)
+		apiResponse, err := r.client.RoleMembershipsAPI.GrantRoleMembershipApplicationOrOrganizationExecute(apiRequest)
+		if err != nil {
+			diags.AddError(
+				"Error granting role membership",
+				apiErrorDetail(fmt.Sprintf("Could not grant role %s to %s %s", member.roleId, member.memberType, member.memberName), apiResponse, err),
+			)
+		}
+	}
+	return diags
+}
+
+// matchMemberNames returns the names of the members returned by IQ Server as a set. Names that
+// only differ in casing from a name in the prior state keep the casing of the prior state when
+// ignoreCase is set, so that they do not show up as a difference.
+func matchMemberNames(ctx context.Context, actual []string, prior types.Set, ignoreCase bool) (types.Set, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var configured []string
+	if !prior.IsNull() && !prior.IsUnknown() {
+		diags.Append(prior.ElementsAs(ctx, &configured, false)...)
+	}
+
+	names := make([]string, 0, len(actual))
+	seen := map[string]bool{}
+	for _, name := range actual {
+		for _, candidate := range configured {
+			if memberNameMatches(name, candidate, ignoreCase) {
+				name = candidate
+				break
+			}
+		}
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+
+	set, setDiags := types.SetValueFrom(ctx, types.StringType, names)
+	diags.Append(setDiags...)
+	return set, diags
+}
+
+// sortedRoleMembers returns the members in a stable order, so that API calls are made in the
+// same order on every run.
+func sortedRoleMembers(members map[roleMember]bool) []roleMember {
+	sorted := make([]roleMember, 0, len(members))
+	for member := range members {
+		sorted = append(sorted, member)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if a.roleId != b.roleId {
+			return a.roleId < b.roleId
+		}
+		if a.memberType != b.memberType {
+			return a.memberType < b.memberType
+		}
+		return a.memberName < b.memberName
+	})
+	return sorted
+}
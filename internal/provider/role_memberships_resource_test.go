@@ -0,0 +1,170 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	sonatypeiq "github.com/sonatype-nexus-community/nexus-iq-api-client-go"
+)
+
+func TestAccRoleMembershipsResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccSandbox(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccRoleMembershipsResourceConfig(`
+					"Developer" = {
+						users = [sonatypeiq_user.user.username]
+					}
+				`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("sonatypeiq_role_memberships.test", "id"),
+					resource.TestCheckResourceAttr("sonatypeiq_role_memberships.test", "roles.Developer.users.#", "1"),
+					resource.TestCheckResourceAttr("sonatypeiq_role_memberships.test", "roles.Developer.users.0", "role-memberships"),
+					resource.TestCheckResourceAttr("sonatypeiq_role_memberships.test", "roles.Developer.groups.#", "0"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccRoleMembershipsResourceConfig(`
+					"Developer" = {}
+					"Owner" = {
+						users = [sonatypeiq_user.user.username]
+					}
+				`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("sonatypeiq_role_memberships.test", "roles.Developer.users.#", "0"),
+					resource.TestCheckResourceAttr("sonatypeiq_role_memberships.test", "roles.Owner.users.#", "1"),
+				),
+			},
+			// Unknown Role
+			{
+				Config: testAccRoleMembershipsResourceConfig(`
+					"Does Not Exist" = {
+						users = [sonatypeiq_user.user.username]
+					}
+				`),
+				ExpectError: regexp.MustCompile("Unknown Role"),
+			},
+		},
+	})
+}
+
+func testAccRoleMembershipsResourceConfig(roles string) string {
+	return fmt.Sprintf(providerConfig+`
+data "sonatypeiq_application" "sandbox" {
+  public_id = "sandbox-application"
+}
+
+resource "sonatypeiq_user" "user" {
+  username   = "role-memberships"
+  password   = "randomthing"
+  first_name = "Example"
+  last_name  = "User"
+  email      = "role-memberships@user.tld"
+}
+
+resource "sonatypeiq_role_memberships" "test" {
+  application_id = data.sonatypeiq_application.sandbox.id
+  roles = {
+    %s
+  }
+}
+`, roles)
+}
+
+func TestRoleMembershipsResourceCreateRevokesExistingMembers(t *testing.T) {
+	const roleId = "1cddabf7fdaa47d6833454af10e0a3ef"
+	ctx := context.Background()
+	m := newMockIqServer(t)
+	m.roles = []sonatypeiq.ApiRoleDTO{{Id: sonatypeiq.PtrString(roleId), Name: sonatypeiq.PtrString("Developer")}}
+	m.roleMemberships["organization/ROOT_ORGANIZATION_ID"] = []sonatypeiq.ApiRoleMemberMappingDTO{{
+		RoleId: sonatypeiq.PtrString(roleId),
+		Members: []sonatypeiq.ApiMemberDTO{
+			{
+				OwnerType:       sonatypeiq.PtrString("ORGANIZATION"),
+				OwnerId:         sonatypeiq.PtrString("ROOT_ORGANIZATION_ID"),
+				Type:            sonatypeiq.PtrString("USER"),
+				UserOrGroupName: sonatypeiq.PtrString("alice"),
+			},
+			{
+				OwnerType:       sonatypeiq.PtrString("ORGANIZATION"),
+				OwnerId:         sonatypeiq.PtrString("ROOT_ORGANIZATION_ID"),
+				Type:            sonatypeiq.PtrString("USER"),
+				UserOrGroupName: sonatypeiq.PtrString("Bob"),
+			},
+		},
+	}}
+	server, schemas := testProviderServer(t, m)
+
+	s := schemas["sonatypeiq_role_memberships"]
+	rolesType := s.ValueType().(tftypes.Object).AttributeTypes["roles"].(tftypes.Map)
+	membersType := rolesType.ElementType.(tftypes.Object)
+	users := tftypes.NewValue(membersType.AttributeTypes["users"], []tftypes.Value{
+		tftypes.NewValue(tftypes.String, "bob"),
+		tftypes.NewValue(tftypes.String, "carol"),
+	})
+	config := map[string]tftypes.Value{
+		"organization_id": tftypes.NewValue(tftypes.String, "ROOT_ORGANIZATION_ID"),
+		"roles": tftypes.NewValue(rolesType, map[string]tftypes.Value{
+			"Developer": tftypes.NewValue(membersType, map[string]tftypes.Value{
+				"users":  users,
+				"groups": tftypes.NewValue(membersType.AttributeTypes["groups"], []tftypes.Value{}),
+			}),
+		}),
+		"ignore_member_name_case": tftypes.NewValue(tftypes.Bool, true),
+	}
+	planned := map[string]tftypes.Value{
+		"id": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+	}
+	for name, value := range config {
+		planned[name] = value
+	}
+
+	created, err := server.ApplyResourceChange(ctx, &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:     "sonatypeiq_role_memberships",
+		PriorState:   testDynamicValue(t, s, nil, true),
+		PlannedState: testDynamicValue(t, s, planned, false),
+		Config:       testDynamicValue(t, s, config, false),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkDiagnostics(t, "create", created.Diagnostics, "")
+
+	path := "/api/v2/roleMemberships/organization/ROOT_ORGANIZATION_ID/role/" + roleId + "/user/"
+	if !m.received(http.MethodDelete, path+"alice", "") {
+		t.Error("Expected the unconfigured member alice to be revoked")
+	}
+	if m.received(http.MethodDelete, path+"Bob", "") || m.received(http.MethodPut, path+"bob", "") {
+		t.Error("Expected the member Bob to be kept, as names are matched case-insensitively")
+	}
+	if !m.received(http.MethodPut, path+"carol", "") {
+		t.Error("Expected the member carol to be granted")
+	}
+}
@@ -0,0 +1,131 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	sonatypeiq "github.com/sonatype-nexus-community/nexus-iq-api-client-go"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &rootOrganizationDataSource{}
+	_ datasource.DataSourceWithConfigure = &rootOrganizationDataSource{}
+)
+
+// RootOrganizationDataSource is a helper function to simplify the provider implementation.
+func RootOrganizationDataSource() datasource.DataSource {
+	return &rootOrganizationDataSource{}
+}
+
+// rootOrganizationDataSource is the data source implementation.
+type rootOrganizationDataSource struct {
+	baseDataSource
+}
+
+type rootOrganizationModel struct {
+	ID   types.String `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+	Tags []tagModel   `tfsdk:"tags"`
+}
+
+// Metadata returns the data source type name.
+func (d *rootOrganizationDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_root_organization"
+}
+
+// Schema defines the schema for the data source.
+func (d *rootOrganizationDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Use this data source to get the Root Organization, e.g. to refer to its ID rather than hardcoding 'ROOT_ORGANIZATION_ID'",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Internal ID of the Root Organization, to use wherever an Organization ID is expected",
+				Computed:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of the Root Organization",
+				Computed:    true,
+			},
+			"tags": schema.ListNestedAttribute{
+				Description: "List of Tags associated to the Root Organization",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Internal ID of the Tag",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Name of the Tag",
+							Computed:    true,
+						},
+						"description": schema.StringAttribute{
+							Description: "Description of the Tag",
+							Computed:    true,
+						},
+						"color": schema.StringAttribute{
+							Description: "Color of the Tag",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *rootOrganizationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = context.WithValue(
+		ctx,
+		sonatypeiq.ContextBasicAuth,
+		d.auth,
+	)
+
+	// Reading the Root Organization verifies the ID is understood by this IQ Server
+	org, api_response, err := d.client.OrganizationsAPI.GetOrganization(ctx, rootOrganizationId).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read IQ Root Organization",
+			describeApiError(api_response, err),
+		)
+		return
+	}
+
+	data := rootOrganizationModel{
+		ID:   types.StringValue(org.GetId()),
+		Name: types.StringPointerValue(org.Name),
+		Tags: []tagModel{},
+	}
+	for _, tag := range org.Tags {
+		data.Tags = append(data.Tags, tagModel{
+			ID:          types.StringPointerValue(tag.Id),
+			Name:        types.StringPointerValue(tag.Name),
+			Description: types.StringPointerValue(tag.Description),
+			Color:       types.StringPointerValue(tag.Color),
+		})
+	}
+
+	// Set state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
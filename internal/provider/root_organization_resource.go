@@ -0,0 +1,547 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	sonatypeiq "github.com/sonatype-nexus-community/nexus-iq-api-client-go"
+)
+
+// rootOrganizationId is the internal ID of the Root Organization, which every IQ Server has.
+const rootOrganizationId = "ROOT_ORGANIZATION_ID"
+
+// privateKeyImported is the private state key marking a resource that was just imported, so that
+// the next Read refreshes all configuration rather than only the configuration managed so far.
+const privateKeyImported = "imported"
+
+// rootOrganizationResource is the resource implementation.
+type rootOrganizationResource struct {
+	baseResource
+}
+
+type rootOrganizationModelResource struct {
+	ID                    types.String                        `tfsdk:"id"`
+	SourceControl         *rootOrganizationSourceControlModel `tfsdk:"source_control"`
+	SourceControlToken    types.String                        `tfsdk:"source_control_token"`
+	TokenVersion          types.Int64                         `tfsdk:"source_control_token_version"`
+	ApplicationCategories []rootOrganizationCategoryModel     `tfsdk:"application_categories"`
+	LastUpdated           types.String                        `tfsdk:"last_updated"`
+	Timeouts              *timeoutsModel                      `tfsdk:"timeouts"`
+}
+
+type rootOrganizationSourceControlModel struct {
+	Provider                        types.String `tfsdk:"scm_provider"`
+	BaseBranch                      types.String `tfsdk:"base_branch"`
+	Username                        types.String `tfsdk:"username"`
+	RemediationPullRequestsEnabled  types.Bool   `tfsdk:"remediation_pull_requests_enabled"`
+	PullRequestCommentingEnabled    types.Bool   `tfsdk:"pull_request_commenting_enabled"`
+	SourceControlEvaluationsEnabled types.Bool   `tfsdk:"source_control_evaluations_enabled"`
+	CommitStatusEnabled             types.Bool   `tfsdk:"commit_status_enabled"`
+	StatusChecksEnabled             types.Bool   `tfsdk:"status_checks_enabled"`
+	SshEnabled                      types.Bool   `tfsdk:"ssh_enabled"`
+}
+
+type rootOrganizationCategoryModel struct {
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	Color       types.String `tfsdk:"color"`
+}
+
+// NewRootOrganizationResource is a helper function to simplify the provider implementation.
+func NewRootOrganizationResource() resource.Resource {
+	return &rootOrganizationResource{}
+}
+
+// Metadata returns the resource type name.
+func (r *rootOrganizationResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_root_organization"
+}
+
+// Schema defines the schema for the resource.
+func (r *rootOrganizationResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Use this resource to manage the configuration of the Root Organization, which all Organizations and Applications inherit from. The Root Organization itself always exists: destroying this resource only deletes its Source Control configuration, when managed",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Internal ID of the Root Organization",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"source_control": schema.SingleNestedAttribute{
+				Description: "Source Control defaults of the Root Organization - removing it deletes the configuration. Do not combine with a sonatypeiq_source_control resource for the Root Organization",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"scm_provider": schema.StringAttribute{
+						Description: "SCM provider, one of " + strings.Join(scmProviders, ", "),
+						Required:    true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(scmProviders...),
+						},
+					},
+					"base_branch": schema.StringAttribute{
+						Description: "Default branch",
+						Required:    true,
+					},
+					"username": schema.StringAttribute{
+						Description: "Username used to authenticate with the SCM provider",
+						Optional:    true,
+					},
+					"remediation_pull_requests_enabled": schema.BoolAttribute{
+						Description: "Whether automated remediation Pull Requests are enabled",
+						Optional:    true,
+					},
+					"pull_request_commenting_enabled": schema.BoolAttribute{
+						Description: "Whether Pull Request commenting is enabled",
+						Optional:    true,
+					},
+					"source_control_evaluations_enabled": schema.BoolAttribute{
+						Description: "Whether Source Control evaluations are enabled",
+						Optional:    true,
+					},
+					"commit_status_enabled": schema.BoolAttribute{
+						Description: "Whether the status of policy evaluations is reported on commits",
+						Optional:    true,
+					},
+					"status_checks_enabled": schema.BoolAttribute{
+						Description: "Whether Pull Requests are blocked by failing status checks",
+						Optional:    true,
+					},
+					"ssh_enabled": schema.BoolAttribute{
+						Description: "Whether repositories are accessed over SSH rather than HTTPS",
+						Optional:    true,
+					},
+				},
+			},
+			"source_control_token": schema.StringAttribute{
+				Description: "Token used to authenticate with the SCM provider - required with source_control. This value is write-only and never stored in state - it is sent to IQ Server whenever it differs from the token that was sent last",
+				Optional:    true,
+				Sensitive:   true,
+				WriteOnly:   true,
+			},
+			"source_control_token_version": schema.Int64Attribute{
+				Description: "Version of the token - changing this value sends the token to IQ Server again, even if it is unchanged",
+				Optional:    true,
+			},
+			"application_categories": schema.SetNestedAttribute{
+				Description: "Application Categories of the Root Organization. When set, Categories of the Root Organization that are not listed are deleted - removing it leaves the Categories in place",
+				Optional:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "Name of the Category",
+							Required:    true,
+						},
+						"description": schema.StringAttribute{
+							Description: "Description of the Category",
+							Required:    true,
+						},
+						"color": schema.StringAttribute{
+							Description: "Color of the Category, e.g. dark-blue or light-green",
+							Required:    true,
+						},
+					},
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(),
+		},
+	}
+}
+
+// ValidateConfig rejects a token without Source Control configuration to send it with, and Source
+// Control configuration that IQ Server would reject for the Root Organization.
+func (r *rootOrganizationResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config rootOrganizationModelResource
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.SourceControl == nil && !config.SourceControlToken.IsNull() && !config.SourceControlToken.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("source_control_token"),
+			"Missing Attribute Configuration",
+			"The source_control_token can only be configured together with source_control",
+		)
+	}
+	if config.SourceControl != nil {
+		resp.Diagnostics.Append(requireRootSourceControl(
+			configuredAttribute{path.Root("source_control_token"), config.SourceControlToken},
+		)...)
+	}
+}
+
+// ModifyPlan plans an update when the configured token no longer matches the token that was sent
+// to IQ Server last, in the same way as for the sonatypeiq_source_control resource.
+func (r *rootOrganizationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to compare with when creating or destroying
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var token types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("source_control_token"), &token)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if tokenChanged(ctx, req.Private, token) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("last_updated"), types.StringUnknown())...)
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *rootOrganizationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan rootOrganizationModelResource
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Write-only attributes are only available in the configuration
+	var token types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("source_control_token"), &token)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = context.WithValue(
+		ctx,
+		sonatypeiq.ContextBasicAuth,
+		r.auth,
+	)
+
+	ctx, cancel := withTimeout(ctx, plan.Timeouts.create())
+	defer cancel()
+
+	plan.ID = types.StringValue(rootOrganizationId)
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	resp.Diagnostics.Append(r.updateSourceControl(ctx, false, plan.SourceControl, token)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(rememberToken(ctx, resp.Private, token)...)
+
+	resp.Diagnostics.Append(r.updateCategories(ctx, plan.ApplicationCategories)...)
+	if resp.Diagnostics.HasError() {
+		// Keep track of the Source Control configuration that was created
+		plan.ApplicationCategories = nil
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *rootOrganizationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state rootOrganizationModelResource
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = context.WithValue(
+		ctx,
+		sonatypeiq.ContextBasicAuth,
+		r.auth,
+	)
+
+	ctx, cancel := withTimeout(ctx, state.Timeouts.read())
+	defer cancel()
+
+	// Only refresh the configuration that is managed by this resource, unless it was just imported
+	imported, diags := req.Private.GetKey(ctx, privateKeyImported)
+	resp.Diagnostics.Append(diags...)
+	if imported != nil {
+		state.SourceControl = &rootOrganizationSourceControlModel{}
+		state.ApplicationCategories = []rootOrganizationCategoryModel{}
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, privateKeyImported, nil)...)
+	}
+
+	if state.SourceControl != nil {
+		sourceControl, api_response, err := r.client.SourceControlAPI.GetSourceControl1(ctx, "organization", rootOrganizationId).Execute()
+		if err != nil && !isNotFound(api_response) {
+			resp.Diagnostics.AddError(
+				"Error Reading IQ Root Organization Source Control configuration",
+				apiErrorDetail("Could not read Source Control configuration for the Root Organization", api_response, err),
+			)
+			return
+		}
+		if err != nil {
+			state.SourceControl = nil
+		} else {
+			state.SourceControl.fromDTO(sourceControl)
+		}
+	}
+
+	if state.ApplicationCategories != nil {
+		categories, api_response, err := r.client.ApplicationCategoriesAPI.GetTags(ctx, rootOrganizationId).Execute()
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading IQ Root Organization Application Categories",
+				apiErrorDetail("Could not read Application Categories of the Root Organization", api_response, err),
+			)
+			return
+		}
+		state.ApplicationCategories = []rootOrganizationCategoryModel{}
+		for _, category := range categories {
+			state.ApplicationCategories = append(state.ApplicationCategories, rootOrganizationCategoryModel{
+				Name:        types.StringPointerValue(category.Name),
+				Description: types.StringPointerValue(category.Description),
+				Color:       types.StringPointerValue(category.Color),
+			})
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *rootOrganizationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan rootOrganizationModelResource
+	var state rootOrganizationModelResource
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// IQ Server only returns a masked token, so the token is only sent again when its version
+	// changes or it no longer matches the fingerprint of the token that was sent last
+	var token types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("source_control_token"), &token)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if state.SourceControl != nil && plan.TokenVersion.Equal(state.TokenVersion) && !tokenChanged(ctx, req.Private, token) {
+		token = types.StringNull()
+	}
+
+	ctx = context.WithValue(
+		ctx,
+		sonatypeiq.ContextBasicAuth,
+		r.auth,
+	)
+
+	ctx, cancel := withTimeout(ctx, plan.Timeouts.update())
+	defer cancel()
+
+	plan.ID = types.StringValue(rootOrganizationId)
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	if plan.SourceControl != nil || state.SourceControl != nil {
+		resp.Diagnostics.Append(r.updateSourceControl(ctx, state.SourceControl != nil, plan.SourceControl, token)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.Append(rememberToken(ctx, resp.Private, token)...)
+	}
+
+	resp.Diagnostics.Append(r.updateCategories(ctx, plan.ApplicationCategories)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete deletes the Source Control configuration of the Root Organization, when managed, and
+// removes the Terraform state on success.
+func (r *rootOrganizationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state rootOrganizationModelResource
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() || state.SourceControl == nil {
+		return
+	}
+
+	ctx = context.WithValue(
+		ctx,
+		sonatypeiq.ContextBasicAuth,
+		r.auth,
+	)
+
+	ctx, cancel := withTimeout(ctx, state.Timeouts.delete())
+	defer cancel()
+
+	resp.Diagnostics.Append(r.updateSourceControl(ctx, true, nil, types.StringNull())...)
+}
+
+// ImportState imports the configuration of the Root Organization - as there is only one, any ID
+// is accepted.
+func (r *rootOrganizationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), rootOrganizationId)...)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, privateKeyImported, []byte("true"))...)
+}
+
+// updateSourceControl adds, updates or deletes the Source Control configuration of the Root
+// Organization depending on whether it is configured and exists. It is assumed to exist when it is
+// managed by this resource already, and looked up otherwise, as the Root Organization may have been
+// configured outside of Terraform.
+func (r *rootOrganizationResource) updateSourceControl(ctx context.Context, managed bool, plan *rootOrganizationSourceControlModel, token types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var api_response *http.Response
+	var err error
+
+	exists := managed
+	if plan != nil && !managed {
+		_, api_response, err = r.client.SourceControlAPI.GetSourceControl1(ctx, "organization", rootOrganizationId).Execute()
+		if err != nil && !isNotFound(api_response) {
+			diags.AddError(
+				"Error updating Root Organization Source Control configuration",
+				apiErrorDetail("Could not read Source Control configuration for the Root Organization", api_response, err),
+			)
+			return diags
+		}
+		exists = err == nil
+	}
+
+	switch {
+	case plan == nil && !exists:
+		return diags
+	case plan == nil:
+		api_response, err = r.client.SourceControlAPI.DeleteSourceControl(ctx, "organization", rootOrganizationId).Execute()
+		if isNotFound(api_response) {
+			err = nil
+		}
+	case !exists:
+		_, api_response, err = r.client.SourceControlAPI.AddSourceControl(ctx, "organization", rootOrganizationId).ApiSourceControlDTO(plan.toDTO(token)).Execute()
+	default:
+		_, api_response, err = r.client.SourceControlAPI.UpdateSourceControl(ctx, "organization", rootOrganizationId).ApiSourceControlDTO(plan.toDTO(token)).Execute()
+	}
+
+	if err != nil {
+		diags.AddError(
+			"Error updating Root Organization Source Control configuration",
+			apiErrorDetail("Could not update Source Control configuration for the Root Organization", api_response, err),
+		)
+	}
+	return diags
+}
+
+// updateCategories adds, updates and deletes Application Categories of the Root Organization so
+// that they match the configured Categories, which are matched by name. Nothing is changed when
+// no Categories are configured.
+func (r *rootOrganizationResource) updateCategories(ctx context.Context, planned []rootOrganizationCategoryModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if planned == nil {
+		return diags
+	}
+
+	categories, api_response, err := r.client.ApplicationCategoriesAPI.GetTags(ctx, rootOrganizationId).Execute()
+	if err != nil {
+		diags.AddError(
+			"Error updating Root Organization Application Categories",
+			apiErrorDetail("Could not read Application Categories of the Root Organization", api_response, err),
+		)
+		return diags
+	}
+	existing := map[string]sonatypeiq.ApiApplicationCategoryDTO{}
+	for _, category := range categories {
+		existing[category.GetName()] = category
+	}
+
+	for _, category := range planned {
+		dto := sonatypeiq.ApiApplicationCategoryDTO{
+			Name:           category.Name.ValueStringPointer(),
+			Description:    category.Description.ValueStringPointer(),
+			Color:          category.Color.ValueStringPointer(),
+			OrganizationId: sonatypeiq.PtrString(rootOrganizationId),
+		}
+		current, ok := existing[category.Name.ValueString()]
+		delete(existing, category.Name.ValueString())
+		switch {
+		case !ok:
+			_, api_response, err = r.client.ApplicationCategoriesAPI.AddTag(ctx, rootOrganizationId).ApiApplicationCategoryDTO(dto).Execute()
+		case current.GetDescription() != category.Description.ValueString() || current.GetColor() != category.Color.ValueString():
+			dto.Id = current.Id
+			_, api_response, err = r.client.ApplicationCategoriesAPI.UpdateTag(ctx, rootOrganizationId).ApiApplicationCategoryDTO(dto).Execute()
+		default:
+			continue
+		}
+		if err != nil {
+			diags.AddError(
+				"Error updating Root Organization Application Categories",
+				apiErrorDetail("Could not save Application Category "+category.Name.ValueString(), api_response, err),
+			)
+			return diags
+		}
+	}
+
+	for name, category := range existing {
+		api_response, err = r.client.ApplicationCategoriesAPI.DeleteTag(ctx, rootOrganizationId, category.GetId()).Execute()
+		if err != nil && !isNotFound(api_response) {
+			diags.AddError(
+				"Error updating Root Organization Application Categories",
+				apiErrorDetail("Could not delete Application Category "+name, api_response, err),
+			)
+			return diags
+		}
+	}
+	return diags
+}
+
+// toDTO maps the model to the Source Control configuration sent to IQ Server. The token is only
+// sent when supplied.
+func (m *rootOrganizationSourceControlModel) toDTO(token types.String) sonatypeiq.ApiSourceControlDTO {
+	dto := sonatypeiq.ApiSourceControlDTO{
+		Provider:                        m.Provider.ValueStringPointer(),
+		BaseBranch:                      m.BaseBranch.ValueStringPointer(),
+		Username:                        m.Username.ValueStringPointer(),
+		RemediationPullRequestsEnabled:  m.RemediationPullRequestsEnabled.ValueBoolPointer(),
+		PullRequestCommentingEnabled:    m.PullRequestCommentingEnabled.ValueBoolPointer(),
+		SourceControlEvaluationsEnabled: m.SourceControlEvaluationsEnabled.ValueBoolPointer(),
+		CommitStatusEnabled:             m.CommitStatusEnabled.ValueBoolPointer(),
+		StatusChecksEnabled:             m.StatusChecksEnabled.ValueBoolPointer(),
+		SshEnabled:                      m.SshEnabled.ValueBoolPointer(),
+	}
+	if !token.IsNull() && !token.IsUnknown() {
+		dto.Token = token.ValueStringPointer()
+	}
+	return dto
+}
+
+func (m *rootOrganizationSourceControlModel) fromDTO(sourceControl *sonatypeiq.ApiSourceControlDTO) {
+	m.Provider = types.StringPointerValue(sourceControl.Provider)
+	m.BaseBranch = types.StringPointerValue(sourceControl.BaseBranch)
+	m.Username = types.StringPointerValue(sourceControl.Username)
+	m.RemediationPullRequestsEnabled = types.BoolPointerValue(sourceControl.RemediationPullRequestsEnabled)
+	m.PullRequestCommentingEnabled = types.BoolPointerValue(sourceControl.PullRequestCommentingEnabled)
+	m.SourceControlEvaluationsEnabled = types.BoolPointerValue(sourceControl.SourceControlEvaluationsEnabled)
+	m.CommitStatusEnabled = types.BoolPointerValue(sourceControl.CommitStatusEnabled)
+	m.StatusChecksEnabled = types.BoolPointerValue(sourceControl.StatusChecksEnabled)
+	m.SshEnabled = types.BoolPointerValue(sourceControl.SshEnabled)
+}
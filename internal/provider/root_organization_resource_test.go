@@ -0,0 +1,105 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccRootOrganizationResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccSandbox(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			// Write-only attributes require Terraform 1.11
+			tfversion.SkipBelow(version.Must(version.NewVersion("1.11.0"))),
+		},
+		Steps: []resource.TestStep{
+			// A token needs Source Control configuration to be sent with
+			{
+				Config: providerConfig + `
+resource "sonatypeiq_root_organization" "test" {
+  source_control_token = "token-1"
+}`,
+				ExpectError: regexp.MustCompile(`can only be configured together with source_control`),
+			},
+			// Source Control configuration of the Root Organization cannot inherit the token
+			{
+				Config: providerConfig + `
+resource "sonatypeiq_root_organization" "test" {
+  source_control = {
+    scm_provider = "github"
+    base_branch  = "main"
+  }
+}`,
+				ExpectError: regexp.MustCompile(`source_control_token must be configured for the Root Organization`),
+			},
+			// Create and Read testing
+			{
+				Config: testAccRootOrganizationResource("main", "token-1", 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("sonatypeiq_root_organization.test", "id", "ROOT_ORGANIZATION_ID"),
+					resource.TestCheckResourceAttr("sonatypeiq_root_organization.test", "source_control.scm_provider", "github"),
+					resource.TestCheckResourceAttr("sonatypeiq_root_organization.test", "source_control.base_branch", "main"),
+					resource.TestCheckNoResourceAttr("sonatypeiq_root_organization.test", "source_control_token"),
+					resource.TestCheckNoResourceAttr("sonatypeiq_root_organization.test", "application_categories"),
+					resource.TestCheckResourceAttrSet("sonatypeiq_root_organization.test", "last_updated"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "sonatypeiq_root_organization.test",
+				ImportState:             true,
+				ImportStateId:           "ROOT_ORGANIZATION_ID",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated", "source_control_token_version", "application_categories"},
+			},
+			// Update and rotate the token
+			{
+				Config: testAccRootOrganizationResource("develop", "token-2", 2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("sonatypeiq_root_organization.test", "source_control.base_branch", "develop"),
+					resource.TestCheckResourceAttr("sonatypeiq_root_organization.test", "source_control_token_version", "2"),
+				),
+			},
+			// The masked token returned by IQ Server does not cause drift
+			{
+				Config:   testAccRootOrganizationResource("develop", "token-2", 2),
+				PlanOnly: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccRootOrganizationResource(branch string, token string, tokenVersion int) string {
+	return fmt.Sprintf(providerConfig+`
+resource "sonatypeiq_root_organization" "test" {
+  source_control = {
+    scm_provider = "github"
+    base_branch  = "%s"
+  }
+  source_control_token         = "%s"
+  source_control_token_version = %d
+}`, branch, token, tokenVersion)
+}
@@ -0,0 +1,94 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// scmProviders are the SCM providers supported by Sonatype IQ Server. IQ Server offers no
+// endpoint to discover these, so they are maintained here.
+var scmProviders = []string{
+	"azure",
+	"bitbucket",
+	"github",
+	"gitlab",
+}
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &scmProvidersDataSource{}
+	_ datasource.DataSourceWithConfigure = &scmProvidersDataSource{}
+)
+
+// ScmProvidersDataSource is a helper function to simplify the provider implementation.
+func ScmProvidersDataSource() datasource.DataSource {
+	return &scmProvidersDataSource{}
+}
+
+// scmProvidersDataSource is the data source implementation.
+type scmProvidersDataSource struct {
+	baseDataSource
+}
+
+type scmProvidersDataSourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	ScmProviders []string     `tfsdk:"scm_providers"`
+}
+
+// Metadata returns the data source type name.
+func (d *scmProvidersDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_scm_providers"
+}
+
+// Schema defines the schema for the data source.
+func (d *scmProvidersDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Use this data source to get the SCM providers supported by Sonatype IQ Server",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"scm_providers": schema.ListAttribute{
+				Description: "List of supported SCM providers, as used for the `scm_provider` of a Source Control configuration",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *scmProvidersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	state := scmProvidersDataSourceModel{
+		ScmProviders: scmProviders,
+	}
+
+	// For test framework
+	state.ID = types.StringValue("placeholder")
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
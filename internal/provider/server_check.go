@@ -0,0 +1,134 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	sonatypeiq "github.com/sonatype-nexus-community/nexus-iq-api-client-go"
+)
+
+type productVersionDTO struct {
+	Version string  `json:"version"`
+	Build   *string `json:"build,omitempty"`
+}
+
+// checkServer verifies that Sonatype IQ Server can be reached with the configured credentials and,
+// when a minimum version is supplied, that the server is recent enough.
+func checkServer(ctx context.Context, client *sonatypeiq.APIClient, auth sonatypeiq.BasicAuth, minimumVersion string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	ctx = context.WithValue(
+		ctx,
+		sonatypeiq.ContextBasicAuth,
+		auth,
+	)
+
+	// Filtering on a Public ID keeps the response small on servers with many Applications
+	_, api_response, err := client.ApplicationsAPI.GetApplications(ctx).PublicId([]string{"terraform-connectivity-check"}).Execute()
+	if err != nil {
+		switch {
+		case api_response == nil:
+			diags.AddAttributeError(
+				path.Root("url"),
+				"Unable to connect to Sonatype IQ Server",
+				err.Error(),
+			)
+		case api_response.StatusCode == http.StatusUnauthorized:
+			diags.AddError(
+				"Invalid Sonatype IQ Server credentials",
+				"Sonatype IQ Server rejected the configured credentials: "+api_response.Status,
+			)
+		case api_response.StatusCode == http.StatusNotFound:
+			diags.AddAttributeError(
+				path.Root("url"),
+				"Invalid Sonatype IQ Server URL",
+				"The configured URL does not point to a Sonatype IQ Server: "+api_response.Status,
+			)
+		default:
+			diags.AddError(
+				"Unable to connect to Sonatype IQ Server",
+				err.Error(),
+			)
+		}
+		return diags
+	}
+
+	if len(minimumVersion) == 0 {
+		return diags
+	}
+
+	var product productVersionDTO
+	if _, err := callIqApi(ctx, client, http.MethodGet, "/rest/product/version", nil, &product); err != nil {
+		diags.AddError(
+			"Unable to determine Sonatype IQ Server version",
+			err.Error(),
+		)
+		return diags
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Connected to Sonatype IQ Server %s", product.Version))
+
+	if compareVersions(product.Version, minimumVersion) < 0 {
+		diags.AddAttributeError(
+			path.Root("minimum_server_version"),
+			"Sonatype IQ Server version not supported",
+			fmt.Sprintf("Sonatype IQ Server %s is older than the required minimum version %s", product.Version, minimumVersion),
+		)
+	}
+
+	return diags
+}
+
+// compareVersions compares two IQ Server versions (e.g. 1.170.0-01), returning a negative number
+// when a is older than b, zero when they are equal and a positive number when a is newer.
+func compareVersions(a, b string) int {
+	split := func(version string) []int {
+		var parts []int
+		for _, part := range strings.FieldsFunc(version, func(r rune) bool { return r == '.' || r == '-' }) {
+			n, err := strconv.Atoi(part)
+			if err != nil {
+				break
+			}
+			parts = append(parts, n)
+		}
+		return parts
+	}
+
+	partsA, partsB := split(a), split(b)
+	for i := 0; i < len(partsA) || i < len(partsB); i++ {
+		var x, y int
+		if i < len(partsA) {
+			x = partsA[i]
+		}
+		if i < len(partsB) {
+			y = partsB[i]
+		}
+		if x != y {
+			return x - y
+		}
+	}
+	return 0
+}
@@ -0,0 +1,208 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	sonatypeiq "github.com/sonatype-nexus-community/nexus-iq-api-client-go"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                     = &sourceControlDataSource{}
+	_ datasource.DataSourceWithConfigure        = &sourceControlDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &sourceControlDataSource{}
+)
+
+// SourceControlDataSource is a helper function to simplify the provider implementation.
+func SourceControlDataSource() datasource.DataSource {
+	return &sourceControlDataSource{}
+}
+
+// sourceControlDataSource is the data source implementation.
+type sourceControlDataSource struct {
+	baseDataSource
+}
+
+type sourceControlDataSourceModel struct {
+	ID                              types.String `tfsdk:"id"`
+	OrganizationId                  types.String `tfsdk:"organization_id"`
+	ApplicationId                   types.String `tfsdk:"application_id"`
+	Provider                        types.String `tfsdk:"scm_provider"`
+	RepositoryUrl                   types.String `tfsdk:"repository_url"`
+	BaseBranch                      types.String `tfsdk:"base_branch"`
+	Username                        types.String `tfsdk:"username"`
+	RemediationPullRequestsEnabled  types.Bool   `tfsdk:"remediation_pull_requests_enabled"`
+	PullRequestCommentingEnabled    types.Bool   `tfsdk:"pull_request_commenting_enabled"`
+	SourceControlEvaluationsEnabled types.Bool   `tfsdk:"source_control_evaluations_enabled"`
+	CommitStatusEnabled             types.Bool   `tfsdk:"commit_status_enabled"`
+	StatusChecksEnabled             types.Bool   `tfsdk:"status_checks_enabled"`
+	SshEnabled                      types.Bool   `tfsdk:"ssh_enabled"`
+	SourceControlScanTarget         types.String `tfsdk:"source_control_scan_target"`
+}
+
+// Metadata returns the data source type name.
+func (d *sourceControlDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_source_control"
+}
+
+// Schema defines the schema for the data source.
+func (d *sourceControlDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Use this data source to get the Source Control configuration of an Organization or Application",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Internal ID of the Source Control configuration",
+				Computed:    true,
+			},
+			"organization_id": schema.StringAttribute{
+				Description: "Internal ID of the Organization - use 'ROOT_ORGANIZATION_ID' for the Root Organization",
+				Optional:    true,
+			},
+			"application_id": schema.StringAttribute{
+				Description: "Internal ID of the Application",
+				Optional:    true,
+			},
+			"scm_provider": schema.StringAttribute{
+				Description: "SCM provider (e.g. github, gitlab, azure, bitbucket) - null when inherited",
+				Computed:    true,
+			},
+			"repository_url": schema.StringAttribute{
+				Description: "Repository URL - only available for Applications",
+				Computed:    true,
+			},
+			"base_branch": schema.StringAttribute{
+				Description: "Default branch - null when inherited",
+				Computed:    true,
+			},
+			"username": schema.StringAttribute{
+				Description: "Username used to authenticate with the SCM provider - null when inherited",
+				Computed:    true,
+			},
+			"remediation_pull_requests_enabled": schema.BoolAttribute{
+				Description: "Whether automated remediation Pull Requests are enabled - null when inherited",
+				Computed:    true,
+			},
+			"pull_request_commenting_enabled": schema.BoolAttribute{
+				Description: "Whether Pull Request commenting is enabled - null when inherited",
+				Computed:    true,
+			},
+			"source_control_evaluations_enabled": schema.BoolAttribute{
+				Description: "Whether Source Control evaluations are enabled - null when inherited",
+				Computed:    true,
+			},
+			"commit_status_enabled": schema.BoolAttribute{
+				Description: "Whether the status of policy evaluations is reported on commits - null when inherited",
+				Computed:    true,
+			},
+			"status_checks_enabled": schema.BoolAttribute{
+				Description: "Whether Pull Requests are blocked by failing status checks - null when inherited",
+				Computed:    true,
+			},
+			"ssh_enabled": schema.BoolAttribute{
+				Description: "Whether repositories are accessed over SSH rather than HTTPS - null when inherited",
+				Computed:    true,
+			},
+			"source_control_scan_target": schema.StringAttribute{
+				Description: "Branch or target that is scanned for Source Control evaluations - null when inherited",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *sourceControlDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("organization_id"),
+			path.MatchRoot("application_id"),
+		),
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *sourceControlDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if deferUnknownOwnerRead(req, resp) {
+		return
+	}
+
+	var data sourceControlDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = context.WithValue(
+		ctx,
+		sonatypeiq.ContextBasicAuth,
+		d.auth,
+	)
+
+	// The config validator makes sure that exactly one of these is configured.
+	ownerType, ownerId := "organization", data.OrganizationId.ValueString()
+	if !data.ApplicationId.IsNull() {
+		ownerType, ownerId = "application", data.ApplicationId.ValueString()
+	}
+
+	sourceControl, api_response, err := d.client.SourceControlAPI.GetSourceControl1(ctx, ownerType, ownerId).Execute()
+
+	if err != nil {
+		if api_response != nil && api_response.StatusCode == http.StatusNotFound {
+			resp.Diagnostics.AddError(
+				"No Source Control configuration found",
+				fmt.Sprintf("No Source Control configuration found for %s '%s'", ownerType, ownerId),
+			)
+		} else {
+			resp.Diagnostics.AddError(
+				"Unable to Read IQ Source Control configuration",
+				describeApiError(api_response, err),
+			)
+		}
+		return
+	}
+
+	data.ID = types.StringPointerValue(sourceControl.Id)
+	data.Provider = types.StringPointerValue(sourceControl.Provider)
+	data.RepositoryUrl = types.StringPointerValue(sourceControl.RepositoryUrl)
+	data.BaseBranch = types.StringPointerValue(sourceControl.BaseBranch)
+	data.Username = types.StringPointerValue(sourceControl.Username)
+	data.RemediationPullRequestsEnabled = types.BoolPointerValue(sourceControl.RemediationPullRequestsEnabled)
+	data.PullRequestCommentingEnabled = types.BoolPointerValue(sourceControl.PullRequestCommentingEnabled)
+	data.SourceControlEvaluationsEnabled = types.BoolPointerValue(sourceControl.SourceControlEvaluationsEnabled)
+	data.CommitStatusEnabled = types.BoolPointerValue(sourceControl.CommitStatusEnabled)
+	data.StatusChecksEnabled = types.BoolPointerValue(sourceControl.StatusChecksEnabled)
+	data.SshEnabled = types.BoolPointerValue(sourceControl.SshEnabled)
+	data.SourceControlScanTarget = types.StringPointerValue(sourceControl.SourceControlScanTarget)
+
+	// Set state
+	diags := resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,212 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	sonatypeiq "github.com/sonatype-nexus-community/nexus-iq-api-client-go"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                     = &sourceControlMetricsDataSource{}
+	_ datasource.DataSourceWithConfigure        = &sourceControlMetricsDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &sourceControlMetricsDataSource{}
+)
+
+// SourceControlMetricsDataSource is a helper function to simplify the provider implementation.
+func SourceControlMetricsDataSource() datasource.DataSource {
+	return &sourceControlMetricsDataSource{}
+}
+
+// sourceControlMetricsDataSource is the data source implementation.
+type sourceControlMetricsDataSource struct {
+	baseDataSource
+}
+
+type sourceControlMetricsDataSourceModel struct {
+	ID               types.String             `tfsdk:"id"`
+	OrganizationId   types.String             `tfsdk:"organization_id"`
+	ApplicationId    types.String             `tfsdk:"application_id"`
+	PullRequestCount types.Int64              `tfsdk:"pull_request_count"`
+	SuccessfulCount  types.Int64              `tfsdk:"successful_count"`
+	FailedCount      types.Int64              `tfsdk:"failed_count"`
+	PullRequests     []pullRequestResultModel `tfsdk:"pull_requests"`
+}
+
+type pullRequestResultModel struct {
+	Title           types.String `tfsdk:"title"`
+	Successful      types.Bool   `tfsdk:"successful"`
+	ExceptionThrown types.Bool   `tfsdk:"exception_thrown"`
+	Reasoning       types.String `tfsdk:"reasoning"`
+	StartTime       types.String `tfsdk:"start_time"`
+	TotalTime       types.Int64  `tfsdk:"total_time"`
+}
+
+// Metadata returns the data source type name.
+func (d *sourceControlMetricsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_source_control_metrics"
+}
+
+// Schema defines the schema for the data source.
+func (d *sourceControlMetricsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Use this data source to get the automated Pull Requests that IQ Server attempted for an Organization or Application",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"organization_id": schema.StringAttribute{
+				Description: "Internal ID of the Organization - use 'ROOT_ORGANIZATION_ID' for the Root Organization",
+				Optional:    true,
+			},
+			"application_id": schema.StringAttribute{
+				Description: "Internal ID of the Application",
+				Optional:    true,
+			},
+			"pull_request_count": schema.Int64Attribute{
+				Description: "Number of Pull Requests that IQ Server attempted to open",
+				Computed:    true,
+			},
+			"successful_count": schema.Int64Attribute{
+				Description: "Number of Pull Requests that were opened successfully",
+				Computed:    true,
+			},
+			"failed_count": schema.Int64Attribute{
+				Description: "Number of Pull Requests that could not be opened",
+				Computed:    true,
+			},
+			"pull_requests": schema.ListNestedAttribute{
+				Description: "Pull Requests that IQ Server attempted to open",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"title": schema.StringAttribute{
+							Description: "Title of the Pull Request",
+							Computed:    true,
+						},
+						"successful": schema.BoolAttribute{
+							Description: "Whether the Pull Request was opened successfully",
+							Computed:    true,
+						},
+						"exception_thrown": schema.BoolAttribute{
+							Description: "Whether opening the Pull Request failed with an error",
+							Computed:    true,
+						},
+						"reasoning": schema.StringAttribute{
+							Description: "Why the Pull Request was or was not opened",
+							Computed:    true,
+						},
+						"start_time": schema.StringAttribute{
+							Description: "When IQ Server started to open the Pull Request, in RFC 3339 format",
+							Computed:    true,
+						},
+						"total_time": schema.Int64Attribute{
+							Description: "Time it took to open the Pull Request, in milliseconds",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *sourceControlMetricsDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("organization_id"),
+			path.MatchRoot("application_id"),
+		),
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *sourceControlMetricsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if deferUnknownOwnerRead(req, resp) {
+		return
+	}
+
+	var data sourceControlMetricsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = context.WithValue(
+		ctx,
+		sonatypeiq.ContextBasicAuth,
+		d.auth,
+	)
+
+	// The config validator makes sure that exactly one of these is configured.
+	ownerType, ownerId := "organization", data.OrganizationId.ValueString()
+	if !data.ApplicationId.IsNull() {
+		ownerType, ownerId = "application", data.ApplicationId.ValueString()
+	}
+
+	metrics, api_response, err := d.client.SourceControlMetricsAPI.GetSourceControl(ctx, ownerType, ownerId).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read IQ Source Control metrics",
+			describeApiError(api_response, err),
+		)
+		return
+	}
+
+	var successful int64
+	data.PullRequests = []pullRequestResultModel{}
+	for _, result := range metrics.Results {
+		startTime := types.StringNull()
+		if result.StartTime != nil {
+			startTime = types.StringValue(result.StartTime.Format(time.RFC3339))
+		}
+		if result.GetSuccessful() {
+			successful++
+		}
+		data.PullRequests = append(data.PullRequests, pullRequestResultModel{
+			Title:           types.StringPointerValue(result.Title),
+			Successful:      types.BoolPointerValue(result.Successful),
+			ExceptionThrown: types.BoolPointerValue(result.ExceptionThrown),
+			Reasoning:       types.StringPointerValue(result.Reasoning),
+			StartTime:       startTime,
+			TotalTime:       types.Int64PointerValue(result.TotalTime),
+		})
+	}
+
+	data.ID = types.StringValue(ownerType + "_" + ownerId)
+	data.PullRequestCount = types.Int64Value(int64(len(metrics.Results)))
+	data.SuccessfulCount = types.Int64Value(successful)
+	data.FailedCount = types.Int64Value(int64(len(metrics.Results)) - successful)
+
+	// Set state
+	diags := resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
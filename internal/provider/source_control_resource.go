@@ -0,0 +1,788 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/crypto/argon2"
+
+	sonatypeiq "github.com/sonatype-nexus-community/nexus-iq-api-client-go"
+)
+
+// sourceControlResource is the resource implementation.
+type sourceControlResource struct {
+	baseResource
+}
+
+type sourceControlModelResource struct {
+	ID                                       types.String   `tfsdk:"id"`
+	OrganizationId                           types.String   `tfsdk:"organization_id"`
+	ApplicationId                            types.String   `tfsdk:"application_id"`
+	Provider                                 types.String   `tfsdk:"scm_provider"`
+	RepositoryUrl                            types.String   `tfsdk:"repository_url"`
+	RepositoryUrlGitSuffix                   types.String   `tfsdk:"repository_url_git_suffix"`
+	BaseBranch                               types.String   `tfsdk:"base_branch"`
+	Username                                 types.String   `tfsdk:"username"`
+	Token                                    types.String   `tfsdk:"token"`
+	TokenVersion                             types.Int64    `tfsdk:"token_version"`
+	RemediationPullRequestsEnabled           types.Bool     `tfsdk:"remediation_pull_requests_enabled"`
+	PullRequestCommentingEnabled             types.Bool     `tfsdk:"pull_request_commenting_enabled"`
+	SourceControlEvaluationsEnabled          types.Bool     `tfsdk:"source_control_evaluations_enabled"`
+	CommitStatusEnabled                      types.Bool     `tfsdk:"commit_status_enabled"`
+	StatusChecksEnabled                      types.Bool     `tfsdk:"status_checks_enabled"`
+	SshEnabled                               types.Bool     `tfsdk:"ssh_enabled"`
+	SourceControlScanTarget                  types.String   `tfsdk:"source_control_scan_target"`
+	EffectiveRemediationPullRequestsEnabled  types.Bool     `tfsdk:"effective_remediation_pull_requests_enabled"`
+	EffectivePullRequestCommentingEnabled    types.Bool     `tfsdk:"effective_pull_request_commenting_enabled"`
+	EffectiveSourceControlEvaluationsEnabled types.Bool     `tfsdk:"effective_source_control_evaluations_enabled"`
+	EffectiveCommitStatusEnabled             types.Bool     `tfsdk:"effective_commit_status_enabled"`
+	EffectiveStatusChecksEnabled             types.Bool     `tfsdk:"effective_status_checks_enabled"`
+	EffectiveSshEnabled                      types.Bool     `tfsdk:"effective_ssh_enabled"`
+	LastUpdated                              types.String   `tfsdk:"last_updated"`
+	Timeouts                                 *timeoutsModel `tfsdk:"timeouts"`
+}
+
+// owner returns the type and internal ID of the Organization or Application the Source Control
+// configuration belongs to. The config validator makes sure that exactly one of these is configured.
+func (m *sourceControlModelResource) owner() (string, string) {
+	if !m.ApplicationId.IsNull() {
+		return "application", m.ApplicationId.ValueString()
+	}
+	return "organization", m.OrganizationId.ValueString()
+}
+
+// toDTO maps the model to the Source Control configuration sent to IQ Server. The token is
+// write-only, so it is read from the configuration and only sent when supplied.
+func (m *sourceControlModelResource) toDTO(token types.String) sonatypeiq.ApiSourceControlDTO {
+	dto := sonatypeiq.ApiSourceControlDTO{
+		Provider:                        m.Provider.ValueStringPointer(),
+		RepositoryUrl:                   m.RepositoryUrl.ValueStringPointer(),
+		BaseBranch:                      m.BaseBranch.ValueStringPointer(),
+		Username:                        m.Username.ValueStringPointer(),
+		RemediationPullRequestsEnabled:  m.RemediationPullRequestsEnabled.ValueBoolPointer(),
+		PullRequestCommentingEnabled:    m.PullRequestCommentingEnabled.ValueBoolPointer(),
+		SourceControlEvaluationsEnabled: m.SourceControlEvaluationsEnabled.ValueBoolPointer(),
+		CommitStatusEnabled:             m.CommitStatusEnabled.ValueBoolPointer(),
+		StatusChecksEnabled:             m.StatusChecksEnabled.ValueBoolPointer(),
+		SshEnabled:                      m.SshEnabled.ValueBoolPointer(),
+		SourceControlScanTarget:         m.SourceControlScanTarget.ValueStringPointer(),
+	}
+	if !token.IsNull() && !token.IsUnknown() {
+		dto.Token = token.ValueStringPointer()
+	}
+	return dto
+}
+
+// fromDTO maps the Source Control configuration returned by IQ Server to the model. The token is
+// returned masked and therefore never read back.
+func (m *sourceControlModelResource) fromDTO(sourceControl *sonatypeiq.ApiSourceControlDTO) {
+	m.ID = types.StringPointerValue(sourceControl.Id)
+	m.Provider = types.StringPointerValue(sourceControl.Provider)
+	m.RepositoryUrl = types.StringPointerValue(sourceControl.RepositoryUrl)
+	m.BaseBranch = types.StringPointerValue(sourceControl.BaseBranch)
+	m.Username = types.StringPointerValue(sourceControl.Username)
+	m.RemediationPullRequestsEnabled = types.BoolPointerValue(sourceControl.RemediationPullRequestsEnabled)
+	m.PullRequestCommentingEnabled = types.BoolPointerValue(sourceControl.PullRequestCommentingEnabled)
+	m.SourceControlEvaluationsEnabled = types.BoolPointerValue(sourceControl.SourceControlEvaluationsEnabled)
+	m.CommitStatusEnabled = types.BoolPointerValue(sourceControl.CommitStatusEnabled)
+	m.StatusChecksEnabled = types.BoolPointerValue(sourceControl.StatusChecksEnabled)
+	m.SshEnabled = types.BoolPointerValue(sourceControl.SshEnabled)
+	m.SourceControlScanTarget = types.StringPointerValue(sourceControl.SourceControlScanTarget)
+	m.Token = types.StringNull()
+}
+
+// NewSourceControlResource is a helper function to simplify the provider implementation.
+func NewSourceControlResource() resource.Resource {
+	return &sourceControlResource{}
+}
+
+// Metadata returns the resource type name.
+func (r *sourceControlResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_source_control"
+}
+
+// Schema defines the schema for the resource.
+func (r *sourceControlResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Use this resource to manage the Source Control configuration of an Organization or Application",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Internal ID of the Source Control configuration",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"organization_id": schema.StringAttribute{
+				Description: "Internal ID of the Organization - use 'ROOT_ORGANIZATION_ID' for the Root Organization. Defaults to the default_organization_id of the provider when application_id is not configured either",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					organizationIdValidator,
+				},
+			},
+			"application_id": schema.StringAttribute{
+				Description: "Internal ID of the Application",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					applicationIdValidator,
+				},
+			},
+			"scm_provider": schema.StringAttribute{
+				Description: "SCM provider, one of " + strings.Join(scmProviders, ", ") + " - inherited when not set, required for the Root Organization",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(scmProviders...),
+				},
+			},
+			"repository_url": schema.StringAttribute{
+				Description: "Repository URL, either an https:// or SSH git URL - only applicable to Applications",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					repositoryUrlValidator{},
+				},
+			},
+			"repository_url_git_suffix": schema.StringAttribute{
+				Description: "Whether the repository_url must end in .git (required), must not end in .git (forbidden) or may do either (optional, the default). Only used to validate the repository_url",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(gitSuffixes...),
+				},
+			},
+			"base_branch": schema.StringAttribute{
+				Description: "Default branch - inherited when not set, required for the Root Organization",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"username": schema.StringAttribute{
+				Description: "Username used to authenticate with the SCM provider - inherited when not set",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"token": schema.StringAttribute{
+				Description: "Token used to authenticate with the SCM provider - inherited when not set, required for the Root Organization. This value is write-only and never stored in state - it is sent to IQ Server whenever it differs from the token that was sent last",
+				Optional:    true,
+				Sensitive:   true,
+				WriteOnly:   true,
+			},
+			"token_version": schema.Int64Attribute{
+				Description: "Version of the token - changing this value sends the token to IQ Server again, even if it is unchanged",
+				Optional:    true,
+			},
+			"remediation_pull_requests_enabled": schema.BoolAttribute{
+				Description: "Whether automated remediation Pull Requests are enabled - inherited from the parent Organization when not set",
+				Optional:    true,
+			},
+			"effective_remediation_pull_requests_enabled": schema.BoolAttribute{
+				Description: "Whether automated remediation Pull Requests are enabled, taking inheritance from parent Organizations into account",
+				Computed:    true,
+			},
+			"pull_request_commenting_enabled": schema.BoolAttribute{
+				Description: "Whether Pull Request commenting is enabled - inherited from the parent Organization when not set",
+				Optional:    true,
+			},
+			"effective_pull_request_commenting_enabled": schema.BoolAttribute{
+				Description: "Whether Pull Request commenting is enabled, taking inheritance from parent Organizations into account",
+				Computed:    true,
+			},
+			"source_control_evaluations_enabled": schema.BoolAttribute{
+				Description: "Whether Source Control evaluations are enabled - inherited from the parent Organization when not set",
+				Optional:    true,
+			},
+			"effective_source_control_evaluations_enabled": schema.BoolAttribute{
+				Description: "Whether Source Control evaluations are enabled, taking inheritance from parent Organizations into account",
+				Computed:    true,
+			},
+			"commit_status_enabled": schema.BoolAttribute{
+				Description: "Whether the status of policy evaluations is reported on commits - inherited from the parent Organization when not set",
+				Optional:    true,
+			},
+			"effective_commit_status_enabled": schema.BoolAttribute{
+				Description: "Whether the status of policy evaluations is reported on commits, taking inheritance from parent Organizations into account",
+				Computed:    true,
+			},
+			"status_checks_enabled": schema.BoolAttribute{
+				Description: "Whether Pull Requests are blocked by failing status checks - inherited from the parent Organization when not set",
+				Optional:    true,
+			},
+			"effective_status_checks_enabled": schema.BoolAttribute{
+				Description: "Whether Pull Requests are blocked by failing status checks, taking inheritance from parent Organizations into account",
+				Computed:    true,
+			},
+			"ssh_enabled": schema.BoolAttribute{
+				Description: "Whether repositories are accessed over SSH rather than HTTPS - inherited from the parent Organization when not set",
+				Optional:    true,
+			},
+			"effective_ssh_enabled": schema.BoolAttribute{
+				Description: "Whether repositories are accessed over SSH rather than HTTPS, taking inheritance from parent Organizations into account",
+				Computed:    true,
+			},
+			"source_control_scan_target": schema.StringAttribute{
+				Description: "Branch or target that is scanned for Source Control evaluations - inherited when not set",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(),
+		},
+	}
+}
+
+func (r *sourceControlResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.Conflicting(
+			path.MatchRoot("organization_id"),
+			path.MatchRoot("application_id"),
+		),
+	}
+}
+
+// ValidateConfig rejects configurations that IQ Server would reject with a less clear error.
+func (r *sourceControlResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config sourceControlModelResource
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() || config.OrganizationId.IsNull() || config.OrganizationId.IsUnknown() {
+		return
+	}
+
+	if !config.RepositoryUrl.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("repository_url"),
+			"Invalid Attribute Combination",
+			"The repository_url can only be configured for Applications, not for Organizations",
+		)
+	}
+
+	if config.OrganizationId.ValueString() == rootOrganizationId {
+		resp.Diagnostics.Append(requireRootSourceControl(
+			configuredAttribute{path.Root("scm_provider"), config.Provider},
+			configuredAttribute{path.Root("base_branch"), config.BaseBranch},
+			configuredAttribute{path.Root("token"), config.Token},
+		)...)
+	}
+}
+
+// configuredAttribute is the configuration value of an attribute.
+type configuredAttribute struct {
+	path  path.Path
+	value types.String
+}
+
+// requireRootSourceControl reports the attributes of the Source Control configuration of the Root
+// Organization that are not configured. IQ Server requires the SCM provider, base branch and token
+// of the Root Organization, as there is no parent Organization to inherit them from, while other
+// Organizations and Applications may inherit all of them.
+func requireRootSourceControl(attributes ...configuredAttribute) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, attribute := range attributes {
+		if attribute.value.IsNull() {
+			diags.AddAttributeError(
+				attribute.path,
+				"Missing Attribute Configuration",
+				fmt.Sprintf("The %s must be configured for the Root Organization, as there is no parent Organization to inherit it from", attribute.path),
+			)
+		}
+	}
+	return diags
+}
+
+// ModifyPlan defaults the owner to the default_organization_id of the provider, and plans an update
+// when the configured token no longer matches the token that was sent to IQ Server last. The token
+// itself is write-only, so this is surfaced through last_updated.
+func (r *sourceControlResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do when destroying
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	if deferUnknownOwner(req, resp) {
+		return
+	}
+
+	r.planOwner(ctx, req, resp)
+
+	// Nothing to compare the token with when creating
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var id, token types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("id"), &id)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("token"), &token)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if tokenChanged(ctx, req.Private, token) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("last_updated"), types.StringUnknown())...)
+	}
+}
+
+// planOwner defaults the Organization to the default_organization_id of the provider when neither
+// organization_id nor application_id is configured.
+func (r *sourceControlResource) planOwner(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var organizationId, applicationId types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("organization_id"), &organizationId)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("application_id"), &applicationId)...)
+	if resp.Diagnostics.HasError() || !organizationId.IsNull() {
+		return
+	}
+
+	// An Application is not owned by an Organization as far as its Source Control configuration goes
+	if !applicationId.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("organization_id"), types.StringNull())...)
+		return
+	}
+
+	// The default is not known when the provider has not been configured (yet)
+	if r.client == nil {
+		return
+	}
+	if len(r.defaultOrganizationId) == 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("organization_id"),
+			"Missing Owner",
+			"Either organization_id or application_id must be configured when the provider does not configure a default_organization_id",
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("organization_id"), r.defaultOrganizationId)...)
+
+	if r.defaultOrganizationId == rootOrganizationId {
+		var config sourceControlModelResource
+		resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.Append(requireRootSourceControl(
+			configuredAttribute{path.Root("scm_provider"), config.Provider},
+			configuredAttribute{path.Root("base_branch"), config.BaseBranch},
+			configuredAttribute{path.Root("token"), config.Token},
+		)...)
+	}
+
+	// The Source Control configuration moves to another Organization when the default changes
+	if req.State.Raw.IsNull() {
+		return
+	}
+	var priorOrganizationId types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("organization_id"), &priorOrganizationId)...)
+	if !priorOrganizationId.IsNull() && priorOrganizationId.ValueString() != r.defaultOrganizationId {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("organization_id"))
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *sourceControlResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan sourceControlModelResource
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Write-only attributes are only available in the configuration
+	var token types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("token"), &token)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = context.WithValue(
+		ctx,
+		sonatypeiq.ContextBasicAuth,
+		r.auth,
+	)
+
+	ctx, cancel := withTimeout(ctx, plan.Timeouts.create())
+	defer cancel()
+
+	ownerType, ownerId := plan.owner()
+	sourceControl, api_response, err := r.client.SourceControlAPI.AddSourceControl(ctx, ownerType, ownerId).ApiSourceControlDTO(plan.toDTO(token)).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating Source Control configuration",
+			apiErrorDetail(fmt.Sprintf("Could not create Source Control configuration for %s %s", ownerType, ownerId), api_response, err),
+		)
+		return
+	}
+
+	plan.fromDTO(sourceControl)
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+	resp.Diagnostics.Append(r.resolveInheritance(ctx, &plan, sourceControl)...)
+
+	// IQ Server may not return the new entity straight away
+	resp.Diagnostics.Append(markCreated(ctx, resp.Private)...)
+	resp.Diagnostics.Append(rememberToken(ctx, resp.Private, token)...)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *sourceControlResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state sourceControlModelResource
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = context.WithValue(
+		ctx,
+		sonatypeiq.ContextBasicAuth,
+		r.auth,
+	)
+
+	ctx, cancel := withTimeout(ctx, state.Timeouts.read())
+	defer cancel()
+
+	ownerType, ownerId := state.owner()
+	var sourceControl *sonatypeiq.ApiSourceControlDTO
+	var api_response *http.Response
+	var err error
+	resp.Diagnostics.Append(readConsistently(ctx, req.Private, resp.Private, func(ctx context.Context) bool {
+		sourceControl, api_response, err = r.client.SourceControlAPI.GetSourceControl1(ctx, ownerType, ownerId).Execute()
+		return isNotFound(api_response)
+	})...)
+	if err != nil {
+		if isNotFound(api_response) {
+			resp.State.RemoveResource(ctx)
+		} else {
+			resp.Diagnostics.AddError(
+				"Error Reading IQ Source Control configuration",
+				apiErrorDetail(fmt.Sprintf("Could not read Source Control configuration for %s %s", ownerType, ownerId), api_response, err),
+			)
+		}
+		return
+	}
+
+	state.fromDTO(sourceControl)
+	resp.Diagnostics.Append(r.resolveInheritance(ctx, &state, sourceControl)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *sourceControlResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan sourceControlModelResource
+	var state sourceControlModelResource
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// IQ Server only returns a masked token, so the token is only sent again when its version
+	// changes or it no longer matches the fingerprint of the token that was sent last
+	var token types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("token"), &token)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.TokenVersion.Equal(state.TokenVersion) && !tokenChanged(ctx, req.Private, token) {
+		token = types.StringNull()
+	}
+
+	ctx = context.WithValue(
+		ctx,
+		sonatypeiq.ContextBasicAuth,
+		r.auth,
+	)
+
+	ctx, cancel := withTimeout(ctx, plan.Timeouts.update())
+	defer cancel()
+
+	ownerType, ownerId := plan.owner()
+	sourceControl, api_response, err := r.client.SourceControlAPI.UpdateSourceControl(ctx, ownerType, ownerId).ApiSourceControlDTO(plan.toDTO(token)).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating Source Control configuration",
+			apiErrorDetail(fmt.Sprintf("Could not update Source Control configuration for %s %s", ownerType, ownerId), api_response, err),
+		)
+		return
+	}
+
+	plan.fromDTO(sourceControl)
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+	resp.Diagnostics.Append(r.resolveInheritance(ctx, &plan, sourceControl)...)
+
+	resp.Diagnostics.Append(rememberToken(ctx, resp.Private, token)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *sourceControlResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state sourceControlModelResource
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = context.WithValue(
+		ctx,
+		sonatypeiq.ContextBasicAuth,
+		r.auth,
+	)
+
+	ctx, cancel := withTimeout(ctx, state.Timeouts.delete())
+	defer cancel()
+
+	ownerType, ownerId := state.owner()
+	api_response, err := r.client.SourceControlAPI.DeleteSourceControl(ctx, ownerType, ownerId).Execute()
+	if err != nil && !isNotFound(api_response) {
+		resp.Diagnostics.AddError(
+			"Error deleting Source Control configuration",
+			apiErrorDetail(fmt.Sprintf("Could not delete Source Control configuration for %s %s", ownerType, ownerId), api_response, err),
+		)
+		return
+	}
+}
+
+// MoveState moves the resource from the Sonatype IQ community provider.
+func (r *sourceControlResource) MoveState(_ context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		moveResourceState("sonatypeiq_source_control", nil),
+	}
+}
+
+// ImportState imports the Source Control configuration by an ID of the form
+// <organization|application>/<organization_id|application_id>, with each part URL-escaped. IDs of
+// the form <organization|application>_<organization_id|application_id> are still accepted.
+func (r *sourceControlResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts, err := parseEscapedId(req.ID, "owner_type", "owner_id")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Source Control configuration ID", err.Error())
+		return
+	}
+
+	switch parts[0] {
+	case "organization":
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("organization_id"), parts[1])...)
+	case "application":
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("application_id"), parts[1])...)
+	default:
+		resp.Diagnostics.AddError(
+			"Invalid Source Control configuration ID",
+			fmt.Sprintf("The owner type of ID %s must be either organization or application, got: %s", req.ID, parts[0]),
+		)
+	}
+
+	resp.Diagnostics.Append(markCreated(ctx, resp.Private)...)
+}
+
+// maxOrganizationDepth guards against cycles while walking up the Organization hierarchy.
+const maxOrganizationDepth = 100
+
+// resolveInheritance sets the effective_* attributes of the model. Flags that are not set on the
+// owner itself are inherited from the closest parent Organization that sets them, and are disabled
+// when no Organization up to the Root Organization sets them.
+func (r *sourceControlResource) resolveInheritance(ctx context.Context, m *sourceControlModelResource, sourceControl *sonatypeiq.ApiSourceControlDTO) diag.Diagnostics {
+	var diags diag.Diagnostics
+	effective := inheritableFlags(sourceControl)
+	unresolved := 0
+	for _, flag := range effective {
+		if flag == nil {
+			unresolved++
+		}
+	}
+
+	// Find the Organization the owner inherits from
+	ownerType, ownerId := m.owner()
+	hierarchy := r.organizationHierarchy()
+	var parentId *string
+	if unresolved > 0 {
+		if ownerType == "application" {
+			application, api_response, err := r.client.ApplicationsAPI.GetApplication(ctx, ownerId).Execute()
+			if err != nil {
+				diags.AddError("Error resolving inherited Source Control configuration", apiErrorDetail("Could not read Application "+ownerId, api_response, err))
+				return diags
+			}
+			parentId = application.OrganizationId
+		} else {
+			var err error
+			parentId, err = hierarchy.parentId(ctx, ownerId)
+			if err != nil {
+				diags.AddError("Error resolving inherited Source Control configuration", err.Error())
+				return diags
+			}
+		}
+	}
+
+	for depth := 0; unresolved > 0 && parentId != nil && depth < maxOrganizationDepth; depth++ {
+		parent, api_response, err := r.client.SourceControlAPI.GetSourceControl1(ctx, "organization", *parentId).Execute()
+		if err != nil && !isNotFound(api_response) {
+			diags.AddError("Error resolving inherited Source Control configuration", apiErrorDetail("Could not read Source Control configuration for organization "+*parentId, api_response, err))
+			return diags
+		}
+		if err == nil {
+			parentFlags := inheritableFlags(parent)
+			for i := range effective {
+				if effective[i] == nil && parentFlags[i] != nil {
+					effective[i] = parentFlags[i]
+					unresolved--
+				}
+			}
+		}
+		if unresolved == 0 {
+			break
+		}
+
+		parentId, err = hierarchy.parentId(ctx, *parentId)
+		if err != nil {
+			diags.AddError("Error resolving inherited Source Control configuration", err.Error())
+			return diags
+		}
+	}
+
+	value := func(flag *bool) types.Bool {
+		if flag == nil {
+			return types.BoolValue(false)
+		}
+		return types.BoolValue(*flag)
+	}
+	for i, attribute := range m.effectiveFlags() {
+		*attribute = value(effective[i])
+	}
+	return diags
+}
+
+// inheritableFlags returns the flags of a Source Control configuration that are inherited from
+// parent Organizations when not set, in the same order as effectiveFlags.
+func inheritableFlags(sourceControl *sonatypeiq.ApiSourceControlDTO) []*bool {
+	return []*bool{
+		sourceControl.RemediationPullRequestsEnabled,
+		sourceControl.PullRequestCommentingEnabled,
+		sourceControl.SourceControlEvaluationsEnabled,
+		sourceControl.CommitStatusEnabled,
+		sourceControl.StatusChecksEnabled,
+		sourceControl.SshEnabled,
+	}
+}
+
+// effectiveFlags returns the effective_* attributes of the model, in the same order as inheritableFlags.
+func (m *sourceControlModelResource) effectiveFlags() []*types.Bool {
+	return []*types.Bool{
+		&m.EffectiveRemediationPullRequestsEnabled,
+		&m.EffectivePullRequestCommentingEnabled,
+		&m.EffectiveSourceControlEvaluationsEnabled,
+		&m.EffectiveCommitStatusEnabled,
+		&m.EffectiveStatusChecksEnabled,
+		&m.EffectiveSshEnabled,
+	}
+}
+
+// privateKeyTokenFingerprint is the private state key holding the fingerprint of the token sent last.
+const privateKeyTokenFingerprint = "token_fingerprint"
+
+// tokenFingerprint is kept in the private state to recognise the token that was sent last. The
+// private state is stored in plain text in the state file, so the token is hashed with Argon2id and
+// a random salt, which makes guessing a token from its fingerprint slow.
+type tokenFingerprint struct {
+	Salt []byte `json:"salt"`
+	Hash []byte `json:"hash"`
+}
+
+// hashToken hashes a token with the minimum Argon2id parameters recommended by OWASP.
+func hashToken(salt []byte, token types.String) []byte {
+	return argon2.IDKey([]byte(token.ValueString()), salt, 2, 19*1024, 1, 32)
+}
+
+// rememberToken records the fingerprint of the token that was sent to IQ Server, if any.
+func rememberToken(ctx context.Context, private privateState, token types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if token.IsNull() || token.IsUnknown() {
+		return diags
+	}
+
+	fingerprint := tokenFingerprint{Salt: make([]byte, 16)}
+	if _, err := rand.Read(fingerprint.Salt); err != nil {
+		diags.AddError("Unable to record the token", "No random salt could be generated: "+err.Error())
+		return diags
+	}
+	fingerprint.Hash = hashToken(fingerprint.Salt, token)
+	value, err := json.Marshal(fingerprint)
+	if err != nil {
+		diags.AddError("Unable to record the token", err.Error())
+		return diags
+	}
+	return private.SetKey(ctx, privateKeyTokenFingerprint, value)
+}
+
+// tokenChanged reports whether a token is configured that differs from the token sent last. A
+// token without a fingerprint, e.g. after an import, is considered changed so that it is sent once.
+// So is a token with a fingerprint from an earlier version of the provider, which is replaced by
+// one in the current format once the token is sent.
+func tokenChanged(ctx context.Context, private privateState, token types.String) bool {
+	if token.IsNull() || token.IsUnknown() {
+		return false
+	}
+	value, diags := private.GetKey(ctx, privateKeyTokenFingerprint)
+	if diags.HasError() || value == nil {
+		return true
+	}
+	var fingerprint tokenFingerprint
+	if err := json.Unmarshal(value, &fingerprint); err != nil || len(fingerprint.Salt) == 0 {
+		return true
+	}
+	return subtle.ConstantTimeCompare(fingerprint.Hash, hashToken(fingerprint.Salt, token)) != 1
+}
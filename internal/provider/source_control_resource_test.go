@@ -0,0 +1,404 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccSourceControlResource(t *testing.T) {
+
+	appName := testAccRandomName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccSandbox(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			// Write-only attributes require Terraform 1.11
+			tfversion.SkipBelow(version.Must(version.NewVersion("1.11.0"))),
+		},
+		Steps: []resource.TestStep{
+			// Unsupported SCM providers are rejected at plan time
+			{
+				Config: providerConfig + `
+resource "sonatypeiq_source_control" "test" {
+  organization_id = "ROOT_ORGANIZATION_ID"
+  scm_provider = "subversion"
+}`,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Value Match`),
+			},
+			// Repository URLs without a scheme are rejected at plan time
+			{
+				Config: providerConfig + `
+resource "sonatypeiq_source_control" "test" {
+  application_id = "00000000000000000000000000000000"
+  repository_url = "github.com/sonatype/example"
+}`,
+				ExpectError: regexp.MustCompile(`Invalid Repository URL`),
+			},
+			// The .git suffix can be required or forbidden
+			{
+				Config: providerConfig + `
+resource "sonatypeiq_source_control" "test" {
+  application_id            = "00000000000000000000000000000000"
+  repository_url            = "https://github.com/sonatype/example.git"
+  repository_url_git_suffix = "forbidden"
+}`,
+				ExpectError: regexp.MustCompile(`not ending in .git`),
+			},
+			// Public IDs are reported while planning, with a hint
+			{
+				Config: providerConfig + `
+resource "sonatypeiq_source_control" "test" {
+  application_id = "sandbox-application"
+  repository_url = "https://github.com/sonatype/example"
+}`,
+				ExpectError: regexp.MustCompile(`Invalid Application ID`),
+			},
+			// Repository URLs are only applicable to Applications
+			{
+				Config: providerConfig + `
+resource "sonatypeiq_source_control" "test" {
+  organization_id = "ROOT_ORGANIZATION_ID"
+  scm_provider = "github"
+  repository_url = "https://github.com/sonatype/example"
+}`,
+				ExpectError: regexp.MustCompile(`can only be configured for Applications`),
+			},
+			// The Root Organization has nothing to inherit the SCM provider from
+			{
+				Config: providerConfig + `
+resource "sonatypeiq_source_control" "test" {
+  organization_id = "ROOT_ORGANIZATION_ID"
+  base_branch = "main"
+}`,
+				ExpectError: regexp.MustCompile(`scm_provider must be configured for the Root Organization`),
+			},
+			// Nor to inherit the token from
+			{
+				Config: providerConfig + `
+resource "sonatypeiq_source_control" "test" {
+  organization_id = "ROOT_ORGANIZATION_ID"
+  scm_provider = "github"
+  base_branch = "main"
+}`,
+				ExpectError: regexp.MustCompile(`token must be configured for the Root Organization`),
+			},
+			// Create and Read testing
+			{
+				Config: testAccSourceControlResource(appName, "main", "token-1", 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("sonatypeiq_source_control.test", "id"),
+					resource.TestCheckResourceAttr("sonatypeiq_source_control.test", "repository_url", "https://github.com/sonatype/"+appName),
+					resource.TestCheckResourceAttr("sonatypeiq_source_control.test", "base_branch", "main"),
+					resource.TestCheckNoResourceAttr("sonatypeiq_source_control.test", "token"),
+					resource.TestCheckResourceAttr("sonatypeiq_source_control.test", "token_version", "1"),
+					resource.TestCheckNoResourceAttr("sonatypeiq_source_control.test", "remediation_pull_requests_enabled"),
+					resource.TestCheckResourceAttrSet("sonatypeiq_source_control.test", "effective_remediation_pull_requests_enabled"),
+					resource.TestCheckResourceAttrSet("sonatypeiq_source_control.test", "last_updated"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:                         "sonatypeiq_source_control.test",
+				ImportState:                          true,
+				ImportStateIdFunc:                    testAccSourceControlImportId("sonatypeiq_source_control.test"),
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "application_id",
+				ImportStateVerifyIgnore:              []string{"last_updated", "token_version"},
+			},
+			// Update and rotate the token
+			{
+				Config: testAccSourceControlResource(appName, "develop", "token-2", 2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("sonatypeiq_source_control.test", "base_branch", "develop"),
+					resource.TestCheckNoResourceAttr("sonatypeiq_source_control.test", "token"),
+					resource.TestCheckResourceAttr("sonatypeiq_source_control.test", "token_version", "2"),
+				),
+			},
+			// Change the token without changing its version
+			{
+				Config: testAccSourceControlResource(appName, "develop", "token-3", 2),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("sonatypeiq_source_control.test", plancheck.ResourceActionUpdate),
+					},
+				},
+			},
+			// The masked token returned by IQ Server does not cause drift
+			{
+				Config:   testAccSourceControlResource(appName, "develop", "token-3", 2),
+				PlanOnly: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccSourceControlImportId(resourceName string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("resource not found: %s", resourceName)
+		}
+		return formatEscapedId("application", rs.Primary.Attributes["application_id"]), nil
+	}
+}
+
+func testAccSourceControlResource(name string, branch string, token string, tokenVersion int) string {
+	return fmt.Sprintf(providerConfig+`
+data "sonatypeiq_organization" "sandbox" {
+  name = "Sandbox Organization"
+}
+
+resource "sonatypeiq_application" "test" {
+  name = "%s"
+  public_id = "%s"
+  organization_id = data.sonatypeiq_organization.sandbox.id
+}
+
+resource "sonatypeiq_source_control" "test" {
+  application_id = sonatypeiq_application.test.id
+  repository_url = "https://github.com/sonatype/%s"
+  base_branch = "%s"
+  token = "%s"
+  token_version = %d
+}`, name, name, name, branch, token, tokenVersion)
+}
+
+func TestSourceControlResourceDefaultOrganization(t *testing.T) {
+	const (
+		defaultOrganizationId = "2f2f5a0ff0c64c0c8c8b9d3f1d0d7f0a"
+		priorOrganizationId   = "a3f4e5d6c7b8a9f0e1d2c3b4a5f6e7d8"
+		applicationId         = "c5b6a7f8e9d0c1b2a3f4e5d6c7b8a9f0"
+	)
+	scm := map[string]tftypes.Value{
+		"scm_provider": tftypes.NewValue(tftypes.String, "github"),
+	}
+
+	for _, test := range []struct {
+		name                  string
+		defaultOrganizationId string
+		config                map[string]tftypes.Value
+		prior                 map[string]tftypes.Value
+		expectedError         string
+		expected              interface{}
+		replace               bool
+	}{
+		{
+			name:                  "default",
+			defaultOrganizationId: defaultOrganizationId,
+			config:                scm,
+			expected:              defaultOrganizationId,
+		},
+		{
+			name:                  "application",
+			defaultOrganizationId: defaultOrganizationId,
+			config: map[string]tftypes.Value{
+				"application_id": tftypes.NewValue(tftypes.String, applicationId),
+			},
+			expected: nil,
+		},
+		{
+			name:          "no default",
+			config:        scm,
+			expectedError: "Missing Owner",
+		},
+		{
+			name:                  "root organization",
+			defaultOrganizationId: rootOrganizationId,
+			config:                scm,
+			expectedError:         "base_branch must be configured for the Root Organization",
+		},
+		{
+			name:                  "changed default",
+			defaultOrganizationId: defaultOrganizationId,
+			config:                scm,
+			prior: map[string]tftypes.Value{
+				"id":              tftypes.NewValue(tftypes.String, "e9f0a1b2c3d4e5f6a7b8c9d0e1f2a3b4"),
+				"organization_id": tftypes.NewValue(tftypes.String, priorOrganizationId),
+				"scm_provider":    tftypes.NewValue(tftypes.String, "github"),
+			},
+			expected: defaultOrganizationId,
+			replace:  true,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			server, schemas := testProviderServerWithConfig(t, newMockIqServer(t), map[string]tftypes.Value{
+				"default_organization_id": tftypes.NewValue(tftypes.String, test.defaultOrganizationId),
+			})
+			s := schemas["sonatypeiq_source_control"]
+
+			// Terraform proposes the prior values of computed attributes that are not configured
+			proposed := map[string]tftypes.Value{}
+			for name, value := range test.prior {
+				proposed[name] = value
+			}
+			for name, value := range test.config {
+				proposed[name] = value
+			}
+
+			planned, err := server.PlanResourceChange(context.Background(), &tfprotov6.PlanResourceChangeRequest{
+				TypeName:         "sonatypeiq_source_control",
+				PriorState:       testDynamicValue(t, s, test.prior, test.prior == nil),
+				ProposedNewState: testDynamicValue(t, s, proposed, false),
+				Config:           testDynamicValue(t, s, test.config, false),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !checkDiagnostics(t, "plan", planned.Diagnostics, test.expectedError) {
+				return
+			}
+
+			var attributes map[string]tftypes.Value
+			if err := testStateValue(t, s, planned.PlannedState).As(&attributes); err != nil {
+				t.Fatal(err)
+			}
+			var organizationId *string
+			if err := attributes["organization_id"].As(&organizationId); err != nil {
+				t.Fatal(err)
+			}
+			switch expected := test.expected.(type) {
+			case string:
+				if organizationId == nil || *organizationId != expected {
+					t.Errorf("Expected organization_id %s to be planned, got %v", expected, attributes["organization_id"])
+				}
+			default:
+				if organizationId != nil {
+					t.Errorf("Expected no organization_id to be planned, got %s", *organizationId)
+				}
+			}
+
+			replace := false
+			for _, attribute := range planned.RequiresReplace {
+				replace = replace || attribute.Equal(tftypes.NewAttributePath().WithAttributeName("organization_id"))
+			}
+			if replace != test.replace {
+				t.Errorf("Expected replacing the Source Control configuration to be %t, got %t", test.replace, replace)
+			}
+		})
+	}
+}
+
+// testPrivateState is an in-memory private state.
+type testPrivateState map[string][]byte
+
+func (s testPrivateState) GetKey(_ context.Context, key string) ([]byte, diag.Diagnostics) {
+	return s[key], nil
+}
+
+func (s testPrivateState) SetKey(_ context.Context, key string, value []byte) diag.Diagnostics {
+	s[key] = value
+	return nil
+}
+
+func TestSourceControlResourceImportState(t *testing.T) {
+	const applicationId = "c5b6a7f8e9d0c1b2a3f4e5d6c7b8a9f0"
+	server, schemas := testProviderServer(t, newMockIqServer(t))
+
+	for id, expected := range map[string]map[string]string{
+		"application/" + applicationId:      {"application_id": applicationId},
+		"application_" + applicationId:      {"application_id": applicationId},
+		"organization/ROOT_ORGANIZATION_ID": {"organization_id": "ROOT_ORGANIZATION_ID"},
+		"organization_ROOT_ORGANIZATION_ID": {"organization_id": "ROOT_ORGANIZATION_ID"},
+		"repository/" + applicationId:       nil,
+	} {
+		imported, err := server.ImportResourceState(context.Background(), &tfprotov6.ImportResourceStateRequest{
+			TypeName: "sonatypeiq_source_control",
+			ID:       id,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		expectedError := ""
+		if expected == nil {
+			expectedError = "must be either organization or application"
+		}
+		if !checkDiagnostics(t, "import of "+id, imported.Diagnostics, expectedError) || expected == nil {
+			continue
+		}
+
+		var state map[string]tftypes.Value
+		if err := testStateValue(t, schemas["sonatypeiq_source_control"], imported.ImportedResources[0].State).As(&state); err != nil {
+			t.Fatal(err)
+		}
+		for _, name := range []string{"organization_id", "application_id"} {
+			var value *string
+			if err := state[name].As(&value); err != nil {
+				t.Fatal(err)
+			}
+			if expected[name] == "" && value != nil || expected[name] != "" && (value == nil || *value != expected[name]) {
+				t.Errorf("Expected %s of import %s to be '%s', got %v", name, id, expected[name], state[name])
+			}
+		}
+	}
+}
+
+func TestTokenFingerprint(t *testing.T) {
+	ctx := context.Background()
+	token := types.StringValue("ghp_secret")
+
+	// Without a fingerprint, e.g. after an import or with a fingerprint of an earlier version
+	private := testPrivateState{}
+	if !tokenChanged(ctx, private, token) {
+		t.Error("Expected a token without fingerprint to be changed")
+	}
+	private[privateKeyTokenFingerprint] = []byte(`"0a1b2c3d"`)
+	if !tokenChanged(ctx, private, token) {
+		t.Error("Expected a token with a fingerprint of an earlier version to be changed")
+	}
+
+	if diags := rememberToken(ctx, private, token); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if bytes.Contains(private[privateKeyTokenFingerprint], []byte("ghp_secret")) {
+		t.Errorf("Expected the fingerprint not to contain the token, got %s", private[privateKeyTokenFingerprint])
+	}
+	if tokenChanged(ctx, private, token) {
+		t.Error("Expected the token that was remembered not to be changed")
+	}
+	if !tokenChanged(ctx, private, types.StringValue("ghp_other")) {
+		t.Error("Expected another token to be changed")
+	}
+	if tokenChanged(ctx, private, types.StringNull()) {
+		t.Error("Expected no token not to be changed")
+	}
+
+	// Every fingerprint is salted differently, so equal tokens cannot be recognised across resources
+	other := testPrivateState{}
+	if diags := rememberToken(ctx, other, token); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if bytes.Equal(private[privateKeyTokenFingerprint], other[privateKeyTokenFingerprint]) {
+		t.Error("Expected fingerprints of the same token to differ")
+	}
+}
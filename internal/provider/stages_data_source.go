@@ -0,0 +1,132 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	sonatypeiq "github.com/sonatype-nexus-community/nexus-iq-api-client-go"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &stagesDataSource{}
+	_ datasource.DataSourceWithConfigure = &stagesDataSource{}
+)
+
+// StagesDataSource is a helper function to simplify the provider implementation.
+func StagesDataSource() datasource.DataSource {
+	return &stagesDataSource{}
+}
+
+// stagesDataSource is the data source implementation.
+type stagesDataSource struct {
+	baseDataSource
+}
+
+type stagesDataSourceModel struct {
+	ID     types.String `tfsdk:"id"`
+	Stages []stageModel `tfsdk:"stages"`
+}
+
+type stageModel struct {
+	ID   types.String `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+}
+
+// stageDTO is a lifecycle Stage as returned by IQ Server.
+type stageDTO struct {
+	StageId   *string `json:"stageId,omitempty"`
+	StageName *string `json:"stageName,omitempty"`
+}
+
+// Metadata returns the data source type name.
+func (d *stagesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_stages"
+}
+
+// Schema defines the schema for the data source.
+func (d *stagesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Use this data source to get the lifecycle Stages supported by Sonatype IQ Server",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"stages": schema.ListNestedAttribute{
+				Description: "List of Stages",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "ID of the Stage (e.g. 'build') as used by other resources and the API",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Display name of the Stage",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *stagesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state stagesDataSourceModel
+
+	ctx = context.WithValue(
+		ctx,
+		sonatypeiq.ContextBasicAuth,
+		d.auth,
+	)
+
+	// Stages are not exposed by the generated API client
+	var stages []stageDTO
+	api_response, err := callIqApi(ctx, d.client, http.MethodGet, "/rest/policy/stages", nil, &stages)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read IQ Stages",
+			describeApiError(api_response, err),
+		)
+		return
+	}
+
+	for _, stage := range stages {
+		state.Stages = append(state.Stages, stageModel{
+			ID:   types.StringPointerValue(stage.StageId),
+			Name: types.StringPointerValue(stage.StageName),
+		})
+	}
+
+	// For test framework
+	state.ID = types.StringValue("placeholder")
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,293 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	sonatypeiq "github.com/sonatype-nexus-community/nexus-iq-api-client-go"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &successMetricsDataSource{}
+	_ datasource.DataSourceWithConfigure = &successMetricsDataSource{}
+)
+
+// SuccessMetricsDataSource is a helper function to simplify the provider implementation.
+func SuccessMetricsDataSource() datasource.DataSource {
+	return &successMetricsDataSource{}
+}
+
+// successMetricsDataSource is the data source implementation.
+type successMetricsDataSource struct {
+	baseDataSource
+}
+
+type successMetricsDataSourceModel struct {
+	ID              types.String                      `tfsdk:"id"`
+	TimePeriod      types.String                      `tfsdk:"time_period"`
+	FirstTimePeriod types.String                      `tfsdk:"first_time_period"`
+	LastTimePeriod  types.String                      `tfsdk:"last_time_period"`
+	OrganizationIds []types.String                    `tfsdk:"organization_ids"`
+	Organizations   []organizationSuccessMetricsModel `tfsdk:"organizations"`
+}
+
+type organizationSuccessMetricsModel struct {
+	OrganizationId     types.String `tfsdk:"organization_id"`
+	OrganizationName   types.String `tfsdk:"organization_name"`
+	ApplicationCount   types.Int64  `tfsdk:"application_count"`
+	EvaluationCount    types.Int64  `tfsdk:"evaluation_count"`
+	Discovered         types.Int64  `tfsdk:"discovered"`
+	Fixed              types.Int64  `tfsdk:"fixed"`
+	Waived             types.Int64  `tfsdk:"waived"`
+	Open               types.Int64  `tfsdk:"open"`
+	MttrLowThreat      types.Int64  `tfsdk:"mttr_low_threat"`
+	MttrModerateThreat types.Int64  `tfsdk:"mttr_moderate_threat"`
+	MttrSevereThreat   types.Int64  `tfsdk:"mttr_severe_threat"`
+	MttrCriticalThreat types.Int64  `tfsdk:"mttr_critical_threat"`
+}
+
+// mttrThreatLevels are the threat levels mean times to resolution are reported for.
+var mttrThreatLevels = []string{"LOW", "MODERATE", "SEVERE", "CRITICAL"}
+
+// Metadata returns the data source type name.
+func (d *successMetricsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_success_metrics"
+}
+
+// Schema defines the schema for the data source.
+func (d *successMetricsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	mttrAttribute := func(threatLevel string) schema.Int64Attribute {
+		return schema.Int64Attribute{
+			Description: "Mean time to resolution of " + threatLevel + " threat violations in milliseconds, weighted by the number of violations resolved - null when none were resolved",
+			Computed:    true,
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Use this data source to get Success Metrics aggregates (violations resolved, mean time to resolution) per Organization for a time range",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"time_period": schema.StringAttribute{
+				Description: "Granularity of first_time_period and last_time_period, one of " + strings.Join(metricsTimePeriods, ", "),
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(metricsTimePeriods...),
+				},
+			},
+			"first_time_period": schema.StringAttribute{
+				Description: "First time period to include - the start date of a week (YYYY-MM-DD, a Monday) or a month (YYYY-MM)",
+				Required:    true,
+				Validators:  metricsTimePeriodValidators,
+			},
+			"last_time_period": schema.StringAttribute{
+				Description: "Last time period to include, in the same format as first_time_period - up to the current time period when not set",
+				Optional:    true,
+				Validators:  metricsTimePeriodValidators,
+			},
+			"organization_ids": schema.SetAttribute{
+				Description: "Only include these Organizations (internal IDs) - all Organizations are included when not set",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"organizations": schema.ListNestedAttribute{
+				Description: "Success Metrics aggregated over the time range per Organization",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"organization_id": schema.StringAttribute{
+							Description: "Internal ID of the Organization",
+							Computed:    true,
+						},
+						"organization_name": schema.StringAttribute{
+							Description: "Name of the Organization",
+							Computed:    true,
+						},
+						"application_count": schema.Int64Attribute{
+							Description: "Number of Applications with Success Metrics in the time range",
+							Computed:    true,
+						},
+						"evaluation_count": schema.Int64Attribute{
+							Description: "Number of policy evaluations in the time range",
+							Computed:    true,
+						},
+						"discovered": schema.Int64Attribute{
+							Description: "Number of violations discovered in the time range",
+							Computed:    true,
+						},
+						"fixed": schema.Int64Attribute{
+							Description: "Number of violations fixed in the time range",
+							Computed:    true,
+						},
+						"waived": schema.Int64Attribute{
+							Description: "Number of violations waived in the time range",
+							Computed:    true,
+						},
+						"open": schema.Int64Attribute{
+							Description: "Number of violations open at the end of the time range",
+							Computed:    true,
+						},
+						"mttr_low_threat":      mttrAttribute("low"),
+						"mttr_moderate_threat": mttrAttribute("moderate"),
+						"mttr_severe_threat":   mttrAttribute("severe"),
+						"mttr_critical_threat": mttrAttribute("critical"),
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *successMetricsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if deferUnknownOwnerRead(req, resp) {
+		return
+	}
+
+	var data successMetricsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = context.WithValue(
+		ctx,
+		sonatypeiq.ContextBasicAuth,
+		d.auth,
+	)
+
+	query := sonatypeiq.ApiMetricsReportingQueryDTOV2{
+		TimePeriod:      data.TimePeriod.ValueStringPointer(),
+		FirstTimePeriod: data.FirstTimePeriod.ValueStringPointer(),
+		LastTimePeriod:  data.LastTimePeriod.ValueStringPointer(),
+	}
+	for _, organizationId := range data.OrganizationIds {
+		query.OrganizationIds = append(query.OrganizationIds, organizationId.ValueString())
+	}
+
+	metrics, api_response, err := getMetrics(ctx, d.client, query)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read IQ success metrics",
+			describeApiError(api_response, err),
+		)
+		return
+	}
+
+	type organizationTotals struct {
+		name                            *string
+		applications, evaluations       int64
+		discovered, fixed, waived, open int64
+		mttrWeightedSum, mttrResolved   map[string]int64
+	}
+	totals := map[string]*organizationTotals{}
+	for _, application := range metrics {
+		organizationId := types.StringPointerValue(application.OrganizationId).ValueString()
+		organization, ok := totals[organizationId]
+		if !ok {
+			organization = &organizationTotals{
+				name:            application.OrganizationName,
+				mttrWeightedSum: map[string]int64{},
+				mttrResolved:    map[string]int64{},
+			}
+			totals[organizationId] = organization
+		}
+		organization.applications++
+
+		var latest *metricsAggregation
+		for i, aggregation := range application.Aggregations {
+			organization.evaluations += aggregation.EvaluationCount
+			organization.discovered += sumViolationCounts(aggregation.DiscoveredCounts, "")
+			organization.fixed += sumViolationCounts(aggregation.FixedCounts, "")
+			organization.waived += sumViolationCounts(aggregation.WaivedCounts, "")
+
+			for threatLevel, mttr := range map[string]*int64{
+				"LOW":      aggregation.MttrLowThreat,
+				"MODERATE": aggregation.MttrModerateThreat,
+				"SEVERE":   aggregation.MttrSevereThreat,
+				"CRITICAL": aggregation.MttrCriticalThreat,
+			} {
+				resolved := sumViolationCounts(aggregation.FixedCounts, threatLevel) + sumViolationCounts(aggregation.WaivedCounts, threatLevel)
+				if mttr != nil && resolved > 0 {
+					organization.mttrWeightedSum[threatLevel] += *mttr * resolved
+					organization.mttrResolved[threatLevel] += resolved
+				}
+			}
+
+			if latest == nil || aggregation.TimePeriodStart > latest.TimePeriodStart {
+				latest = &application.Aggregations[i]
+			}
+		}
+		if latest != nil {
+			organization.open += sumViolationCounts(latest.OpenCountsAtTimePeriodEnd, "")
+		}
+	}
+
+	organizationIds := make([]string, 0, len(totals))
+	for organizationId := range totals {
+		organizationIds = append(organizationIds, organizationId)
+	}
+	sort.Strings(organizationIds)
+
+	data.Organizations = make([]organizationSuccessMetricsModel, 0, len(organizationIds))
+	for _, organizationId := range organizationIds {
+		organization := totals[organizationId]
+		mttr := map[string]types.Int64{}
+		for _, threatLevel := range mttrThreatLevels {
+			mttr[threatLevel] = types.Int64Null()
+			if organization.mttrResolved[threatLevel] > 0 {
+				mttr[threatLevel] = types.Int64Value(organization.mttrWeightedSum[threatLevel] / organization.mttrResolved[threatLevel])
+			}
+		}
+		data.Organizations = append(data.Organizations, organizationSuccessMetricsModel{
+			OrganizationId:     types.StringValue(organizationId),
+			OrganizationName:   types.StringPointerValue(organization.name),
+			ApplicationCount:   types.Int64Value(organization.applications),
+			EvaluationCount:    types.Int64Value(organization.evaluations),
+			Discovered:         types.Int64Value(organization.discovered),
+			Fixed:              types.Int64Value(organization.fixed),
+			Waived:             types.Int64Value(organization.waived),
+			Open:               types.Int64Value(organization.open),
+			MttrLowThreat:      mttr["LOW"],
+			MttrModerateThreat: mttr["MODERATE"],
+			MttrSevereThreat:   mttr["SEVERE"],
+			MttrCriticalThreat: mttr["CRITICAL"],
+		})
+	}
+
+	data.ID = types.StringValue(data.TimePeriod.ValueString() + "_" + data.FirstTimePeriod.ValueString())
+
+	// Set state
+	diags := resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
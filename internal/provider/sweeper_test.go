@@ -0,0 +1,238 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	sonatypeiq "github.com/sonatype-nexus-community/nexus-iq-api-client-go"
+)
+
+// testAccPrefix prefixes the names of the Organizations and Applications created by acceptance
+// tests, so that sweepers can tell leftovers of interrupted test runs apart from anything else.
+const testAccPrefix = "tf-acc-test"
+
+// testAccRandomName returns a unique name for an entity created by an acceptance test.
+func testAccRandomName() string {
+	return acctest.RandomWithPrefix(testAccPrefix)
+}
+
+// TestMain runs the sweepers instead of the tests when go test is called with -sweep.
+func TestMain(m *testing.M) {
+	resource.TestMain(m)
+}
+
+// Sweepers remove Organizations and Applications named with the testAccPrefix, and the Source
+// Control configurations and (Auto) Policy Waivers of the sandbox fixtures. IQ Server has no
+// regions, so any value of -sweep will do.
+func init() {
+	resource.AddTestSweepers("sonatypeiq_application", &resource.Sweeper{
+		Name: "sonatypeiq_application",
+		F:    sweepApplications,
+	})
+	resource.AddTestSweepers("sonatypeiq_organization", &resource.Sweeper{
+		Name:         "sonatypeiq_organization",
+		Dependencies: []string{"sonatypeiq_application"},
+		F:            sweepOrganizations,
+	})
+	resource.AddTestSweepers("sonatypeiq_source_control", &resource.Sweeper{
+		Name: "sonatypeiq_source_control",
+		F:    sweepSourceControl,
+	})
+	resource.AddTestSweepers("sonatypeiq_policy_waiver", &resource.Sweeper{
+		Name: "sonatypeiq_policy_waiver",
+		F:    sweepPolicyWaivers,
+	})
+	resource.AddTestSweepers("sonatypeiq_auto_policy_waiver", &resource.Sweeper{
+		Name: "sonatypeiq_auto_policy_waiver",
+		F:    sweepAutoPolicyWaivers,
+	})
+}
+
+func sweepApplications(_ string) error {
+	ctx, client, err := newTestAccClient()
+	if err != nil {
+		return err
+	}
+
+	applications, apiResponse, err := client.ApplicationsAPI.GetApplications(ctx).Execute()
+	if err != nil {
+		return fmt.Errorf("unable to list Applications: %s", describeApiError(apiResponse, err))
+	}
+	for _, application := range applications.Applications {
+		if !strings.HasPrefix(application.GetPublicId(), testAccPrefix) && !strings.HasPrefix(application.GetName(), testAccPrefix) {
+			continue
+		}
+		log.Printf("[INFO] Deleting Application %s", application.GetPublicId())
+		apiResponse, err := client.ApplicationsAPI.DeleteApplication(ctx, application.GetId()).Execute()
+		if err != nil && !isNotFound(apiResponse) {
+			return fmt.Errorf("unable to delete Application %s: %s", application.GetPublicId(), describeApiError(apiResponse, err))
+		}
+	}
+	return nil
+}
+
+func sweepOrganizations(_ string) error {
+	ctx, client, err := newTestAccClient()
+	if err != nil {
+		return err
+	}
+
+	organizations, apiResponse, err := client.OrganizationsAPI.GetOrganizations(ctx).Execute()
+	if err != nil {
+		return fmt.Errorf("unable to list Organizations: %s", describeApiError(apiResponse, err))
+	}
+
+	// Organizations are deleted together with their contents, as force_destroy does
+	r := &organizationResource{baseResource{client: client}}
+	children := childOrganizations(organizations.Organizations)
+	for _, organization := range organizations.Organizations {
+		if !strings.HasPrefix(organization.GetName(), testAccPrefix) {
+			continue
+		}
+		log.Printf("[INFO] Deleting Organization %s", organization.GetName())
+		if diags := r.deleteContents(ctx, organization.GetId(), children); diags.HasError() {
+			return fmt.Errorf("%s: %s", diags[0].Summary(), diags[0].Detail())
+		}
+		apiResponse, err := client.OrganizationsAPI.DeleteOrganization(ctx, organization.GetId()).Execute()
+		if err != nil && !isNotFound(apiResponse) {
+			return fmt.Errorf("unable to delete Organization %s: %s", organization.GetName(), describeApiError(apiResponse, err))
+		}
+	}
+	return nil
+}
+
+func sweepSourceControl(_ string) error {
+	ctx, client, err := newTestAccClient()
+	if err != nil {
+		return err
+	}
+
+	owners, err := sandboxOwners(ctx, client)
+	if err != nil {
+		return err
+	}
+	for _, owner := range owners {
+		log.Printf("[INFO] Deleting Source Control configuration of %s %s", owner.ownerType, owner.ownerId)
+		apiResponse, err := client.SourceControlAPI.DeleteSourceControl(ctx, owner.ownerType, owner.ownerId).Execute()
+		if err != nil && !isNotFound(apiResponse) {
+			return fmt.Errorf("unable to delete Source Control configuration of %s %s: %s", owner.ownerType, owner.ownerId, describeApiError(apiResponse, err))
+		}
+	}
+	return nil
+}
+
+func sweepPolicyWaivers(_ string) error {
+	ctx, client, err := newTestAccClient()
+	if err != nil {
+		return err
+	}
+
+	owners, err := sandboxOwners(ctx, client)
+	if err != nil {
+		return err
+	}
+	for _, owner := range owners {
+		waivers, apiResponse, err := client.PolicyWaiversAPI.GetPolicyWaivers(ctx, owner.ownerType, owner.ownerId).Execute()
+		if err != nil {
+			return fmt.Errorf("unable to list Policy Waivers of %s %s: %s", owner.ownerType, owner.ownerId, describeApiError(apiResponse, err))
+		}
+		for _, waiver := range waivers {
+			log.Printf("[INFO] Deleting Policy Waiver %s of %s %s", waiver.GetPolicyWaiverId(), owner.ownerType, owner.ownerId)
+			apiResponse, err := client.PolicyWaiversAPI.DeletePolicyWaiver(ctx, owner.ownerType, owner.ownerId, waiver.GetPolicyWaiverId()).Execute()
+			if err != nil && !isNotFound(apiResponse) {
+				return fmt.Errorf("unable to delete Policy Waiver %s: %s", waiver.GetPolicyWaiverId(), describeApiError(apiResponse, err))
+			}
+		}
+	}
+	return nil
+}
+
+func sweepAutoPolicyWaivers(_ string) error {
+	ctx, client, err := newTestAccClient()
+	if err != nil {
+		return err
+	}
+
+	owners, err := sandboxOwners(ctx, client)
+	if err != nil {
+		return err
+	}
+	for _, owner := range owners {
+		path := "/api/v2/autoPolicyWaivers/" + owner.ownerType + "/" + url.PathEscape(owner.ownerId)
+		var autoPolicyWaiver autoPolicyWaiverDTO
+		apiResponse, err := callIqApi(ctx, client, http.MethodGet, path, nil, &autoPolicyWaiver)
+		if isNotFound(apiResponse) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("unable to read Auto Policy Waiver of %s %s: %s", owner.ownerType, owner.ownerId, describeApiError(apiResponse, err))
+		}
+
+		// Skip configurations inherited from a parent Organization
+		if autoPolicyWaiver.OwnerId == nil || *autoPolicyWaiver.OwnerId != owner.ownerId {
+			continue
+		}
+		log.Printf("[INFO] Deleting Auto Policy Waiver of %s %s", owner.ownerType, owner.ownerId)
+		apiResponse, err = callIqApi(ctx, client, http.MethodDelete, path+"/"+url.PathEscape(autoPolicyWaiver.GetId()), nil, nil)
+		if err != nil && !isNotFound(apiResponse) {
+			return fmt.Errorf("unable to delete Auto Policy Waiver of %s %s: %s", owner.ownerType, owner.ownerId, describeApiError(apiResponse, err))
+		}
+	}
+	return nil
+}
+
+// sandboxOwner is an Organization or Application of the sandbox fixtures.
+type sandboxOwner struct {
+	ownerType string
+	ownerId   string
+}
+
+// sandboxOwners returns the sandbox fixtures that exist on IQ Server. Everything configured on
+// them is left behind by acceptance tests.
+func sandboxOwners(ctx context.Context, client *sonatypeiq.APIClient) ([]sandboxOwner, error) {
+	var owners []sandboxOwner
+
+	organizations, apiResponse, err := client.OrganizationsAPI.GetOrganizations(ctx).OrganizationName([]string{sandboxOrganizationName}).Execute()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the %s: %s", sandboxOrganizationName, describeApiError(apiResponse, err))
+	}
+	for _, organization := range organizations.Organizations {
+		if organization.GetName() == sandboxOrganizationName {
+			owners = append(owners, sandboxOwner{"organization", organization.GetId()})
+		}
+	}
+
+	applications, apiResponse, err := client.ApplicationsAPI.GetApplications(ctx).PublicId([]string{sandboxApplicationPublicId}).Execute()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read Application %s: %s", sandboxApplicationPublicId, describeApiError(apiResponse, err))
+	}
+	for _, application := range applications.Applications {
+		owners = append(owners, sandboxOwner{"application", application.GetId()})
+	}
+
+	return owners, nil
+}
@@ -100,7 +100,7 @@ func (d *systemConfigDataSource) Read(ctx context.Context, req datasource.ReadRe
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read IQ System Configuration",
-			err.Error(),
+			describeApiError(r, err),
 		)
 		return
 	}
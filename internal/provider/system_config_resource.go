@@ -18,11 +18,11 @@ package provider
 
 import (
 	"context"
-	"io"
 	"math/rand"
 	"strconv"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -105,10 +105,9 @@ func (r *systemConfigResource) Create(ctx context.Context, req resource.CreateRe
 
 	// Call API
 	if err != nil || api_response.StatusCode != 204 {
-		error_body, _ := io.ReadAll(api_response.Body)
 		resp.Diagnostics.AddError(
 			"Error creating System Configuration",
-			"Could not create System Configuration, unexpected error: "+api_response.Status+": "+string(error_body),
+			apiErrorDetail("Could not create System Configuration", api_response, err),
 		)
 		return
 	}
@@ -149,10 +148,9 @@ func (r *systemConfigResource) Read(ctx context.Context, req resource.ReadReques
 	system_config, api_response, err := config_request.Execute()
 
 	if err != nil || api_response.StatusCode != 200 {
-		error_body, _ := io.ReadAll(api_response.Body)
 		resp.Diagnostics.AddError(
 			"Error reading System Configuration",
-			"Could not read System Configuration, unexpected error: "+api_response.Status+": "+string(error_body),
+			apiErrorDetail("Could not read System Configuration", api_response, err),
 		)
 		return
 	}
@@ -203,10 +201,9 @@ func (r *systemConfigResource) Update(ctx context.Context, req resource.UpdateRe
 
 	// Call API
 	if err != nil || api_response.StatusCode != 204 {
-		error_body, _ := io.ReadAll(api_response.Body)
 		resp.Diagnostics.AddError(
 			"Error updating System Configuration",
-			"Could not update System Configuration, unexpected error: "+api_response.Status+": "+string(error_body),
+			apiErrorDetail("Could not update System Configuration", api_response, err),
 		)
 		return
 	}
@@ -226,3 +223,15 @@ func (r *systemConfigResource) Update(ctx context.Context, req resource.UpdateRe
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *systemConfigResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 }
+
+// MoveState moves the resource from the Sonatype IQ community provider.
+func (r *systemConfigResource) MoveState(_ context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		moveResourceState("sonatypeiq_system_config", nil),
+	}
+}
+
+// ImportState imports the System Configuration - as there is only one, any ID is accepted.
+func (r *systemConfigResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,116 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// throttleTransport limits the number of concurrent requests and, optionally, the number of
+// requests per second sent to IQ Server.
+type throttleTransport struct {
+	next http.RoundTripper
+
+	// semaphore holds a token for every request in flight - nil when unlimited
+	semaphore chan struct{}
+
+	// interval is the minimum time between two requests - zero when unlimited
+	interval time.Duration
+	mu       sync.Mutex
+	nextAt   time.Time
+}
+
+func newThrottleTransport(next http.RoundTripper, maxConcurrentRequests int, requestsPerSecond float64) *throttleTransport {
+	t := &throttleTransport{next: next}
+	if maxConcurrentRequests > 0 {
+		t.semaphore = make(chan struct{}, maxConcurrentRequests)
+	}
+	if requestsPerSecond > 0 {
+		t.interval = time.Duration(float64(time.Second) / requestsPerSecond)
+	}
+	return t
+}
+
+func (t *throttleTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.semaphore != nil {
+		select {
+		case t.semaphore <- struct{}{}:
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+	}
+
+	if err := t.wait(req); err != nil {
+		t.release()
+		return nil, err
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || t.semaphore == nil {
+		t.release()
+		return resp, err
+	}
+
+	// The request is in flight until its body has been consumed
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: t.release}
+	return resp, nil
+}
+
+// wait blocks until the request is allowed to be sent according to the requests per second limit.
+func (t *throttleTransport) wait(req *http.Request) error {
+	if t.interval == 0 {
+		return nil
+	}
+
+	t.mu.Lock()
+	now := time.Now()
+	at := t.nextAt
+	if at.Before(now) {
+		at = now
+	}
+	t.nextAt = at.Add(t.interval)
+	t.mu.Unlock()
+
+	select {
+	case <-req.Context().Done():
+		return req.Context().Err()
+	case <-time.After(time.Until(at)):
+		return nil
+	}
+}
+
+func (t *throttleTransport) release() {
+	if t.semaphore != nil {
+		<-t.semaphore
+	}
+}
+
+// releasingBody releases the concurrency slot of a request once its response body is closed.
+type releasingBody struct {
+	io.ReadCloser
+	release func()
+	once    sync.Once
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
@@ -0,0 +1,79 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestTimeoutTransportBoundsBodyReads(t *testing.T) {
+	stalled := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("%PDF-1.7"))
+		w.(http.Flusher).Flush()
+		<-stalled
+	}))
+	defer server.Close()
+	defer close(stalled)
+
+	client := &http.Client{Transport: &timeoutTransport{next: http.DefaultTransport, timeout: 100 * time.Millisecond}}
+	resp, err := client.Get(server.URL + "/api/v2/applications/web-app/reports/1/pdf")
+	if err != nil {
+		t.Fatalf("Expected the response headers before the timeout, got: %s", err)
+	}
+	defer resp.Body.Close()
+
+	done := make(chan error)
+	go func() {
+		_, err := io.ReadAll(resp.Body)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if !errors.Is(err, os.ErrDeadlineExceeded) && !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Expected reading the stalled body to exceed the deadline, got: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Reading the stalled body did not time out")
+	}
+}
+
+func TestTimeoutTransportReleasesCompletedRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"organizations":[]}`))
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &timeoutTransport{next: http.DefaultTransport, timeout: time.Second}}
+	resp, err := client.Get(server.URL + "/api/v2/organizations")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil || string(body) != `{"organizations":[]}` {
+		t.Errorf("Expected the complete body, got %q and error %v", body, err)
+	}
+}
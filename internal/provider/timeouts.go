@@ -0,0 +1,128 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// defaultOperationTimeout is used for any operation that does not have a timeout configured.
+const defaultOperationTimeout = 20 * time.Minute
+
+// timeoutsModel holds the optional timeouts block of a resource. A nil *timeoutsModel means that
+// the block was not configured.
+type timeoutsModel struct {
+	Create types.String `tfsdk:"create"`
+	Read   types.String `tfsdk:"read"`
+	Update types.String `tfsdk:"update"`
+	Delete types.String `tfsdk:"delete"`
+}
+
+// timeoutsBlock returns the schema of the timeouts block shared by resources with long-running operations.
+func timeoutsBlock() schema.Block {
+	attribute := func(operation string) schema.StringAttribute {
+		return schema.StringAttribute{
+			Description: fmt.Sprintf("Maximum time the %s operation may take, as a duration such as \"30s\" or \"1h\" - defaults to 20m", operation),
+			Optional:    true,
+			Validators: []validator.String{
+				durationValidator{},
+			},
+		}
+	}
+
+	return schema.SingleNestedBlock{
+		Description: "Timeouts of the operations of this resource",
+		Attributes: map[string]schema.Attribute{
+			"create": attribute("create"),
+			"read":   attribute("read"),
+			"update": attribute("update"),
+			"delete": attribute("delete"),
+		},
+	}
+}
+
+// withTimeout derives a context that is cancelled once the configured timeout has passed.
+func withTimeout(ctx context.Context, timeout types.String) (context.Context, context.CancelFunc) {
+	duration := defaultOperationTimeout
+	if !timeout.IsNull() && !timeout.IsUnknown() {
+		// The value has already been validated at plan time
+		if parsed, err := time.ParseDuration(timeout.ValueString()); err == nil {
+			duration = parsed
+		}
+	}
+	return context.WithTimeout(ctx, duration)
+}
+
+func (t *timeoutsModel) create() types.String {
+	if t == nil {
+		return types.StringNull()
+	}
+	return t.Create
+}
+
+func (t *timeoutsModel) read() types.String {
+	if t == nil {
+		return types.StringNull()
+	}
+	return t.Read
+}
+
+func (t *timeoutsModel) update() types.String {
+	if t == nil {
+		return types.StringNull()
+	}
+	return t.Update
+}
+
+func (t *timeoutsModel) delete() types.String {
+	if t == nil {
+		return types.StringNull()
+	}
+	return t.Delete
+}
+
+// durationValidator validates that a string is a positive Go duration.
+type durationValidator struct{}
+
+func (v durationValidator) Description(_ context.Context) string {
+	return "value must be a positive duration such as \"30s\", \"10m\" or \"1h\""
+}
+
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v durationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	duration, err := time.ParseDuration(req.ConfigValue.ValueString())
+	if err != nil || duration <= 0 {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Duration",
+			fmt.Sprintf("The %s %s, got: %s", req.Path, v.Description(ctx), req.ConfigValue.ValueString()),
+		)
+	}
+}
@@ -20,9 +20,10 @@ import (
 	"context"
 	"crypto/sha1"
 	"fmt"
-	"io"
+	"net/http"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
@@ -155,10 +156,9 @@ func (r *userResource) Create(ctx context.Context, req resource.CreateRequest, r
 
 	// Call API
 	if err != nil || api_response.StatusCode != 204 {
-		error_body, _ := io.ReadAll(api_response.Body)
 		resp.Diagnostics.AddError(
 			"Error creating User",
-			"Could not create User, unexpected error: "+api_response.Status+": "+string(error_body),
+			apiErrorDetail("Could not create User", api_response, err),
 		)
 		return
 	}
@@ -167,6 +167,9 @@ func (r *userResource) Create(ctx context.Context, req resource.CreateRequest, r
 	plan.GenerateID()
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
+	// IQ Server may not return the new entity straight away
+	resp.Diagnostics.Append(markCreated(ctx, resp.Private)...)
+
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -190,13 +193,18 @@ func (r *userResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	)
 
 	// Lookup System Configuration
-	user, api_response, err := r.client.UsersAPI.Get1(ctx, state.Username.ValueString()).Execute()
+	var user *sonatypeiq.ApiUserDTO
+	var api_response *http.Response
+	var err error
+	resp.Diagnostics.Append(readConsistently(ctx, req.Private, resp.Private, func(ctx context.Context) bool {
+		user, api_response, err = r.client.UsersAPI.Get1(ctx, state.Username.ValueString()).Execute()
+		return isNotFound(api_response)
+	})...)
 
 	if err != nil || api_response.StatusCode != 200 {
-		error_body, _ := io.ReadAll(api_response.Body)
 		resp.Diagnostics.AddError(
 			"Error reading User",
-			"Could not read User, unexpected error: "+api_response.Status+": "+string(error_body),
+			apiErrorDetail("Could not read User", api_response, err),
 		)
 		return
 	}
@@ -205,6 +213,7 @@ func (r *userResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	state.LastName = types.StringValue(*user.LastName)
 	state.Email = types.StringValue(*user.Email)
 	state.Realm = types.StringValue(*user.Realm)
+	state.GenerateID()
 
 	// Set refreshed state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
@@ -269,10 +278,9 @@ func (r *userResource) Update(ctx context.Context, req resource.UpdateRequest, r
 
 	// Call API
 	if err != nil || api_response.StatusCode != 200 {
-		error_body, _ := io.ReadAll(api_response.Body)
 		resp.Diagnostics.AddError(
 			"Error updating User",
-			"Could not update User, unexpected error: "+api_response.Status+": "+string(error_body),
+			apiErrorDetail("Could not update User", api_response, err),
 		)
 		return
 	}
@@ -314,11 +322,23 @@ func (r *userResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 	// Call Delete API
 	api_response, err := r.client.UsersAPI.Delete1(ctx, plan.Username.ValueString()).Execute()
 	if err != nil || api_response.StatusCode != 204 {
-		error_body, _ := io.ReadAll(api_response.Body)
 		resp.Diagnostics.AddError(
 			"Error deleting User",
-			"Could not delete User, unexpected error: "+api_response.Status+": "+string(error_body),
+			apiErrorDetail("Could not delete User", api_response, err),
 		)
 		return
 	}
 }
+
+// MoveState moves the resource from the Sonatype IQ community provider.
+func (r *userResource) MoveState(_ context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		moveResourceState("sonatypeiq_user", nil),
+	}
+}
+
+// ImportState imports the User by its username.
+func (r *userResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("username"), req, resp)
+	resp.Diagnostics.Append(markCreated(ctx, resp.Private)...)
+}
@@ -0,0 +1,199 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// gitSuffix determines whether repository URLs must, may or must not end in .git.
+type gitSuffix string
+
+const (
+	gitSuffixOptional  gitSuffix = "optional"
+	gitSuffixRequired  gitSuffix = "required"
+	gitSuffixForbidden gitSuffix = "forbidden"
+)
+
+// gitSuffixes are the values of the repository_url_git_suffix attributes.
+var gitSuffixes = []string{string(gitSuffixOptional), string(gitSuffixRequired), string(gitSuffixForbidden)}
+
+// gitSuffixAttribute is the attribute next to a repository_url that configures its .git suffix.
+const gitSuffixAttribute = "repository_url_git_suffix"
+
+// scpLikeRepositoryUrl matches SSH repository URLs in the scp-like form git@github.com:org/repo.git.
+var scpLikeRepositoryUrl = regexp.MustCompile(`^[A-Za-z0-9._-]+@[A-Za-z0-9.-]+:[^/\s][^\s]*$`)
+
+// repositoryUrlValidator validates that a string is an https:// or SSH git repository URL. Whether
+// it must, may or must not end in .git is configured by the repository_url_git_suffix attribute
+// next to it.
+type repositoryUrlValidator struct{}
+
+func (v repositoryUrlValidator) Description(_ context.Context) string {
+	return describeRepositoryUrl(gitSuffixOptional) + ", ending in .git as configured by " + gitSuffixAttribute
+}
+
+func (v repositoryUrlValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v repositoryUrlValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	var configured types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, req.Path.ParentPath().AtName(gitSuffixAttribute), &configured)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	suffix := gitSuffixOptional
+	if !configured.IsNull() && !configured.IsUnknown() {
+		suffix = gitSuffix(configured.ValueString())
+	}
+
+	if problem := validateRepositoryUrl(req.ConfigValue.ValueString(), suffix); problem != "" {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Repository URL",
+			fmt.Sprintf("The %s %s, got: %s (%s)", req.Path, describeRepositoryUrl(suffix), req.ConfigValue.ValueString(), problem),
+		)
+	}
+}
+
+// describeRepositoryUrl describes the repository URLs that are valid with the given .git suffix.
+func describeRepositoryUrl(suffix gitSuffix) string {
+	description := "value must be an https:// or SSH (ssh:// or git@host:path) git repository URL"
+	switch suffix {
+	case gitSuffixRequired:
+		description += " ending in .git"
+	case gitSuffixForbidden:
+		description += " not ending in .git"
+	}
+	return description
+}
+
+// validateRepositoryUrl returns what is wrong with the repository URL, or an empty string if it is
+// valid.
+func validateRepositoryUrl(repositoryUrl string, suffix gitSuffix) string {
+	if strings.ContainsAny(repositoryUrl, " \t\r\n") {
+		return "it contains whitespace"
+	}
+
+	var repositoryPath string
+	if scpLikeRepositoryUrl.MatchString(repositoryUrl) {
+		repositoryPath = repositoryUrl[strings.Index(repositoryUrl, ":")+1:]
+	} else {
+		parsed, err := url.Parse(repositoryUrl)
+		if err != nil || parsed.Scheme == "" {
+			return "it has no scheme"
+		}
+		if parsed.Scheme != "https" && parsed.Scheme != "ssh" {
+			return fmt.Sprintf("scheme %s is not supported", parsed.Scheme)
+		}
+		if parsed.Host == "" {
+			return "it has no host"
+		}
+		repositoryPath = strings.TrimPrefix(parsed.Path, "/")
+	}
+
+	if repositoryPath == "" {
+		return "it has no repository path"
+	}
+	if strings.HasSuffix(repositoryPath, ".git/") || strings.HasSuffix(repositoryPath, ".git.git") {
+		return "it ends in a malformed .git suffix"
+	}
+
+	hasGitSuffix := strings.HasSuffix(repositoryPath, ".git")
+	if suffix == gitSuffixRequired && !hasGitSuffix {
+		return "it does not end in .git"
+	}
+	if suffix == gitSuffixForbidden && hasGitSuffix {
+		return "it ends in .git"
+	}
+	return ""
+}
+
+// internalIdPattern matches the internal IDs Sonatype IQ Server assigns to e.g. Organizations,
+// Applications and Roles.
+var internalIdPattern = regexp.MustCompile(`^[0-9a-f]{32}$`)
+
+// internalIdValidator validates that a string is an internal ID, so that passing a public ID or
+// name instead is reported while planning rather than as a confusing error of the API.
+type internalIdValidator struct {
+	// object is what the internal ID identifies, e.g. Organization
+	object string
+
+	// dataSource looks up the internal ID of the object
+	dataSource string
+
+	// allowRoot accepts ROOT_ORGANIZATION_ID as well
+	allowRoot bool
+}
+
+var (
+	organizationIdValidator = internalIdValidator{object: "Organization", dataSource: "sonatypeiq_organization", allowRoot: true}
+	applicationIdValidator  = internalIdValidator{object: "Application", dataSource: "sonatypeiq_application"}
+	roleIdValidator         = internalIdValidator{object: "Role", dataSource: "sonatypeiq_role"}
+)
+
+func (v internalIdValidator) Description(_ context.Context) string {
+	description := fmt.Sprintf("value must be the internal ID of the %s (32 lowercase hexadecimal characters)", v.object)
+	if v.allowRoot {
+		description += " or " + rootOrganizationId
+	}
+	return description
+}
+
+func (v internalIdValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v internalIdValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	if internalIdPattern.MatchString(value) || (v.allowRoot && value == rootOrganizationId) {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		fmt.Sprintf("Invalid %s ID", v.object),
+		fmt.Sprintf("The %s %s, got: %s\n\n%s", req.Path, v.Description(ctx), value, v.hint(value)),
+	)
+}
+
+// hint suggests how to fix a value that is not an internal ID.
+func (v internalIdValidator) hint(value string) string {
+	if normalized := strings.ToLower(strings.ReplaceAll(value, "-", "")); internalIdPattern.MatchString(normalized) {
+		return fmt.Sprintf("Internal IDs are written in lowercase without dashes: %s", normalized)
+	}
+	if v.allowRoot && (strings.EqualFold(value, rootOrganizationId) || strings.EqualFold(value, "Root Organization")) {
+		return fmt.Sprintf("The Root Organization is identified by %s", rootOrganizationId)
+	}
+	return fmt.Sprintf("This looks like a public ID or name rather than an internal ID - look up the internal ID with the %s data source, e.g. data.%s.example.id", v.dataSource, v.dataSource)
+}
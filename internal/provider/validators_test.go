@@ -0,0 +1,105 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestInternalIdValidator(t *testing.T) {
+	for _, tc := range []struct {
+		validator internalIdValidator
+		value     string
+		hint      string
+	}{
+		{organizationIdValidator, "4bb67dcfc86344e3a483832f8c496419", ""},
+		{organizationIdValidator, "ROOT_ORGANIZATION_ID", ""},
+		{organizationIdValidator, "root_organization_id", "identified by ROOT_ORGANIZATION_ID"},
+		{organizationIdValidator, "Sandbox Organization", "sonatypeiq_organization data source"},
+		{applicationIdValidator, "ROOT_ORGANIZATION_ID", "sonatypeiq_application data source"},
+		{applicationIdValidator, "sandbox-application", "sonatypeiq_application data source"},
+		{roleIdValidator, "1CDDABF7-FDAA-47D6-8334-54AF10E0A3EF", "lowercase without dashes: 1cddabf7fdaa47d6833454af10e0a3ef"},
+	} {
+		resp := &validator.StringResponse{}
+		tc.validator.ValidateString(context.Background(), validator.StringRequest{
+			Path:        path.Root("id"),
+			ConfigValue: types.StringValue(tc.value),
+		}, resp)
+
+		if len(tc.hint) == 0 {
+			if resp.Diagnostics.HasError() {
+				t.Errorf("expected %s to be a valid %s ID, got: %v", tc.value, tc.validator.object, resp.Diagnostics)
+			}
+			continue
+		}
+		if !resp.Diagnostics.HasError() || !strings.Contains(resp.Diagnostics[0].Detail(), tc.hint) {
+			t.Errorf("expected %s to be reported with hint '%s', got: %v", tc.value, tc.hint, resp.Diagnostics)
+		}
+	}
+}
+
+func TestRepositoryUrlValidatorGitSuffix(t *testing.T) {
+	configSchema := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"repository_url":            schema.StringAttribute{Optional: true},
+			"repository_url_git_suffix": schema.StringAttribute{Optional: true},
+		},
+	}
+
+	for _, tc := range []struct {
+		suffix        interface{}
+		repositoryUrl string
+		valid         bool
+	}{
+		{nil, "https://github.com/sonatype/example.git", true},
+		{nil, "git@github.com:sonatype/example", true},
+		{"optional", "https://github.com/sonatype/example", true},
+		{"required", "https://github.com/sonatype/example.git", true},
+		{"required", "git@github.com:sonatype/example", false},
+		{"forbidden", "https://github.com/sonatype/example", true},
+		{"forbidden", "git@github.com:sonatype/example.git", false},
+		// Malformed suffixes are rejected regardless
+		{nil, "https://github.com/sonatype/example.git.git", false},
+	} {
+		config := tfsdk.Config{
+			Schema: configSchema,
+			Raw: tftypes.NewValue(configSchema.Type().TerraformType(context.Background()), map[string]tftypes.Value{
+				"repository_url":            tftypes.NewValue(tftypes.String, tc.repositoryUrl),
+				"repository_url_git_suffix": tftypes.NewValue(tftypes.String, tc.suffix),
+			}),
+		}
+		resp := &validator.StringResponse{}
+		repositoryUrlValidator{}.ValidateString(context.Background(), validator.StringRequest{
+			Path:        path.Root("repository_url"),
+			ConfigValue: types.StringValue(tc.repositoryUrl),
+			Config:      config,
+		}, resp)
+
+		if resp.Diagnostics.HasError() == tc.valid {
+			t.Errorf("expected %s to be valid %t with repository_url_git_suffix %v, got: %v", tc.repositoryUrl, tc.valid, tc.suffix, resp.Diagnostics)
+		}
+	}
+}
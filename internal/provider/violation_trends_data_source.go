@@ -0,0 +1,267 @@
+/*
+ * Copyright (c) 2019-present Sonatype, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	sonatypeiq "github.com/sonatype-nexus-community/nexus-iq-api-client-go"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &violationTrendsDataSource{}
+	_ datasource.DataSourceWithConfigure = &violationTrendsDataSource{}
+)
+
+// ViolationTrendsDataSource is a helper function to simplify the provider implementation.
+func ViolationTrendsDataSource() datasource.DataSource {
+	return &violationTrendsDataSource{}
+}
+
+// violationTrendsDataSource is the data source implementation.
+type violationTrendsDataSource struct {
+	baseDataSource
+}
+
+type violationTrendsDataSourceModel struct {
+	ID              types.String          `tfsdk:"id"`
+	TimePeriod      types.String          `tfsdk:"time_period"`
+	FirstTimePeriod types.String          `tfsdk:"first_time_period"`
+	LastTimePeriod  types.String          `tfsdk:"last_time_period"`
+	OrganizationIds []types.String        `tfsdk:"organization_ids"`
+	ApplicationIds  []types.String        `tfsdk:"application_ids"`
+	Trends          []violationTrendModel `tfsdk:"trends"`
+}
+
+type violationTrendModel struct {
+	TimePeriodStart  types.String `tfsdk:"time_period_start"`
+	OrganizationId   types.String `tfsdk:"organization_id"`
+	OrganizationName types.String `tfsdk:"organization_name"`
+	PolicyType       types.String `tfsdk:"policy_type"`
+	ThreatLevel      types.String `tfsdk:"threat_level"`
+	Discovered       types.Int64  `tfsdk:"discovered"`
+	Fixed            types.Int64  `tfsdk:"fixed"`
+	Waived           types.Int64  `tfsdk:"waived"`
+	Open             types.Int64  `tfsdk:"open"`
+}
+
+// Metadata returns the data source type name.
+func (d *violationTrendsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_violation_trends"
+}
+
+// Schema defines the schema for the data source.
+func (d *violationTrendsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Use this data source to get policy violation counts over time, aggregated per Organization, policy type and threat level",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"time_period": schema.StringAttribute{
+				Description: "Granularity of the trends, one of " + strings.Join(metricsTimePeriods, ", "),
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(metricsTimePeriods...),
+				},
+			},
+			"first_time_period": schema.StringAttribute{
+				Description: "First time period to include - the start date of a week (YYYY-MM-DD, a Monday) or a month (YYYY-MM)",
+				Required:    true,
+				Validators:  metricsTimePeriodValidators,
+			},
+			"last_time_period": schema.StringAttribute{
+				Description: "Last time period to include, in the same format as first_time_period - up to the current time period when not set",
+				Optional:    true,
+				Validators:  metricsTimePeriodValidators,
+			},
+			"organization_ids": schema.SetAttribute{
+				Description: "Only include Applications of these Organizations (internal IDs)",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"application_ids": schema.SetAttribute{
+				Description: "Only include these Applications (internal IDs)",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"trends": schema.ListNestedAttribute{
+				Description: "Violation counts per time period, Organization, policy type and threat level",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"time_period_start": schema.StringAttribute{
+							Description: "Start of the time period",
+							Computed:    true,
+						},
+						"organization_id": schema.StringAttribute{
+							Description: "Internal ID of the Organization",
+							Computed:    true,
+						},
+						"organization_name": schema.StringAttribute{
+							Description: "Name of the Organization",
+							Computed:    true,
+						},
+						"policy_type": schema.StringAttribute{
+							Description: "Policy type, one of SECURITY, LICENSE, QUALITY, OTHER",
+							Computed:    true,
+						},
+						"threat_level": schema.StringAttribute{
+							Description: "Threat level, one of LOW, MODERATE, SEVERE, CRITICAL",
+							Computed:    true,
+						},
+						"discovered": schema.Int64Attribute{
+							Description: "Number of violations discovered in the time period",
+							Computed:    true,
+						},
+						"fixed": schema.Int64Attribute{
+							Description: "Number of violations fixed in the time period",
+							Computed:    true,
+						},
+						"waived": schema.Int64Attribute{
+							Description: "Number of violations waived in the time period",
+							Computed:    true,
+						},
+						"open": schema.Int64Attribute{
+							Description: "Number of violations open at the end of the time period",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *violationTrendsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if deferUnknownOwnerRead(req, resp) {
+		return
+	}
+
+	var data violationTrendsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = context.WithValue(
+		ctx,
+		sonatypeiq.ContextBasicAuth,
+		d.auth,
+	)
+
+	query := sonatypeiq.ApiMetricsReportingQueryDTOV2{
+		TimePeriod:      data.TimePeriod.ValueStringPointer(),
+		FirstTimePeriod: data.FirstTimePeriod.ValueStringPointer(),
+		LastTimePeriod:  data.LastTimePeriod.ValueStringPointer(),
+	}
+	for _, organizationId := range data.OrganizationIds {
+		query.OrganizationIds = append(query.OrganizationIds, organizationId.ValueString())
+	}
+	for _, applicationId := range data.ApplicationIds {
+		query.ApplicationIds = append(query.ApplicationIds, applicationId.ValueString())
+	}
+
+	metrics, api_response, err := getMetrics(ctx, d.client, query)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read IQ success metrics",
+			describeApiError(api_response, err),
+		)
+		return
+	}
+
+	type trendKey struct {
+		timePeriodStart, organizationId, policyType, threatLevel string
+	}
+	trends := map[trendKey]*violationTrendModel{}
+	for _, application := range metrics {
+		for _, aggregation := range application.Aggregations {
+			add := func(counts map[string]map[string]int64, field func(*violationTrendModel) *types.Int64) {
+				for policyType, threatLevels := range counts {
+					for threatLevel, count := range threatLevels {
+						key := trendKey{aggregation.TimePeriodStart, types.StringPointerValue(application.OrganizationId).ValueString(), policyType, threatLevel}
+						trend, ok := trends[key]
+						if !ok {
+							trend = &violationTrendModel{
+								TimePeriodStart:  types.StringValue(key.timePeriodStart),
+								OrganizationId:   types.StringPointerValue(application.OrganizationId),
+								OrganizationName: types.StringPointerValue(application.OrganizationName),
+								PolicyType:       types.StringValue(policyType),
+								ThreatLevel:      types.StringValue(threatLevel),
+								Discovered:       types.Int64Value(0),
+								Fixed:            types.Int64Value(0),
+								Waived:           types.Int64Value(0),
+								Open:             types.Int64Value(0),
+							}
+							trends[key] = trend
+						}
+						*field(trend) = types.Int64Value(field(trend).ValueInt64() + count)
+					}
+				}
+			}
+			add(aggregation.DiscoveredCounts, func(t *violationTrendModel) *types.Int64 { return &t.Discovered })
+			add(aggregation.FixedCounts, func(t *violationTrendModel) *types.Int64 { return &t.Fixed })
+			add(aggregation.WaivedCounts, func(t *violationTrendModel) *types.Int64 { return &t.Waived })
+			add(aggregation.OpenCountsAtTimePeriodEnd, func(t *violationTrendModel) *types.Int64 { return &t.Open })
+		}
+	}
+
+	keys := make([]trendKey, 0, len(trends))
+	for key := range trends {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		if a.timePeriodStart != b.timePeriodStart {
+			return a.timePeriodStart < b.timePeriodStart
+		}
+		if a.organizationId != b.organizationId {
+			return a.organizationId < b.organizationId
+		}
+		if a.policyType != b.policyType {
+			return a.policyType < b.policyType
+		}
+		return a.threatLevel < b.threatLevel
+	})
+
+	data.Trends = make([]violationTrendModel, 0, len(keys))
+	for _, key := range keys {
+		data.Trends = append(data.Trends, *trends[key])
+	}
+
+	data.ID = types.StringValue(data.TimePeriod.ValueString() + "_" + data.FirstTimePeriod.ValueString())
+
+	// Set state
+	diags := resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
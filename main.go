@@ -58,6 +58,11 @@ func main() {
 
 	err := providerserver.Serve(context.Background(), provider.New(version), opts)
 
+	// Terraform is done with the provider once it stops serving
+	if metricsErr := provider.WriteMetrics(); metricsErr != nil {
+		log.Printf("[WARN] %s", metricsErr)
+	}
+
 	if err != nil {
 		log.Fatal(err.Error())
 	}